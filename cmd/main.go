@@ -3,19 +3,52 @@ package main
 import (
 	"context"
 	"fmt"
+	analyticsImpl "github.com/biryanim/workoutbook/internal/api/analytics"
 	authImpl "github.com/biryanim/workoutbook/internal/api/auth"
+	coachImpl "github.com/biryanim/workoutbook/internal/api/coach"
+	idempotencyImpl "github.com/biryanim/workoutbook/internal/api/idempotency"
+	mediaImpl "github.com/biryanim/workoutbook/internal/api/media"
+	plateImpl "github.com/biryanim/workoutbook/internal/api/plate"
+	programImpl "github.com/biryanim/workoutbook/internal/api/program"
+	resolverImpl "github.com/biryanim/workoutbook/internal/api/resolver"
+	searchImpl "github.com/biryanim/workoutbook/internal/api/search"
+	tagImpl "github.com/biryanim/workoutbook/internal/api/tag"
+	userImpl "github.com/biryanim/workoutbook/internal/api/user"
 	workoutImpl "github.com/biryanim/workoutbook/internal/api/workout"
 	"github.com/biryanim/workoutbook/internal/client/db/pg"
 	"github.com/biryanim/workoutbook/internal/client/db/transaction"
 	"github.com/biryanim/workoutbook/internal/config"
 	"github.com/biryanim/workoutbook/internal/config/env"
+	"github.com/biryanim/workoutbook/internal/events"
+	analyticsRepo "github.com/biryanim/workoutbook/internal/repository/analytics"
+	coachRepo "github.com/biryanim/workoutbook/internal/repository/coach"
+	idempotencyRepo "github.com/biryanim/workoutbook/internal/repository/idempotency"
+	mediaRepo "github.com/biryanim/workoutbook/internal/repository/media"
+	plateRepo "github.com/biryanim/workoutbook/internal/repository/plate"
+	programRepo "github.com/biryanim/workoutbook/internal/repository/program"
+	searchRepo "github.com/biryanim/workoutbook/internal/repository/search"
+	tagRepo "github.com/biryanim/workoutbook/internal/repository/tag"
+	templateRepo "github.com/biryanim/workoutbook/internal/repository/template"
 	userRepo "github.com/biryanim/workoutbook/internal/repository/user"
 	workoutRepo "github.com/biryanim/workoutbook/internal/repository/workout"
+	"github.com/biryanim/workoutbook/internal/service/analytics"
 	"github.com/biryanim/workoutbook/internal/service/auth"
+	"github.com/biryanim/workoutbook/internal/service/coach"
+	"github.com/biryanim/workoutbook/internal/service/idempotency"
+	"github.com/biryanim/workoutbook/internal/service/media"
+	"github.com/biryanim/workoutbook/internal/service/plate"
+	"github.com/biryanim/workoutbook/internal/service/program"
+	"github.com/biryanim/workoutbook/internal/service/resolver"
+	"github.com/biryanim/workoutbook/internal/service/search"
+	"github.com/biryanim/workoutbook/internal/service/tag"
+	"github.com/biryanim/workoutbook/internal/service/user"
 	"github.com/biryanim/workoutbook/internal/service/workout"
+	"github.com/biryanim/workoutbook/internal/storage/local"
 	"github.com/gin-gonic/gin"
 	"log"
 	"net/http"
+	"time"
+	_ "time/tzdata"
 )
 
 func main() {
@@ -40,6 +73,18 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to load jwt config: %v", err)
 	}
+	idempotencyConfig, err := env.NewIdempotencyConfig()
+	if err != nil {
+		log.Fatalf("failed to load idempotency config: %v", err)
+	}
+	storageConfig, err := env.NewStorageConfig()
+	if err != nil {
+		log.Fatalf("failed to load storage config: %v", err)
+	}
+	mediaStorage, err := local.New(storageConfig.MediaDir())
+	if err != nil {
+		log.Fatalf("failed to initialize media storage: %v", err)
+	}
 	fmt.Println(pgConfig.DSN())
 	dbClient, err := pg.New(ctx, pgConfig.DSN())
 	if err != nil {
@@ -53,28 +98,162 @@ func main() {
 	txManager := transaction.NewTransactionManager(dbClient.DB())
 	userRepository := userRepo.NewRepository(dbClient)
 	workoutRepository := workoutRepo.NewRepository(dbClient)
+	analyticsRepository := analyticsRepo.NewRepository(dbClient)
+	programRepository := programRepo.NewRepository(dbClient)
+	templateRepository := templateRepo.NewRepository(dbClient)
+	plateRepository := plateRepo.NewRepository(dbClient)
+	idempotencyRepository := idempotencyRepo.NewRepository(dbClient)
+	searchRepository := searchRepo.NewRepository(dbClient)
+	tagRepository := tagRepo.NewRepository(dbClient)
+	mediaRepository := mediaRepo.NewRepository(dbClient)
+	coachRepository := coachRepo.NewRepository(dbClient)
+	sessionBroker := events.NewBroker()
 	authService := auth.NewService(userRepository, txManager, jwtConfig)
-	workoutService := workout.New(workoutRepository, txManager)
+	userService := user.New(userRepository, txManager)
+	workoutService := workout.New(workoutRepository, userRepository, templateRepository, tagRepository, coachRepository, sessionBroker, txManager)
+	analyticsService := analytics.New(analyticsRepository, userRepository, txManager)
+	programService := program.New(programRepository, workoutRepository, userRepository, coachRepository, txManager)
+	plateService := plate.New(plateRepository, workoutRepository, txManager)
+	idempotencyService := idempotency.New(idempotencyRepository, idempotencyConfig.TTL())
+	searchService := search.New(searchRepository, userRepository)
+	tagService := tag.New(tagRepository, workoutRepository, txManager)
+	resolverService := resolver.New(workoutRepository, userRepository)
+	mediaService := media.New(mediaRepository, workoutRepository, userRepository, mediaStorage)
+	coachService := coach.New(coachRepository, userRepository, workoutRepository, programService)
 	authImpl := authImpl.NewImplementation(authService)
+	userImpl := userImpl.NewImplementation(userService)
 	workoutImpl := workoutImpl.NewImplementation(workoutService)
+	analyticsImpl := analyticsImpl.NewImplementation(analyticsService)
+	programImpl := programImpl.NewImplementation(programService)
+	plateImpl := plateImpl.NewImplementation(plateService)
+	idempotencyImpl := idempotencyImpl.NewImplementation(idempotencyService)
+	searchImpl := searchImpl.NewImplementation(searchService)
+	tagImpl := tagImpl.NewImplementation(tagService)
+	mediaImpl := mediaImpl.NewImplementation(mediaService)
+	resolverImpl := resolverImpl.NewImplementation(resolverService)
+	coachImpl := coachImpl.NewImplementation(coachService)
+
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := idempotencyService.PurgeExpired(ctx); err != nil {
+				log.Printf("failed to purge idempotency keys: %v", err)
+			}
+		}
+	}()
 
-	r := gin.Default()
+	r := gin.New()
+	r.Use(authImpl.Logger(), gin.Recovery())
 	public := r.Group("/api")
 	{
 		public.POST("/register", authImpl.Register)
 		public.POST("/login", authImpl.Login)
+		public.GET("/media/:id", mediaImpl.ServeMedia)
+	}
+	stream := r.Group("/api")
+	stream.Use(authImpl.StreamAuthMiddleware())
+	{
+		stream.GET("/workouts/:id/events", workoutImpl.SessionEvents)
 	}
 	protected := r.Group("/api")
-	protected.Use(authImpl.AuthMiddleware())
+	protected.Use(authImpl.AuthMiddleware(), idempotencyImpl.Middleware())
 	{
+		protected.GET("/profile", userImpl.GetProfile)
+		protected.PUT("/profile", userImpl.UpdateProfile)
+		protected.GET("/profile/bodyweight", userImpl.ListBodyWeights)
+		protected.POST("/profile/bodyweight", userImpl.AddBodyWeight)
+		protected.GET("/profile/equipment", userImpl.GetEquipment)
+		protected.PUT("/profile/equipment", userImpl.UpdateEquipment)
+
 		protected.GET("/exercises", workoutImpl.ListExercises)
+		protected.GET("/exercises/:id/strength-standards", workoutImpl.GetStrengthStandards)
+		protected.PUT("/exercises/:id/strength-standards", workoutImpl.SetStrengthStandards)
+		protected.DELETE("/exercises/:id/strength-standards", workoutImpl.DeleteStrengthStandards)
+		protected.GET("/exercises/resolve", resolverImpl.Resolve)
+		protected.GET("/exercises/:id", workoutImpl.GetExercise)
+		protected.GET("/exercises/:id/aliases", resolverImpl.ListAliases)
+		protected.POST("/exercises/:id/aliases", resolverImpl.AddAlias)
+		protected.GET("/exercises/:id/media", mediaImpl.ListMedia)
+		protected.POST("/exercises/:id/media", mediaImpl.UploadMedia)
+		protected.GET("/exercises/:id/next-suggestion", workoutImpl.GetNextSuggestion)
+		protected.GET("/exercises/:id/substitutes", workoutImpl.GetSubstitutes)
+		protected.DELETE("/media/:id", mediaImpl.DeleteMedia)
+		protected.DELETE("/aliases/:id", resolverImpl.DeleteAlias)
+		protected.GET("/equipment", workoutImpl.ListEquipment)
+		protected.GET("/muscles", workoutImpl.ListMuscles)
+
+		protected.POST("/templates", workoutImpl.CreateTemplate)
+		protected.GET("/templates", workoutImpl.ListTemplates)
+		protected.GET("/templates/:id", workoutImpl.GetTemplate)
+		protected.POST("/templates/:id/workouts", workoutImpl.InstantiateTemplate)
+		protected.POST("/templates/:id/exercises/:entry_id/swap", workoutImpl.SwapTemplateExercise)
 
 		protected.POST("/workouts", workoutImpl.CreateWorkout)
 		protected.GET("/workouts", workoutImpl.ListWorkouts)
+		protected.POST("/workouts/import", workoutImpl.ImportActivity)
+		protected.GET("/import-mappings", workoutImpl.ListExerciseMappings)
+		protected.PUT("/import-mappings/:source/:name", workoutImpl.SetExerciseMapping)
+		protected.DELETE("/import-mappings/:source/:name", workoutImpl.DeleteExerciseMapping)
 		protected.GET("/workouts/:id", workoutImpl.GetWorkout)
+		protected.PATCH("/workouts/:id", workoutImpl.UpdateWorkout)
 		protected.POST("/workouts/:id/exercises", workoutImpl.AddExerciseToWorkout)
+		protected.POST("/workouts/:id/start", workoutImpl.StartSession)
+		protected.POST("/workouts/:id/sets", workoutImpl.LogSet)
+		protected.POST("/workouts/:id/finish", workoutImpl.FinishSession)
+		protected.PUT("/workouts/:id/exercises/order", workoutImpl.ReorderExercises)
+		protected.POST("/workouts/:id/warmup", plateImpl.InsertWarmUp)
+		protected.PUT("/workouts/:id/tags", tagImpl.SetWorkoutTags)
+		protected.GET("/workouts/:id/comments", workoutImpl.ListComments)
+		protected.POST("/workouts/:id/comments", workoutImpl.AddComment)
+
+		protected.POST("/tags", tagImpl.CreateTag)
+		protected.GET("/tags", tagImpl.ListTags)
+		protected.GET("/tags/usage", tagImpl.GetTagUsage)
+		protected.GET("/tags/:id", tagImpl.GetTag)
+		protected.PATCH("/tags/:id", tagImpl.UpdateTag)
+		protected.DELETE("/tags/:id", tagImpl.DeleteTag)
+
+		protected.GET("/plates", plateImpl.GetInventory)
+		protected.PUT("/plates", plateImpl.UpdateInventory)
+		protected.GET("/plates/calculate", plateImpl.CalculateLoading)
+		protected.GET("/plates/warmup", plateImpl.GenerateWarmUp)
 
 		protected.GET("/records", workoutImpl.GetPersonalRecords)
+
+		protected.GET("/search", searchImpl.Search)
+
+		protected.POST("/sync", workoutImpl.Sync)
+
+		protected.GET("/analytics/muscle-volume", analyticsImpl.GetMuscleVolume)
+		protected.GET("/analytics/training-load", analyticsImpl.GetTrainingLoad)
+		protected.GET("/stats/calendar", analyticsImpl.GetCalendar)
+		protected.GET("/stats/summary", analyticsImpl.GetSummary)
+
+		protected.POST("/programs", programImpl.CreateProgram)
+		protected.GET("/programs", programImpl.ListPrograms)
+		protected.GET("/programs/generators", programImpl.ListGenerators)
+		protected.POST("/programs/generate", programImpl.GenerateProgram)
+		protected.GET("/programs/:id", programImpl.GetProgram)
+		protected.POST("/programs/:id/enroll", programImpl.Enroll)
+		protected.GET("/enrollments", programImpl.ListEnrollments)
+		protected.GET("/enrollments/:id/schedule", programImpl.GetSchedule)
+		protected.POST("/enrollments/:id/sessions", programImpl.LinkWorkout)
+		protected.GET("/enrollments/:id/adherence", programImpl.GetAdherence)
+		protected.POST("/enrollments/:id/exercises/:entry_id/swap", programImpl.SwapExercise)
+
+		protected.GET("/coach/dashboard", coachImpl.GetDashboard)
+		protected.GET("/coach/athletes", coachImpl.ListAthletes)
+		protected.POST("/coach/athletes", coachImpl.InviteAthlete)
+		protected.DELETE("/coach/athletes/:id", coachImpl.RemoveAthlete)
+		protected.DELETE("/coach/invitations/:id", coachImpl.WithdrawInvitation)
+		protected.GET("/coach/athletes/:id/workouts", workoutImpl.ListAthleteWorkouts)
+		protected.POST("/coach/athletes/:id/workouts", workoutImpl.AssignWorkout)
+		protected.POST("/coach/athletes/:id/enrollments", programImpl.AssignProgram)
+		protected.GET("/coaches", coachImpl.ListCoaches)
+		protected.POST("/coaches/:id/accept", coachImpl.AcceptCoach)
+		protected.PATCH("/coaches/:id", coachImpl.UpdateCoach)
+		protected.DELETE("/coaches/:id", coachImpl.RemoveCoach)
 	}
 
 	r.Static("/static", "./static")
@@ -0,0 +1,125 @@
+package analytics
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/converter"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type Implementation struct {
+	analyticsService service.AnalyticsService
+}
+
+func NewImplementation(analyticsService service.AnalyticsService) *Implementation {
+	return &Implementation{analyticsService: analyticsService}
+}
+
+func (i *Implementation) GetMuscleVolume(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	query := dto.MuscleVolumeQuery{
+		StartDate:       c.Query("start_date"),
+		EndDate:         c.Query("end_date"),
+		SecondaryWeight: c.Query("secondary_weight"),
+		MinSets:         c.Query("min_sets"),
+		MaxSets:         c.Query("max_sets"),
+		TagQuery:        dto.TagQuery{Tag: c.Query("tag"), ExcludeTag: c.Query("exclude_tag")},
+	}
+
+	filter, err := converter.FromMuscleVolumeQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := i.analyticsService.GetMuscleVolume(c.Request.Context(), userID, filter)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToMuscleVolumeResp(report))
+}
+
+func (i *Implementation) GetCalendar(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	year, err := converter.FromCalendarYear(c.Query("year"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tags, err := converter.FromTagQuery(&dto.TagQuery{Tag: c.Query("tag"), ExcludeTag: c.Query("exclude_tag")})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	calendar, err := i.analyticsService.GetCalendar(c.Request.Context(), userID, year, tags)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToCalendarResp(calendar))
+}
+
+func (i *Implementation) GetSummary(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	tags, err := converter.FromTagQuery(&dto.TagQuery{Tag: c.Query("tag"), ExcludeTag: c.Query("exclude_tag")})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	summary, err := i.analyticsService.GetSummary(c.Request.Context(), userID, tags)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToStatsSummaryResp(summary))
+}
+
+func (i *Implementation) GetTrainingLoad(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	query := dto.TrainingLoadQuery{
+		StartDate:         c.Query("start_date"),
+		EndDate:           c.Query("end_date"),
+		Source:            c.Query("source"),
+		ACWRThreshold:     c.Query("acwr_threshold"),
+		MonotonyThreshold: c.Query("monotony_threshold"),
+		StrainThreshold:   c.Query("strain_threshold"),
+		TagQuery:          dto.TagQuery{Tag: c.Query("tag"), ExcludeTag: c.Query("exclude_tag")},
+	}
+
+	filter, err := converter.FromTrainingLoadQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := i.analyticsService.GetTrainingLoad(c.Request.Context(), userID, filter)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTrainingLoadResp(report))
+}
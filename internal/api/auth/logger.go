@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Logger logs the requests like gin's default logger but with the token of
+// the event streams redacted from the query.
+func (i *Implementation) Logger() gin.HandlerFunc {
+	return gin.LoggerWithFormatter(func(p gin.LogFormatterParams) string {
+		return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v\n%s",
+			p.TimeStamp.Format("2006/01/02 - 15:04:05"),
+			p.StatusCode,
+			p.Latency,
+			p.ClientIP,
+			p.Method,
+			redactToken(p.Path),
+			p.ErrorMessage,
+		)
+	})
+}
+
+// redactToken replaces the access_token query parameter of path, if any.
+func redactToken(path string) string {
+	base, rawQuery, ok := strings.Cut(path, "?")
+	if !ok {
+		return path
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return base
+	}
+	if !query.Has(accessTokenParam) {
+		return path
+	}
+	query.Set(accessTokenParam, "REDACTED")
+
+	return base + "?" + query.Encode()
+}
@@ -12,7 +12,8 @@ import (
 )
 
 const (
-	auth = "Bearer "
+	auth             = "Bearer "
+	accessTokenParam = "access_token"
 )
 
 type Implementation struct {
@@ -68,9 +69,26 @@ func (i *Implementation) Login(c *gin.Context) {
 	})
 }
 
+// AuthMiddleware authenticates the request by the bearer token in the
+// Authorization header.
 func (i *Implementation) AuthMiddleware() gin.HandlerFunc {
+	return i.authenticate(false)
+}
+
+// StreamAuthMiddleware also takes the token from the access_token query
+// parameter, as EventSource cannot set headers. It is meant for the event
+// streams only, so the token does not end up in the URLs of other requests;
+// Logger keeps it out of the access log.
+func (i *Implementation) StreamAuthMiddleware() gin.HandlerFunc {
+	return i.authenticate(true)
+}
+
+func (i *Implementation) authenticate(queryToken bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token := c.GetHeader("Authorization")
+		if len(token) == 0 && queryToken {
+			token = c.Query(accessTokenParam)
+		}
 		if len(token) == 0 {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"code":    http.StatusUnauthorized,
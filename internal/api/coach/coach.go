@@ -0,0 +1,193 @@
+package coach
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/converter"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type Implementation struct {
+	coachService service.CoachService
+}
+
+func NewImplementation(coachService service.CoachService) *Implementation {
+	return &Implementation{coachService: coachService}
+}
+
+func (i *Implementation) InviteAthlete(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	var req dto.InviteAthleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	params, err := converter.FromInviteAthleteRequest(userID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	coaching, err := i.coachService.InviteAthlete(c.Request.Context(), params)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.ToCoachingResp(coaching))
+}
+
+func (i *Implementation) ListAthletes(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	athletes, err := i.coachService.ListAthletes(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToCoachingsResp(athletes))
+}
+
+func (i *Implementation) RemoveAthlete(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	athleteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err = i.coachService.RemoveAthlete(c.Request.Context(), userID, athleteID); err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"athlete_id": athleteID})
+}
+
+func (i *Implementation) WithdrawInvitation(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	coachingID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err = i.coachService.WithdrawInvitation(c.Request.Context(), userID, coachingID); err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitation_id": coachingID})
+}
+
+func (i *Implementation) GetDashboard(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	summaries, err := i.coachService.GetDashboard(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToDashboardResp(summaries))
+}
+
+func (i *Implementation) ListCoaches(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	coaches, err := i.coachService.ListCoaches(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToCoachingsResp(coaches))
+}
+
+func (i *Implementation) AcceptCoach(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	coachID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	coaching, err := i.coachService.AcceptCoach(c.Request.Context(), userID, coachID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToCoachingResp(coaching))
+}
+
+func (i *Implementation) UpdateCoach(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	coachID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.UpdateCoachRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	params, err := converter.FromUpdateCoachRequest(userID, coachID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	coaching, err := i.coachService.UpdateCoach(c.Request.Context(), params)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToCoachingResp(coaching))
+}
+
+func (i *Implementation) RemoveCoach(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	coachID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err = i.coachService.RemoveCoach(c.Request.Context(), userID, coachID); err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"coach_id": coachID})
+}
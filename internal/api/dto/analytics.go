@@ -0,0 +1,97 @@
+package dto
+
+import "time"
+
+type MuscleVolumeQuery struct {
+	StartDate       string `json:"start_date"`
+	EndDate         string `json:"end_date"`
+	SecondaryWeight string `json:"secondary_weight"`
+	MinSets         string `json:"min_sets"`
+	MaxSets         string `json:"max_sets"`
+	TagQuery
+}
+
+type MuscleGroupVolume struct {
+	MuscleGroup string  `json:"muscle_group"`
+	Sets        float64 `json:"sets"`
+	Reps        float64 `json:"reps"`
+	Tonnage     float64 `json:"tonnage"`
+	Status      string  `json:"status"`
+}
+
+type WeeklyMuscleVolume struct {
+	WeekStart    time.Time            `json:"week_start"`
+	MuscleGroups []*MuscleGroupVolume `json:"muscle_groups"`
+}
+
+type MuscleVolumeReport struct {
+	StartDate time.Time             `json:"start_date"`
+	EndDate   time.Time             `json:"end_date"`
+	MinSets   float64               `json:"min_sets"`
+	MaxSets   float64               `json:"max_sets"`
+	Weeks     []*WeeklyMuscleVolume `json:"weeks"`
+	Neglected []string              `json:"neglected"`
+}
+
+type CalendarDay struct {
+	Date     string  `json:"date"`
+	Workouts int     `json:"workouts"`
+	Volume   float64 `json:"volume"`
+}
+
+type Calendar struct {
+	Year          int            `json:"year"`
+	Timezone      string         `json:"timezone"`
+	TotalWorkouts int            `json:"total_workouts"`
+	TotalVolume   float64        `json:"total_volume"`
+	Days          []*CalendarDay `json:"days"`
+}
+
+type ExerciseUsage struct {
+	ExerciseID int64  `json:"exercise_id"`
+	Name       string `json:"name"`
+	Workouts   int    `json:"workouts"`
+	Sets       int    `json:"sets"`
+}
+
+type StatsSummary struct {
+	Timezone                      string           `json:"timezone"`
+	CurrentWeeklyStreak           int              `json:"current_weekly_streak"`
+	LongestWeeklyStreak           int              `json:"longest_weekly_streak"`
+	WorkoutsThisWeek              int              `json:"workouts_this_week"`
+	WorkoutsThisMonth             int              `json:"workouts_this_month"`
+	WorkoutsThisYear              int              `json:"workouts_this_year"`
+	AverageSessionDurationSeconds int64            `json:"average_session_duration_seconds"`
+	TopExercises                  []*ExerciseUsage `json:"top_exercises"`
+}
+
+type TrainingLoadQuery struct {
+	StartDate         string `json:"start_date"`
+	EndDate           string `json:"end_date"`
+	Source            string `json:"source"`
+	ACWRThreshold     string `json:"acwr_threshold"`
+	MonotonyThreshold string `json:"monotony_threshold"`
+	StrainThreshold   string `json:"strain_threshold"`
+	TagQuery
+}
+
+type TrainingLoadDay struct {
+	Date        string   `json:"date"`
+	Load        float64  `json:"load"`
+	AcuteLoad   float64  `json:"acute_load"`
+	ChronicLoad float64  `json:"chronic_load"`
+	ACWR        float64  `json:"acwr"`
+	Monotony    float64  `json:"monotony"`
+	Strain      float64  `json:"strain"`
+	Flags       []string `json:"flags,omitempty"`
+}
+
+type TrainingLoadReport struct {
+	Source            string             `json:"source"`
+	Timezone          string             `json:"timezone"`
+	ACWRThreshold     float64            `json:"acwr_threshold"`
+	MonotonyThreshold float64            `json:"monotony_threshold"`
+	StrainThreshold   float64            `json:"strain_threshold"`
+	Days              []*TrainingLoadDay `json:"days"`
+	Spikes            []*TrainingLoadDay `json:"spikes"`
+}
@@ -0,0 +1,67 @@
+package dto
+
+import "time"
+
+type Equipment struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type Muscle struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	MuscleGroup string `json:"muscle_group"`
+}
+
+// ExerciseMedia is an instruction image or video; URL serves its file.
+type ExerciseMedia struct {
+	ID          int64     `json:"id"`
+	ExerciseID  int64     `json:"exercise_id"`
+	Kind        string    `json:"kind"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	URL         string    `json:"url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type ExerciseAlias struct {
+	ID         int64     `json:"id"`
+	ExerciseID int64     `json:"exercise_id"`
+	Alias      string    `json:"alias"`
+	BuiltIn    bool      `json:"built_in"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AddAliasRequest struct {
+	Alias string `json:"alias" binding:"required,max=100"`
+}
+
+type ResolveQuery struct {
+	Query string `json:"q"`
+	Limit string `json:"limit"`
+}
+
+// ExerciseCandidate is an exercise the resolved name may refer to; Matched is
+// the name or alias it matched by and Score its similarity, from 0 to 1.
+type ExerciseCandidate struct {
+	Exercise *Exercise `json:"exercise"`
+	Matched  string    `json:"matched"`
+	Score    float64   `json:"score"`
+}
+
+// Substitute is an alternative to an exercise; SharedMuscles counts the
+// primary muscles both work.
+type Substitute struct {
+	Exercise      *Exercise `json:"exercise"`
+	SharedMuscles int       `json:"shared_muscles"`
+	SamePattern   bool      `json:"same_pattern"`
+	Score         float64   `json:"score"`
+}
+
+type SwapExerciseRequest struct {
+	ExerciseID int64 `json:"exercise_id" binding:"required"`
+}
+
+type UpdateEquipmentRequest struct {
+	Equipment []string `json:"equipment" binding:"max=20,dive,min=1,max=30"`
+}
@@ -0,0 +1,65 @@
+package dto
+
+import "time"
+
+type InviteAthleteRequest struct {
+	Email      string `json:"email" binding:"required,email"`
+	Permission string `json:"permission" binding:"required"`
+}
+
+type UpdateCoachRequest struct {
+	Permission string `json:"permission" binding:"required"`
+}
+
+// CoachingUser is either side of a coaching relationship. An invited athlete
+// is known by the email only.
+type CoachingUser struct {
+	ID    int64  `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email"`
+}
+
+type Coaching struct {
+	ID         int64         `json:"id"`
+	Coach      *CoachingUser `json:"coach"`
+	Athlete    *CoachingUser `json:"athlete"`
+	Permission string        `json:"permission"`
+	Status     string        `json:"status"`
+	CreatedAt  time.Time     `json:"created_at"`
+	AcceptedAt *time.Time    `json:"accepted_at,omitempty"`
+}
+
+// AssignProgramRequest enrolls an athlete in a program of the coach.
+type AssignProgramRequest struct {
+	ProgramID int64 `json:"program_id" binding:"required"`
+	EnrollRequest
+}
+
+type AddCommentRequest struct {
+	Body string `json:"body" binding:"required,max=2000"`
+}
+
+type WorkoutComment struct {
+	ID         int64     `json:"id"`
+	WorkoutID  int64     `json:"workout_id"`
+	AuthorID   int64     `json:"author_id"`
+	AuthorName string    `json:"author_name"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AthleteAdherence is the adherence to an active program without the
+// sessions, which GET /enrollments/:id/adherence lists to the athlete.
+type AthleteAdherence struct {
+	EnrollmentID int64   `json:"enrollment_id"`
+	Planned      int     `json:"planned"`
+	Completed    int     `json:"completed"`
+	Rate         float64 `json:"rate"`
+}
+
+type AthleteSummary struct {
+	Athlete        *CoachingUser       `json:"athlete"`
+	Permission     string              `json:"permission"`
+	RecentWorkouts []*Workout          `json:"recent_workouts"`
+	Adherence      []*AthleteAdherence `json:"adherence"`
+}
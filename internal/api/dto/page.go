@@ -0,0 +1,9 @@
+package dto
+
+// Page is the envelope of every list response. NextCursor is set when more
+// items follow and is passed back as the cursor query parameter.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int64  `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
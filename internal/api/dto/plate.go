@@ -0,0 +1,40 @@
+package dto
+
+type PlateCount struct {
+	Weight float64 `json:"weight" binding:"required,gt=0,lte=100"`
+	Count  int     `json:"count" binding:"required,min=1,max=100"`
+}
+
+type PlateInventory struct {
+	Unit      string        `json:"unit" binding:"required,oneof=kg lb"`
+	BarWeight float64       `json:"bar_weight" binding:"gte=0,lte=100"`
+	Plates    []*PlateCount `json:"plates" binding:"max=30,dive"`
+}
+
+type PlateLoadQuery struct {
+	Weight    string `json:"weight"`
+	Unit      string `json:"unit"`
+	BarWeight string `json:"bar_weight"`
+}
+
+type PlateLoading struct {
+	Unit      string        `json:"unit"`
+	Target    float64       `json:"target"`
+	BarWeight float64       `json:"bar_weight"`
+	Achieved  float64       `json:"achieved"`
+	PerSide   []*PlateCount `json:"per_side"`
+	Exact     bool          `json:"exact"`
+}
+
+type WarmUpRequest struct {
+	ExerciseID    int64    `json:"exercise_id" binding:"required"`
+	WorkingWeight float64  `json:"working_weight" binding:"required,gt=0,lt=1000"`
+	Unit          string   `json:"unit" binding:"omitempty,oneof=kg lb"`
+	BarWeight     *float64 `json:"bar_weight" binding:"omitempty,gte=0,lte=100"`
+}
+
+type WarmUpSet struct {
+	Percent float64       `json:"percent"`
+	Reps    int           `json:"reps"`
+	Loading *PlateLoading `json:"loading"`
+}
@@ -0,0 +1,102 @@
+package dto
+
+import "time"
+
+type ProgramExercise struct {
+	ExerciseID int64     `json:"exercise_id" binding:"required"`
+	Exercise   *Exercise `json:"exercise,omitempty"`
+	Sets       int       `json:"sets" binding:"required,min=1,max=20"`
+	Reps       int       `json:"reps" binding:"min=0,max=100"`
+	Type       string    `json:"prescription_type" binding:"required,oneof=percent_tm rpe fixed"`
+	Value      float64   `json:"prescription_value" binding:"gte=0,lte=1000"`
+	AMRAP      bool      `json:"amrap,omitempty"`
+	Notes      string    `json:"notes,omitempty" binding:"max=500"`
+}
+
+type ProgramDay struct {
+	Week      int                `json:"week" binding:"required,min=1,max=52"`
+	Day       int                `json:"day" binding:"required,min=1,max=7"`
+	Name      string             `json:"name" binding:"max=100"`
+	Exercises []*ProgramExercise `json:"exercises" binding:"dive"`
+}
+
+type Program struct {
+	ID          int64         `json:"id"`
+	Name        string        `json:"name" binding:"required,min=1,max=100"`
+	Description string        `json:"description"`
+	Weeks       int           `json:"weeks" binding:"required,min=1,max=52"`
+	Shared      bool          `json:"shared"`
+	Generator   string        `json:"generator,omitempty"`
+	Days        []*ProgramDay `json:"days,omitempty" binding:"dive"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+type TrainingMax struct {
+	ExerciseID  int64   `json:"exercise_id" binding:"required"`
+	TrainingMax float64 `json:"training_max" binding:"required,gt=0,lt=1000"`
+}
+
+type EnrollRequest struct {
+	StartDate     string         `json:"start_date" binding:"required"`
+	TrainingMaxes []*TrainingMax `json:"training_maxes" binding:"dive"`
+}
+
+type Enrollment struct {
+	ID        int64  `json:"id"`
+	ProgramID int64  `json:"program_id"`
+	StartDate string `json:"start_date"`
+	Active    bool   `json:"active"`
+}
+
+type LinkSessionRequest struct {
+	WorkoutID int64  `json:"workout_id" binding:"required"`
+	Date      string `json:"date" binding:"required"`
+}
+
+// ScheduledExercise is a prescription of a planned session. ProgramExerciseID
+// addresses it when swapping the exercise; ReplacedExerciseID is set once it
+// is swapped.
+type ScheduledExercise struct {
+	ProgramExerciseID  int64    `json:"program_exercise_id"`
+	ExerciseID         int64    `json:"exercise_id"`
+	ReplacedExerciseID *int64   `json:"replaced_exercise_id,omitempty"`
+	Name               string   `json:"name"`
+	Sets               int      `json:"sets"`
+	Reps               int      `json:"reps"`
+	Type               string   `json:"prescription_type"`
+	Value              float64  `json:"prescription_value"`
+	Weight             *float64 `json:"weight,omitempty"`
+	TargetRPE          *float64 `json:"target_rpe,omitempty"`
+	AMRAP              bool     `json:"amrap,omitempty"`
+	Notes              string   `json:"notes,omitempty"`
+}
+
+type ScheduledSession struct {
+	Date      string               `json:"date"`
+	Week      int                  `json:"week"`
+	Day       int                  `json:"day"`
+	Name      string               `json:"name"`
+	WorkoutID *int64               `json:"workout_id,omitempty"`
+	Exercises []*ScheduledExercise `json:"exercises"`
+}
+
+type Adherence struct {
+	EnrollmentID int64               `json:"enrollment_id"`
+	Planned      int                 `json:"planned"`
+	Completed    int                 `json:"completed"`
+	Rate         float64             `json:"rate"`
+	Sessions     []*ScheduledSession `json:"sessions"`
+}
+
+type Generator struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	DefaultWeeks int    `json:"default_weeks"`
+}
+
+type GenerateProgramRequest struct {
+	Generator string         `json:"generator" binding:"required"`
+	Weeks     int            `json:"weeks" binding:"omitempty,min=1,max=52"`
+	StartDate string         `json:"start_date"`
+	Failures  map[string]int `json:"failures" binding:"omitempty,dive,keys,oneof=squat bench deadlift ohp,endkeys,min=0,max=10"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+type SearchQuery struct {
+	Query string `json:"q"`
+	Limit string `json:"limit"`
+}
+
+// SearchResult is one match; Snippet is HTML: the stored text escaped, with
+// the matched words wrapped in <mark></mark>.
+type SearchResult struct {
+	Kind      string     `json:"kind"`
+	ID        int64      `json:"id"`
+	WorkoutID *int64     `json:"workout_id,omitempty"`
+	Title     string     `json:"title"`
+	Snippet   string     `json:"snippet"`
+	Rank      float64    `json:"rank"`
+	Date      *time.Time `json:"date,omitempty"`
+}
@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+type SyncWorkoutData struct {
+	Date       time.Time  `json:"date" binding:"required"`
+	Name       string     `json:"name" binding:"required,max=100"`
+	Notes      string     `json:"notes"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	EndedAt    *time.Time `json:"ended_at,omitempty"`
+	SessionRPE *float64   `json:"session_rpe,omitempty" binding:"omitempty,min=0,max=10"`
+}
+
+type SyncEntryData struct {
+	WorkoutUUID   string     `json:"workout_uuid" binding:"required,uuid"`
+	ExerciseID    int64      `json:"exercise_id" binding:"required,min=1"`
+	Sets          int        `json:"sets" binding:"min=0"`
+	Reps          int        `json:"reps" binding:"min=0"`
+	Weight        float64    `json:"weight"`
+	Duration      int        `json:"duration" binding:"min=0"`
+	Distance      float64    `json:"distance" binding:"min=0"`
+	RPE           *float64   `json:"rpe,omitempty" binding:"omitempty,min=1,max=10"`
+	RIR           *int       `json:"rir,omitempty" binding:"omitempty,min=0,max=10"`
+	TargetReps    *int       `json:"target_reps,omitempty" binding:"omitempty,min=1,max=100"`
+	IsWarmUp      bool       `json:"is_warmup,omitempty"`
+	Position      int        `json:"position" binding:"min=0"`
+	GroupID       *int       `json:"group_id,omitempty" binding:"omitempty,min=1,max=100"`
+	CompletedAt   *time.Time `json:"completed_at,omitempty"`
+	RestSeconds   *int       `json:"rest_seconds,omitempty" binding:"omitempty,min=0"`
+	AvgHeartRate  *int       `json:"avg_heart_rate,omitempty" binding:"omitempty,min=20,max=250"`
+	MaxHeartRate  *int       `json:"max_heart_rate,omitempty" binding:"omitempty,min=20,max=250"`
+	ElevationGain *float64   `json:"elevation_gain,omitempty" binding:"omitempty,min=0"`
+}
+
+// SyncChange is one edit queued on the client. Upserts carry the full state
+// of the workout or entry.
+type SyncChange struct {
+	Entity          string           `json:"entity" binding:"required,oneof=workout workout_exercise"`
+	Op              string           `json:"op" binding:"required,oneof=upsert delete"`
+	UUID            string           `json:"uuid" binding:"required,uuid"`
+	ClientUpdatedAt time.Time        `json:"client_updated_at" binding:"required"`
+	Workout         *SyncWorkoutData `json:"workout,omitempty"`
+	Entry           *SyncEntryData   `json:"entry,omitempty"`
+}
+
+func (c *SyncChange) Validate() error {
+	if c.Op != "upsert" {
+		return nil
+	}
+	if c.Entity == "workout" && c.Workout == nil {
+		return errors.New("workout upsert requires workout")
+	}
+	if c.Entity == "workout_exercise" && c.Entry == nil {
+		return errors.New("workout_exercise upsert requires entry")
+	}
+	return nil
+}
+
+// SyncRequest sends the queued changes; Cursor is the opaque cursor of the
+// previous sync, empty on the first one.
+type SyncRequest struct {
+	Cursor  string        `json:"cursor"`
+	Limit   int           `json:"limit" binding:"omitempty,min=1,max=1000"`
+	Changes []*SyncChange `json:"changes" binding:"max=500,dive"`
+}
+
+func (r *SyncRequest) Validate() error {
+	for _, c := range r.Changes {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type SyncWorkout struct {
+	ID              int64     `json:"id"`
+	UUID            string    `json:"uuid"`
+	ClientUpdatedAt time.Time `json:"client_updated_at"`
+	SyncWorkoutData
+}
+
+type SyncEntry struct {
+	ID              int64     `json:"id"`
+	UUID            string    `json:"uuid"`
+	ClientUpdatedAt time.Time `json:"client_updated_at"`
+	SyncEntryData
+}
+
+type SyncTombstone struct {
+	Entity    string    `json:"entity"`
+	UUID      string    `json:"uuid"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// SyncResult reports what happened to a change. On a conflict it holds the
+// version the server kept.
+type SyncResult struct {
+	Entity  string       `json:"entity"`
+	UUID    string       `json:"uuid"`
+	Status  string       `json:"status"`
+	Error   string       `json:"error,omitempty"`
+	Workout *SyncWorkout `json:"workout,omitempty"`
+	Entry   *SyncEntry   `json:"entry,omitempty"`
+}
+
+type SyncResponse struct {
+	Results  []*SyncResult    `json:"results"`
+	Workouts []*SyncWorkout   `json:"workouts"`
+	Entries  []*SyncEntry     `json:"entries"`
+	Deleted  []*SyncTombstone `json:"deleted"`
+	Cursor   string           `json:"cursor"`
+	HasMore  bool             `json:"has_more"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+var hexColorRe = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// TagQuery filters by tags given as comma separated ids: Tag keeps workouts
+// with any of the tags, ExcludeTag drops workouts with any of them.
+type TagQuery struct {
+	Tag        string `json:"tag"`
+	ExcludeTag string `json:"exclude_tag"`
+}
+
+type Tag struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Color     *string   `json:"color,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateTagRequest struct {
+	Name  string `json:"name" binding:"required,min=1,max=50"`
+	Color string `json:"color,omitempty" binding:"omitempty,hexcolor,max=7"`
+}
+
+// UpdateTagRequest changes the fields present; an empty color clears it.
+type UpdateTagRequest struct {
+	Name  *string `json:"name,omitempty" binding:"omitempty,min=1,max=50"`
+	Color *string `json:"color,omitempty" binding:"omitempty,max=7"`
+}
+
+func (r *UpdateTagRequest) Validate() error {
+	if r.Color != nil && len(*r.Color) != 0 && !hexColorRe.MatchString(*r.Color) {
+		return errors.New("color must be a hex color like #ff8800")
+	}
+	return nil
+}
+
+type SetWorkoutTagsRequest struct {
+	TagIDs []int64 `json:"tag_ids" binding:"max=20,dive,min=1"`
+}
+
+type TagUsage struct {
+	Tag       *Tag       `json:"tag"`
+	Workouts  int        `json:"workouts"`
+	Sets      int        `json:"sets"`
+	Volume    float64    `json:"volume"`
+	FirstUsed *time.Time `json:"first_used,omitempty"`
+	LastUsed  *time.Time `json:"last_used,omitempty"`
+}
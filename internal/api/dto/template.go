@@ -0,0 +1,56 @@
+package dto
+
+import "time"
+
+type TemplateExercise struct {
+	ID         int64     `json:"id,omitempty"`
+	ExerciseID int64     `json:"exercise_id" binding:"required"`
+	Exercise   *Exercise `json:"exercise,omitempty"`
+	Sets       int       `json:"sets" binding:"required,min=1,max=20"`
+	Reps       int       `json:"reps" binding:"min=0,max=100"`
+	Weight     *float64  `json:"weight,omitempty" binding:"omitempty,gte=0,lt=1000"`
+	Notes      string    `json:"notes,omitempty" binding:"max=500"`
+}
+
+type WorkoutTemplate struct {
+	ID        int64               `json:"id"`
+	Name      string              `json:"name" binding:"required,min=1,max=100"`
+	Notes     string              `json:"notes"`
+	Exercises []*TemplateExercise `json:"exercises,omitempty" binding:"max=50,dive"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+type InstantiateTemplateRequest struct {
+	Name string     `json:"name" binding:"max=100"`
+	Date *time.Time `json:"date"`
+}
+
+type SessionSummary struct {
+	WorkoutID  int64     `json:"workout_id"`
+	Date       time.Time `json:"date"`
+	Sets       int       `json:"sets"`
+	Reps       int       `json:"reps"`
+	Weight     float64   `json:"weight"`
+	TargetReps int       `json:"target_reps,omitempty"`
+}
+
+type ProgressionSuggestion struct {
+	ExerciseID        int64           `json:"exercise_id"`
+	Action            string          `json:"action"`
+	Sets              int             `json:"sets,omitempty"`
+	Reps              int             `json:"reps,omitempty"`
+	Weight            float64         `json:"weight"`
+	Increment         float64         `json:"increment"`
+	ConsecutiveMisses int             `json:"consecutive_misses"`
+	Last              *SessionSummary `json:"last,omitempty"`
+}
+
+type PlannedExercise struct {
+	TemplateExercise
+	Suggestion *ProgressionSuggestion `json:"suggestion"`
+}
+
+type TemplateWorkout struct {
+	WorkoutID int64              `json:"workout_id"`
+	Exercises []*PlannedExercise `json:"exercises"`
+}
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type UserRegisterRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Username string `json:"username" binding:"required,min=2,max=20"`
@@ -18,3 +20,24 @@ type UserLoginRequest struct {
 type UserLoginResponse struct {
 	Token string `json:"token"`
 }
+
+type ProfileResponse struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Timezone string `json:"timezone"`
+	Sex      string `json:"sex,omitempty"`
+	Locale   string `json:"locale,omitempty"`
+}
+
+type UpdateProfileRequest struct {
+	Timezone *string `json:"timezone" binding:"omitempty,max=64"`
+	Sex      *string `json:"sex" binding:"omitempty,oneof=male female"`
+	Locale   *string `json:"locale" binding:"omitempty,oneof=en ru"`
+}
+
+type BodyWeight struct {
+	ID         int64     `json:"id"`
+	Weight     float64   `json:"weight" binding:"required,gt=0,lt=1000"`
+	MeasuredAt time.Time `json:"measured_at"`
+}
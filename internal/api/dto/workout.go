@@ -1,16 +1,54 @@
 package dto
 
-import "time"
+import (
+	"errors"
+	"time"
+)
 
 type Workout struct {
-	ID     int64     `json:"id"`
-	UserId int64     `json:"-"`
-	Date   time.Time `json:"date"`
-	Note   string    `json:"notes"`
-	Name   string    `json:"name"`
+	ID              int64      `json:"id"`
+	UUID            string     `json:"uuid,omitempty"`
+	UserId          int64      `json:"-"`
+	Date            time.Time  `json:"date"`
+	Note            string     `json:"notes"`
+	Name            string     `json:"name"`
+	StartedAt       *time.Time `json:"started_at,omitempty"`
+	EndedAt         *time.Time `json:"ended_at,omitempty"`
+	SessionRPE      *float64   `json:"session_rpe,omitempty" binding:"omitempty,min=0,max=10"`
+	DurationSeconds *int64     `json:"duration_seconds,omitempty"`
+	AssignedBy      *int64     `json:"assigned_by,omitempty"`
+	Tags            []*Tag     `json:"tags,omitempty"`
+}
+
+func (w *Workout) Validate() error {
+	if w.EndedAt != nil && w.StartedAt == nil {
+		return errors.New("ended_at requires started_at")
+	}
+	if w.EndedAt != nil && w.EndedAt.Before(*w.StartedAt) {
+		return errors.New("ended_at must not be before started_at")
+	}
+	return nil
+}
+
+type UpdateWorkoutRequest struct {
+	Date       *time.Time `json:"date"`
+	Name       *string    `json:"name" binding:"omitempty,min=1,max=100"`
+	Note       *string    `json:"notes"`
+	StartedAt  *time.Time `json:"started_at"`
+	EndedAt    *time.Time `json:"ended_at"`
+	SessionRPE *float64   `json:"session_rpe" binding:"omitempty,min=0,max=10"`
+}
+
+func (r *UpdateWorkoutRequest) Validate() error {
+	if r.StartedAt != nil && r.EndedAt != nil && r.EndedAt.Before(*r.StartedAt) {
+		return errors.New("ended_at must not be before started_at")
+	}
+	return nil
 }
 
 type WorkoutExercise struct {
+	ID         int64    `json:"id"`
+	UUID       string   `json:"uuid,omitempty"`
 	WorkoutID  int64    `json:"workout_id"`
 	ExerciseID int64    `json:"exercise_id"`
 	Sets       int      `json:"sets"`
@@ -18,25 +56,209 @@ type WorkoutExercise struct {
 	Weight     float64  `json:"weight,omitempty"`
 	Duration   int      `json:"duration,omitempty"`
 	Distance   float64  `json:"distance,omitempty"`
-	Exercise   Exercise `json:"exercise"`
+	RPE        *float64 `json:"rpe,omitempty" binding:"omitempty,min=1,max=10"`
+	RIR        *int     `json:"rir,omitempty" binding:"omitempty,min=0,max=10"`
+	TargetReps *int     `json:"target_reps,omitempty" binding:"omitempty,min=1,max=100"`
+	IsWarmUp   bool     `json:"is_warmup,omitempty"`
+	Position   int      `json:"position" binding:"min=0"`
+	GroupID    *int     `json:"group_id,omitempty" binding:"omitempty,min=1,max=100"`
+	GroupKind  string   `json:"group_kind,omitempty" binding:"omitempty,oneof=superset giant_set circuit"`
+
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	RestSeconds *int       `json:"rest_seconds,omitempty"`
+	// Planned marks the sets a coach planned that are not done yet.
+	Planned bool `json:"planned,omitempty"`
+
+	AvgHeartRate     *int     `json:"avg_heart_rate,omitempty" binding:"omitempty,min=20,max=250"`
+	MaxHeartRate     *int     `json:"max_heart_rate,omitempty" binding:"omitempty,min=20,max=250"`
+	ElevationGain    *float64 `json:"elevation_gain,omitempty" binding:"omitempty,min=0"`
+	Splits           []*Split `json:"splits,omitempty" binding:"omitempty,max=500,dive"`
+	PaceSecondsPerKm *float64 `json:"pace_seconds_per_km,omitempty"`
+	SpeedKmh         *float64 `json:"speed_kmh,omitempty"`
+	Calories         *float64 `json:"calories,omitempty"`
+
+	Exercise Exercise `json:"exercise"`
+}
+
+func (we *WorkoutExercise) Validate() error {
+	if we.AvgHeartRate != nil && we.MaxHeartRate != nil && *we.MaxHeartRate < *we.AvgHeartRate {
+		return errors.New("max_heart_rate must not be below avg_heart_rate")
+	}
+	if we.GroupKind != "" && we.GroupID == nil {
+		return errors.New("group_kind requires group_id")
+	}
+	return nil
+}
+
+// LogSetRequest logs one set of a workout in progress. RestTimer is the rest
+// to count down after it, in seconds.
+type LogSetRequest struct {
+	WorkoutExercise
+	RestTimer int `json:"rest_timer" binding:"omitempty,min=0,max=3600"`
+}
+
+type FinishSessionRequest struct {
+	SessionRPE *float64 `json:"session_rpe" binding:"omitempty,min=0,max=10"`
+}
+
+type RestTimer struct {
+	StartedAt time.Time `json:"started_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Seconds   int       `json:"seconds"`
+}
+
+type SessionEvent struct {
+	Type      string           `json:"type"`
+	WorkoutID int64            `json:"workout_id"`
+	At        time.Time        `json:"at"`
+	Workout   *Workout         `json:"workout,omitempty"`
+	Set       *WorkoutExercise `json:"set,omitempty"`
+	RestTimer *RestTimer       `json:"rest_timer,omitempty"`
+}
+
+type Split struct {
+	Number           int      `json:"number"`
+	Distance         float64  `json:"distance" binding:"min=0"`
+	Duration         int      `json:"duration" binding:"min=0"`
+	AvgHeartRate     *int     `json:"avg_heart_rate,omitempty" binding:"omitempty,min=20,max=250"`
+	ElevationGain    *float64 `json:"elevation_gain,omitempty" binding:"omitempty,min=0"`
+	PaceSecondsPerKm *float64 `json:"pace_seconds_per_km,omitempty"`
 }
 
 type Exercise struct {
-	ID          int64  `json:"id"`
-	Name        string `json:"name"`
-	Type        string `json:"type"`
-	MuscleGroup string `json:"muscle_group"`
-	Description string `json:"description"`
+	ID               int64      `json:"id"`
+	Name             string     `json:"name"`
+	Type             string     `json:"type"`
+	MuscleGroup      string     `json:"muscle_group"`
+	Description      string     `json:"description"`
+	TrackingProfile  string     `json:"tracking_profile,omitempty"`
+	Equipment        *Equipment `json:"equipment,omitempty"`
+	MovementPattern  *string    `json:"movement_pattern,omitempty"`
+	Mechanics        *string    `json:"mechanics,omitempty"`
+	Unilateral       bool       `json:"unilateral,omitempty"`
+	PrimaryMuscles   []*Muscle  `json:"primary_muscles,omitempty"`
+	SecondaryMuscles []*Muscle  `json:"secondary_muscles,omitempty"`
 }
 
 type WorkoutExercises struct {
 	Workout   Workout            `json:"workout"`
 	Exercises []*WorkoutExercise `json:"exercises"`
+	Blocks    []*ExerciseBlock   `json:"blocks"`
+}
+
+type ExerciseBlock struct {
+	GroupID   *int               `json:"group_id,omitempty"`
+	Kind      string             `json:"kind"`
+	Exercises []*WorkoutExercise `json:"exercises"`
+}
+
+type ExerciseOrder struct {
+	WorkoutExerciseID int64 `json:"id" binding:"required"`
+	GroupID           *int  `json:"group_id,omitempty" binding:"omitempty,min=1,max=100"`
+}
+
+type ExerciseGroup struct {
+	GroupID int    `json:"group_id" binding:"required,min=1,max=100"`
+	Kind    string `json:"kind" binding:"required,oneof=superset giant_set circuit"`
+}
+
+type ReorderExercisesRequest struct {
+	Order  []*ExerciseOrder `json:"order" binding:"required,min=1,max=200,dive"`
+	Groups []*ExerciseGroup `json:"groups" binding:"max=100,dive"`
 }
 
 type Pagination struct {
-	StartDate string `json:"start_date"`
-	EndDate   string `json:"end_date"`
-	Limit     string `json:"limit"`
-	Page      string `json:"page"`
+	StartDate   string `json:"start_date"`
+	EndDate     string `json:"end_date"`
+	Limit       string `json:"limit"`
+	Cursor      string `json:"cursor"`
+	ExerciseID  string `json:"exercise_id"`
+	MuscleGroup string `json:"muscle_group"`
+	Search      string `json:"q"`
+	TagQuery
+}
+
+type ExercisesQuery struct {
+	Type            string `json:"type"`
+	MuscleGroup     string `json:"muscle_group"`
+	Equipment       string `json:"equipment"`
+	PrimaryMuscle   string `json:"primary_muscle"`
+	SecondaryMuscle string `json:"secondary_muscle"`
+	MovementPattern string `json:"movement_pattern"`
+	Mechanics       string `json:"mechanics"`
+	Unilateral      string `json:"unilateral"`
+	TrackingProfile string `json:"tracking_profile"`
+	Limit           string `json:"limit"`
+	Cursor          string `json:"cursor"`
+}
+
+type StrengthScore struct {
+	EstimatedOneRM     float64  `json:"estimated_1rm"`
+	BodyweightMultiple *float64 `json:"bodyweight_multiple,omitempty"`
+	Wilks              *float64 `json:"wilks,omitempty"`
+	DOTS               *float64 `json:"dots,omitempty"`
+	IPFGL              *float64 `json:"ipf_gl,omitempty"`
+	Level              string   `json:"level,omitempty"`
+	NextLevel          string   `json:"next_level,omitempty"`
+	NextLevelOneRM     *float64 `json:"next_level_1rm,omitempty"`
+}
+
+type PersonalRecord struct {
+	ID         int64          `json:"id"`
+	ExerciseID int64          `json:"exercise_id"`
+	Weight     float64        `json:"weight"`
+	Reps       int            `json:"reps"`
+	Duration   int            `json:"duration,omitempty"`
+	Distance   float64        `json:"distance,omitempty"`
+	Date       time.Time      `json:"date"`
+	Exercise   Exercise       `json:"exercise"`
+	Strength   *StrengthScore `json:"strength,omitempty"`
+}
+
+type StrengthSummary struct {
+	Sex        string   `json:"sex,omitempty"`
+	Bodyweight *float64 `json:"bodyweight,omitempty"`
+	Total      float64  `json:"total"`
+	Wilks      *float64 `json:"wilks,omitempty"`
+	DOTS       *float64 `json:"dots,omitempty"`
+	IPFGL      *float64 `json:"ipf_gl,omitempty"`
+}
+
+type PersonalRecords struct {
+	Page[*PersonalRecord]
+	Summary *StrengthSummary `json:"summary"`
+}
+
+type StrengthLevel struct {
+	Level              string  `json:"level" binding:"required"`
+	BodyweightMultiple float64 `json:"bodyweight_multiple" binding:"required,gt=0"`
+}
+
+type StrengthStandards struct {
+	ExerciseID int64            `json:"exercise_id"`
+	Custom     bool             `json:"custom"`
+	Levels     []*StrengthLevel `json:"levels"`
+}
+
+// SetStrengthStandardsRequest lists the levels from the lowest to the highest.
+type SetStrengthStandardsRequest struct {
+	Levels []*StrengthLevel `json:"levels" binding:"required,min=1,dive"`
+}
+
+type ActivityImportRequest struct {
+	ExerciseID int64  `form:"exercise_id" binding:"omitempty,min=1"`
+	Name       string `form:"name" binding:"omitempty,max=100"`
+	Format     string `form:"format" binding:"omitempty,oneof=gpx tcx fit"`
+}
+
+// ExerciseMapping maps a name used by devices and other apps onto an
+// exercise; FIT strength sets are mapped by "category" or "category:subtype".
+type ExerciseMapping struct {
+	Source       string `json:"source"`
+	ExternalName string `json:"external_name"`
+	ExerciseID   int64  `json:"exercise_id"`
+}
+
+type SetExerciseMappingRequest struct {
+	ExerciseID int64 `json:"exercise_id" binding:"required,min=1"`
 }
@@ -0,0 +1,147 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	headerKey      = "Idempotency-Key"
+	headerReplayed = "Idempotent-Replayed"
+	maxKeyLength   = 255
+	// maxBodySize bounds the buffered request bodies by the largest upload a
+	// handler accepts, an exercise media file
+	maxBodySize = 50 << 20
+)
+
+type Implementation struct {
+	idempotencyService service.IdempotencyService
+}
+
+func NewImplementation(idempotencyService service.IdempotencyService) *Implementation {
+	return &Implementation{idempotencyService: idempotencyService}
+}
+
+// recorder keeps a copy of the response body while it is written.
+type recorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *recorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func requestHash(method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + "\n" + uri + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Middleware makes mutating requests with an Idempotency-Key header safe to
+// retry. The first response for a key is stored for the user and replayed
+// for later requests with the same key; reusing the key for a different
+// request is rejected. Only successful responses are stored: handlers report
+// transient failures as client errors too, so any failed request runs again
+// on retry. It has to run after the auth middleware.
+func (i *Implementation) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(headerKey)
+		if len(key) == 0 || !isMutating(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if len(key) > maxKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid idempotency key"})
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodySize))
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "request body too large"})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to read body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		userID := c.GetInt64("user_id")
+		stored, err := i.idempotencyService.Begin(c.Request.Context(), userID, key, requestHash(c.Request.Method, c.Request.URL.RequestURI(), body))
+		if err != nil {
+			fmt.Println(err)
+			appErr := apperrors.FromError(err)
+			c.AbortWithStatusJSON(appErr.StatusCode, gin.H{"error": appErr.Error()})
+			return
+		}
+		if stored != nil {
+			c.Header(headerReplayed, "true")
+			c.Data(int(stored.StatusCode.Int32), stored.ContentType, stored.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		// the client may be gone by the time the response is ready, which is
+		// when storing it matters most
+		ctx := context.WithoutCancel(c.Request.Context())
+		rec := &recorder{ResponseWriter: c.Writer}
+		c.Writer = rec
+
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := i.idempotencyService.Release(ctx, userID, key); err != nil {
+				fmt.Println(err)
+			}
+		}()
+
+		c.Next()
+
+		if rec.Status() < http.StatusOK || rec.Status() >= http.StatusMultipleChoices {
+			return
+		}
+		err = i.idempotencyService.Complete(ctx, &model.IdempotencyRecord{
+			UserID:       userID,
+			Key:          key,
+			StatusCode:   sql.NullInt32{Int32: int32(rec.Status()), Valid: true},
+			ContentType:  rec.Header().Get("Content-Type"),
+			ResponseBody: rec.body.Bytes(),
+		})
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		completed = true
+	}
+}
@@ -0,0 +1,214 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// idempotencyService keeps the records in memory and remembers which keys
+// were completed and released.
+type idempotencyService struct {
+	service.IdempotencyService
+	records   map[string]*model.IdempotencyRecord
+	begun     int
+	completed []string
+	released  []string
+}
+
+func newIdempotencyService() *idempotencyService {
+	return &idempotencyService{records: make(map[string]*model.IdempotencyRecord)}
+}
+
+func (s *idempotencyService) Begin(_ context.Context, userID int64, key, requestHash string) (*model.IdempotencyRecord, error) {
+	s.begun++
+	if rec, ok := s.records[key]; ok {
+		if rec.RequestHash != requestHash {
+			return nil, apperrors.ErrIdempotencyReused
+		}
+		if !rec.StatusCode.Valid {
+			return nil, apperrors.ErrRequestInProgress
+		}
+		return rec, nil
+	}
+	s.records[key] = &model.IdempotencyRecord{UserID: userID, Key: key, RequestHash: requestHash}
+	return nil, nil
+}
+
+func (s *idempotencyService) Complete(_ context.Context, rec *model.IdempotencyRecord) error {
+	stored := s.records[rec.Key]
+	stored.StatusCode = rec.StatusCode
+	stored.ContentType = rec.ContentType
+	stored.ResponseBody = rec.ResponseBody
+	s.completed = append(s.completed, rec.Key)
+	return nil
+}
+
+func (s *idempotencyService) Release(_ context.Context, _ int64, key string) error {
+	delete(s.records, key)
+	s.released = append(s.released, key)
+	return nil
+}
+
+// newRouter serves the middleware in front of a handler that echoes the
+// request body with the status from the path and counts its calls.
+func newRouter(s *idempotencyService, calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+	})
+	r.Use(NewImplementation(s).Middleware())
+	handler := func(c *gin.Context) {
+		*calls++
+		body, _ := io.ReadAll(c.Request.Body)
+		status := http.StatusOK
+		switch c.Param("status") {
+		case "created":
+			status = http.StatusCreated
+		case "invalid":
+			status = http.StatusBadRequest
+		case "failed":
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, gin.H{"body": string(body), "call": *calls})
+	}
+	r.GET("/:status", handler)
+	r.POST("/:status", handler)
+	return r
+}
+
+func serve(r *gin.Engine, method, path, key string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, body)
+	if key != "" {
+		req.Header.Set(headerKey, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddlewareStatuses(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		stored bool
+	}{
+		{"ok is stored", "/ok", http.StatusOK, true},
+		{"created is stored", "/created", http.StatusCreated, true},
+		{"client error is released", "/invalid", http.StatusBadRequest, false},
+		{"server error is released", "/failed", http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newIdempotencyService()
+			calls := 0
+			r := newRouter(s, &calls)
+
+			first := serve(r, http.MethodPost, tt.path, "key", strings.NewReader("payload"))
+			if first.Code != tt.status {
+				t.Fatalf("status = %d, want %d", first.Code, tt.status)
+			}
+			if !strings.Contains(first.Body.String(), `"body":"payload"`) {
+				t.Errorf("handler got body %s, want the request body", first.Body.String())
+			}
+			if stored := len(s.completed) == 1; stored != tt.stored {
+				t.Errorf("completed = %v, want stored %v", s.completed, tt.stored)
+			}
+			if released := len(s.released) == 1; released == tt.stored {
+				t.Errorf("released = %v, want released %v", s.released, !tt.stored)
+			}
+
+			retry := serve(r, http.MethodPost, tt.path, "key", strings.NewReader("payload"))
+			replayed := retry.Header().Get(headerReplayed) == "true"
+			if replayed != tt.stored {
+				t.Errorf("retry replayed = %v, want %v", replayed, tt.stored)
+			}
+			if tt.stored && (retry.Code != first.Code || retry.Body.String() != first.Body.String() || calls != 1) {
+				t.Errorf("retry = %d %s after %d calls, want the stored %d %s", retry.Code, retry.Body.String(), calls, first.Code, first.Body.String())
+			}
+			if !tt.stored && calls != 2 {
+				t.Errorf("handler ran %d times, want the retry handled again", calls)
+			}
+		})
+	}
+}
+
+func TestMiddlewareRejections(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		body   io.Reader
+		status int
+	}{
+		{"key too long", strings.Repeat("k", maxKeyLength+1), strings.NewReader("payload"), http.StatusBadRequest},
+		{"body too large", "key", bytes.NewReader(make([]byte, maxBodySize+1)), http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newIdempotencyService()
+			calls := 0
+			r := newRouter(s, &calls)
+
+			w := serve(r, http.MethodPost, "/ok", tt.key, tt.body)
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if calls != 0 || s.begun != 0 {
+				t.Errorf("handler ran %d times and %d keys were claimed, want none", calls, s.begun)
+			}
+		})
+	}
+}
+
+func TestMiddlewareKeyReuse(t *testing.T) {
+	s := newIdempotencyService()
+	calls := 0
+	r := newRouter(s, &calls)
+
+	serve(r, http.MethodPost, "/created", "key", strings.NewReader("first"))
+	w := serve(r, http.MethodPost, "/created", "key", strings.NewReader("second"))
+	if w.Code != http.StatusUnprocessableEntity || calls != 1 {
+		t.Errorf("status = %d after %d calls, want %d after 1", w.Code, calls, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestMiddlewarePassThrough(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		key    string
+	}{
+		{"no key", http.MethodPost, ""},
+		{"read request", http.MethodGet, "key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newIdempotencyService()
+			calls := 0
+			r := newRouter(s, &calls)
+
+			serve(r, tt.method, "/ok", tt.key, nil)
+			w := serve(r, tt.method, "/ok", tt.key, nil)
+			if w.Code != http.StatusOK || calls != 2 {
+				t.Errorf("status = %d after %d calls, want %d after 2", w.Code, calls, http.StatusOK)
+			}
+			if s.begun != 0 || w.Header().Get(headerReplayed) != "" {
+				t.Errorf("%d keys were claimed, want the middleware to stay out", s.begun)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package media
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/biryanim/workoutbook/internal/converter"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// maxMediaFileSize limits uploaded instruction media; short demonstration
+// clips fit well below it.
+const maxMediaFileSize = 50 << 20
+
+type Implementation struct {
+	mediaService service.MediaService
+}
+
+func NewImplementation(mediaService service.MediaService) *Implementation {
+	return &Implementation{mediaService: mediaService}
+}
+
+func (i *Implementation) UploadMedia(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	exerciseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxMediaFileSize)
+
+	file, _, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file required"})
+		return
+	}
+	defer file.Close()
+
+	media, err := i.mediaService.Upload(c.Request.Context(), &model.UploadMediaParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	}, file)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.ToExerciseMediaResp(media))
+}
+
+func (i *Implementation) ListMedia(c *gin.Context) {
+	exerciseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	media, err := i.mediaService.List(c.Request.Context(), exerciseID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToExerciseMediaListResp(media))
+}
+
+// ServeMedia streams the stored file. Media is immutable once uploaded, so
+// clients may cache it for long.
+func (i *Implementation) ServeMedia(c *gin.Context) {
+	mediaID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	media, file, err := i.mediaService.Open(c.Request.Context(), mediaID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, media.Size, media.ContentType, file, map[string]string{
+		"Cache-Control": "public, max-age=31536000, immutable",
+	})
+}
+
+func (i *Implementation) DeleteMedia(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	mediaID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err = i.mediaService.Delete(c.Request.Context(), userID, mediaID); err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"media_id": mediaID})
+}
@@ -0,0 +1,127 @@
+package plate
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/converter"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type Implementation struct {
+	plateService service.PlateService
+}
+
+func NewImplementation(plateService service.PlateService) *Implementation {
+	return &Implementation{plateService: plateService}
+}
+
+func (i *Implementation) GetInventory(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	inv, err := i.plateService.GetInventory(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToPlateInventoryResp(inv))
+}
+
+func (i *Implementation) UpdateInventory(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	var req dto.PlateInventory
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	err := i.plateService.UpdateInventory(c.Request.Context(), converter.FromPlateInventoryRequest(userID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user_id": userID})
+}
+
+func plateLoadQuery(c *gin.Context) *dto.PlateLoadQuery {
+	return &dto.PlateLoadQuery{
+		Weight:    c.Query("weight"),
+		Unit:      c.Query("unit"),
+		BarWeight: c.Query("bar_weight"),
+	}
+}
+
+func (i *Implementation) CalculateLoading(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	params, err := converter.FromPlateLoadQuery(userID, plateLoadQuery(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	loading, err := i.plateService.CalculateLoading(c.Request.Context(), params)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToPlateLoadingResp(loading))
+}
+
+func (i *Implementation) GenerateWarmUp(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	params, err := converter.FromWarmUpQuery(userID, plateLoadQuery(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	sets, err := i.plateService.GenerateWarmUp(c.Request.Context(), params)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToWarmUpResp(sets))
+}
+
+func (i *Implementation) InsertWarmUp(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	workoutID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.WarmUpRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	sets, err := i.plateService.InsertWarmUp(c.Request.Context(), converter.FromWarmUpRequest(userID, workoutID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.ToWarmUpResp(sets))
+}
@@ -0,0 +1,284 @@
+package program
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/converter"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type Implementation struct {
+	programService service.ProgramService
+}
+
+func NewImplementation(programService service.ProgramService) *Implementation {
+	return &Implementation{programService: programService}
+}
+
+func (i *Implementation) CreateProgram(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	var req dto.Program
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	id, err := i.programService.CreateProgram(c.Request.Context(), converter.FromProgramRequest(userID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"program_id": id})
+}
+
+func (i *Implementation) ListPrograms(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	programs, err := i.programService.ListPrograms(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToProgramsResp(programs))
+}
+
+func (i *Implementation) GetProgram(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	programID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	program, err := i.programService.GetProgram(c.Request.Context(), userID, programID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToProgramResp(program))
+}
+
+func (i *Implementation) ListGenerators(c *gin.Context) {
+	c.JSON(http.StatusOK, converter.ToGeneratorsResp(i.programService.ListGenerators(c.Request.Context())))
+}
+
+func (i *Implementation) GenerateProgram(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	var req dto.GenerateProgramRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	params, err := converter.FromGenerateProgramRequest(userID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	enrollment, err := i.programService.GenerateProgram(c.Request.Context(), params)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"program_id": enrollment.ProgramID, "enrollment_id": enrollment.ID})
+}
+
+func (i *Implementation) Enroll(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	programID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.EnrollRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	enrollment, err := converter.FromEnrollRequest(userID, programID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := i.programService.Enroll(c.Request.Context(), enrollment)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"enrollment_id": id})
+}
+
+func (i *Implementation) AssignProgram(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	athleteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.AssignProgramRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	enrollment, err := converter.FromAssignProgramRequest(athleteID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, err := i.programService.AssignProgram(c.Request.Context(), userID, enrollment)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"enrollment_id": id})
+}
+
+func (i *Implementation) ListEnrollments(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	enrollments, err := i.programService.ListEnrollments(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToEnrollmentsResp(enrollments))
+}
+
+func (i *Implementation) GetSchedule(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	enrollmentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	date, err := converter.FromScheduleDate(c.Query("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := i.programService.GetScheduledSession(c.Request.Context(), userID, enrollmentID, date)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToScheduledSessionResp(session))
+}
+
+func (i *Implementation) LinkWorkout(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	enrollmentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.LinkSessionRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	session, err := converter.FromLinkSessionRequest(enrollmentID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = i.programService.LinkWorkout(c.Request.Context(), userID, session); err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"enrollment_id": enrollmentID, "workout_id": req.WorkoutID})
+}
+
+func (i *Implementation) GetAdherence(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	enrollmentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	adherence, err := i.programService.GetAdherence(c.Request.Context(), userID, enrollmentID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToAdherenceResp(adherence))
+}
+
+// SwapExercise replaces a program exercise in the sessions of the enrollment,
+// keeping its prescription.
+func (i *Implementation) SwapExercise(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	enrollmentID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	entryID, err := strconv.ParseInt(c.Param("entry_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.SwapExerciseRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	exercise, err := i.programService.SwapExercise(c.Request.Context(), converter.FromSwapExerciseRequest(userID, enrollmentID, entryID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToScheduledExerciseResp(exercise))
+}
@@ -0,0 +1,109 @@
+package resolver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/converter"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type Implementation struct {
+	resolverService service.ResolverService
+}
+
+func NewImplementation(resolverService service.ResolverService) *Implementation {
+	return &Implementation{resolverService: resolverService}
+}
+
+func (i *Implementation) Resolve(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	query := dto.ResolveQuery{
+		Query: c.Query("q"),
+		Limit: c.Query("limit"),
+	}
+
+	q, err := converter.FromResolveQuery(userID, &query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	q.Locale = converter.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+
+	candidates, err := i.resolverService.Resolve(c.Request.Context(), q)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToExerciseCandidatesResp(candidates))
+}
+
+func (i *Implementation) ListAliases(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	exerciseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	aliases, err := i.resolverService.ListAliases(c.Request.Context(), userID, exerciseID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToExerciseAliasesResp(aliases))
+}
+
+func (i *Implementation) AddAlias(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	exerciseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.AddAliasRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	alias, err := i.resolverService.AddAlias(c.Request.Context(), converter.FromAddAliasRequest(userID, exerciseID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.ToExerciseAliasResp(alias))
+}
+
+func (i *Implementation) DeleteAlias(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	aliasID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err = i.resolverService.DeleteAlias(c.Request.Context(), userID, aliasID); err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"alias_id": aliasID})
+}
@@ -0,0 +1,46 @@
+package search
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/converter"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type Implementation struct {
+	searchService service.SearchService
+}
+
+func NewImplementation(searchService service.SearchService) *Implementation {
+	return &Implementation{searchService: searchService}
+}
+
+func (i *Implementation) Search(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	query := dto.SearchQuery{
+		Query: c.Query("q"),
+		Limit: c.Query("limit"),
+	}
+
+	q, err := converter.FromSearchQuery(userID, &query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	q.Locale = converter.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+
+	results, err := i.searchService.Search(c.Request.Context(), q)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToSearchResultsResp(results))
+}
@@ -0,0 +1,161 @@
+package tag
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/converter"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type Implementation struct {
+	tagService service.TagService
+}
+
+func NewImplementation(tagService service.TagService) *Implementation {
+	return &Implementation{tagService: tagService}
+}
+
+func (i *Implementation) CreateTag(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	var req dto.CreateTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	tagID, err := i.tagService.CreateTag(c.Request.Context(), converter.FromCreateTagRequest(userID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"tag_id": tagID})
+}
+
+func (i *Implementation) ListTags(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	tags, err := i.tagService.ListTags(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTagsResp(tags))
+}
+
+func (i *Implementation) GetTag(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	tagID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	tag, err := i.tagService.GetTag(c.Request.Context(), userID, tagID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTagResp(tag))
+}
+
+func (i *Implementation) UpdateTag(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	tagID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.UpdateTagRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	if err = req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := i.tagService.UpdateTag(c.Request.Context(), converter.FromUpdateTagRequest(userID, tagID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTagResp(tag))
+}
+
+func (i *Implementation) DeleteTag(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	tagID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	err = i.tagService.DeleteTag(c.Request.Context(), userID, tagID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tag_id": tagID})
+}
+
+func (i *Implementation) SetWorkoutTags(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	workoutID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.SetWorkoutTagsRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	tags, err := i.tagService.SetWorkoutTags(c.Request.Context(), converter.FromSetWorkoutTagsRequest(userID, workoutID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTagsResp(tags))
+}
+
+func (i *Implementation) GetTagUsage(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	usage, err := i.tagService.GetTagUsage(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTagUsageResp(usage))
+}
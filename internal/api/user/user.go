@@ -0,0 +1,121 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/converter"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+type Implementation struct {
+	userService service.UserService
+}
+
+func NewImplementation(userService service.UserService) *Implementation {
+	return &Implementation{userService: userService}
+}
+
+func (i *Implementation) GetProfile(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	user, err := i.userService.GetProfile(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToProfileResp(user))
+}
+
+func (i *Implementation) UpdateProfile(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	var req dto.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	err := i.userService.UpdateProfile(c.Request.Context(), converter.FromUpdateProfileRequest(userID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"user_id": userID})
+}
+
+func (i *Implementation) AddBodyWeight(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	var req dto.BodyWeight
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	id, err := i.userService.AddBodyWeight(c.Request.Context(), converter.FromBodyWeightRequest(userID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"body_weight_id": id})
+}
+
+func (i *Implementation) ListBodyWeights(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	weights, err := i.userService.ListBodyWeights(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToBodyWeightsResp(weights))
+}
+
+func (i *Implementation) GetEquipment(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	equipment, err := i.userService.GetEquipment(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToEquipmentResp(equipment))
+}
+
+// UpdateEquipment replaces the equipment the user has by codes; an empty list
+// makes any equipment available.
+func (i *Implementation) UpdateEquipment(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	var req dto.UpdateEquipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	equipment, err := i.userService.UpdateEquipment(c.Request.Context(), userID, req.Equipment)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToEquipmentResp(equipment))
+}
@@ -0,0 +1,124 @@
+package workout
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/converter"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/gin-gonic/gin"
+)
+
+func (i *Implementation) ListAthleteWorkouts(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	athleteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	pagination := dto.Pagination{
+		StartDate:   c.Query("start_date"),
+		EndDate:     c.Query("end_date"),
+		Limit:       c.Query("limit"),
+		Cursor:      c.Query("cursor"),
+		ExerciseID:  c.Query("exercise_id"),
+		MuscleGroup: c.Query("muscle_group"),
+		Search:      c.Query("q"),
+		TagQuery:    dto.TagQuery{Tag: c.Query("tag"), ExcludeTag: c.Query("exclude_tag")},
+	}
+
+	filter, err := converter.FromPaginationToFilter(&pagination)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workouts, err := i.workoutService.GetAthleteWorkouts(c.Request.Context(), userID, athleteID, filter)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToWorkoutsResp(workouts))
+}
+
+func (i *Implementation) AssignWorkout(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	athleteID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var workout dto.Workout
+	if err = c.ShouldBindJSON(&workout); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	if err = workout.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	workout.UserId = athleteID
+
+	id, err := i.workoutService.AssignWorkout(c.Request.Context(), userID, converter.FromCreateWorkoutRequest(&workout))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"workout_id": id})
+}
+
+func (i *Implementation) ListComments(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	workoutID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	comments, err := i.workoutService.ListComments(c.Request.Context(), userID, workoutID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToWorkoutCommentsResp(comments))
+}
+
+func (i *Implementation) AddComment(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	workoutID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.AddCommentRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	comment, err := i.workoutService.AddComment(c.Request.Context(), converter.FromAddCommentRequest(userID, workoutID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.ToWorkoutCommentResp(comment))
+}
@@ -0,0 +1,146 @@
+package workout
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/converter"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/gin-gonic/gin"
+)
+
+func (i *Implementation) GetNextSuggestion(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	exerciseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	reps, err := converter.FromTargetReps(c.Query("reps"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	suggestion, err := i.workoutService.SuggestNext(c.Request.Context(), userID, exerciseID, reps)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToSuggestionResp(suggestion))
+}
+
+func (i *Implementation) CreateTemplate(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	var req dto.WorkoutTemplate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	id, err := i.workoutService.CreateTemplate(c.Request.Context(), converter.FromTemplateRequest(userID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"template_id": id})
+}
+
+func (i *Implementation) ListTemplates(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	templates, err := i.workoutService.ListTemplates(c.Request.Context(), userID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTemplatesResp(templates))
+}
+
+func (i *Implementation) GetTemplate(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	templateID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	template, err := i.workoutService.GetTemplate(c.Request.Context(), userID, templateID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTemplateResp(template))
+}
+
+func (i *Implementation) InstantiateTemplate(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	templateID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.InstantiateTemplateRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	workout, err := i.workoutService.InstantiateTemplate(c.Request.Context(), converter.FromInstantiateTemplateRequest(userID, templateID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, converter.ToTemplateWorkoutResp(workout))
+}
+
+// SwapTemplateExercise replaces the exercise of a template entry, keeping its
+// sets, reps and weight.
+func (i *Implementation) SwapTemplateExercise(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	templateID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	entryID, err := strconv.ParseInt(c.Param("entry_id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.SwapExerciseRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	entry, err := i.workoutService.SwapTemplateExercise(c.Request.Context(), converter.FromSwapExerciseRequest(userID, templateID, entryID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToTemplateExerciseResp(entry))
+}
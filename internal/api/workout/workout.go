@@ -5,12 +5,18 @@ import (
 	"github.com/biryanim/workoutbook/internal/api/dto"
 	"github.com/biryanim/workoutbook/internal/converter"
 	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/importer"
 	"github.com/biryanim/workoutbook/internal/service"
 	"github.com/gin-gonic/gin"
+	"io"
 	"net/http"
 	"strconv"
 )
 
+// maxActivityFileSize limits uploaded activity files; a several-hour GPS
+// recording is well below it.
+const maxActivityFileSize = 20 << 20
+
 type Implementation struct {
 	workoutService service.WorkoutService
 }
@@ -27,6 +33,11 @@ func (i *Implementation) CreateWorkout(c *gin.Context) {
 		return
 	}
 
+	if err := workout.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	workout.UserId = userID
 
 	id, err := i.workoutService.CreateWorkout(c.Request.Context(), converter.FromCreateWorkoutRequest(&workout))
@@ -46,7 +57,8 @@ func (i *Implementation) GetWorkout(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
 		return
 	}
-	workout, err := i.workoutService.GetWorkout(c.Request.Context(), userID, workoutID)
+	locale := converter.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+	workout, err := i.workoutService.GetWorkout(c.Request.Context(), userID, workoutID, locale)
 	if err != nil {
 		fmt.Println(err)
 		appErr := apperrors.FromError(err)
@@ -59,19 +71,142 @@ func (i *Implementation) GetWorkout(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+func (i *Implementation) UpdateWorkout(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	workoutID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.UpdateWorkoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = i.workoutService.UpdateWorkout(c.Request.Context(), converter.FromUpdateWorkoutRequest(userID, workoutID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"workout_id": workoutID})
+}
+
+func (i *Implementation) ImportActivity(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxActivityFileSize)
+
+	var req dto.ActivityImportRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file required"})
+		return
+	}
+	defer file.Close()
+
+	raw, err := io.ReadAll(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read file"})
+		return
+	}
+
+	format := req.Format
+	if format == "" {
+		format = importer.FormatFromFilename(header.Filename)
+	}
+
+	workoutID, err := i.workoutService.ImportActivity(c.Request.Context(), converter.FromActivityImportRequest(userID, &req, format, raw))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"workout_id": workoutID})
+}
+
+func (i *Implementation) ListExerciseMappings(c *gin.Context) {
+	mappings, err := i.workoutService.ListExerciseMappings(c.Request.Context(), c.Query("source"))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToExerciseMappingsResp(mappings))
+}
+
+func (i *Implementation) SetExerciseMapping(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+
+	var req dto.SetExerciseMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	mapping, err := converter.FromSetExerciseMappingRequest(c.Param("source"), c.Param("name"), &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = i.workoutService.SetExerciseMapping(c.Request.Context(), userID, mapping); err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToExerciseMappingResp(mapping))
+}
+
+func (i *Implementation) DeleteExerciseMapping(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	source, name := c.Param("source"), c.Param("name")
+
+	if err := i.workoutService.DeleteExerciseMapping(c.Request.Context(), userID, source, name); err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"source": source, "external_name": name})
+}
+
 func (i *Implementation) ListWorkouts(c *gin.Context) {
 	userID := c.GetInt64("user_id")
 
 	pagination := dto.Pagination{
-		StartDate: c.Query("start_date"),
-		EndDate:   c.Query("end_date"),
-		Limit:     c.Query("limit"),
-		Page:      c.Query("page"),
+		StartDate:   c.Query("start_date"),
+		EndDate:     c.Query("end_date"),
+		Limit:       c.Query("limit"),
+		Cursor:      c.Query("cursor"),
+		ExerciseID:  c.Query("exercise_id"),
+		MuscleGroup: c.Query("muscle_group"),
+		Search:      c.Query("q"),
+		TagQuery:    dto.TagQuery{Tag: c.Query("tag"), ExcludeTag: c.Query("exclude_tag")},
 	}
 
 	filter, err := converter.FromPaginationToFilter(&pagination)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
@@ -99,6 +234,11 @@ func (i *Implementation) AddExerciseToWorkout(c *gin.Context) {
 		return
 	}
 
+	if err := exerc.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	exerc.WorkoutID = workoutID
 
 	err = i.workoutService.AddExerciseToWorkout(c.Request.Context(), userID, converter.FromAddExerciseToWorkout(&exerc))
@@ -112,10 +252,75 @@ func (i *Implementation) AddExerciseToWorkout(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{"workout_id": workoutID})
 }
 
+func (i *Implementation) ReorderExercises(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	workoutID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.ReorderExercisesRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	err = i.workoutService.ReorderExercises(c.Request.Context(), converter.FromReorderExercisesRequest(userID, workoutID, &req))
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"workout_id": workoutID})
+}
+
 func (i *Implementation) ListExercises(c *gin.Context) {
-	exerciseType := c.Query("type")
+	userID := c.GetInt64("user_id")
+	query := dto.ExercisesQuery{
+		Type:            c.Query("type"),
+		MuscleGroup:     c.Query("muscle_group"),
+		Equipment:       c.Query("equipment"),
+		PrimaryMuscle:   c.Query("primary_muscle"),
+		SecondaryMuscle: c.Query("secondary_muscle"),
+		MovementPattern: c.Query("movement_pattern"),
+		Mechanics:       c.Query("mechanics"),
+		Unilateral:      c.Query("unilateral"),
+		TrackingProfile: c.Query("tracking_profile"),
+		Limit:           c.Query("limit"),
+		Cursor:          c.Query("cursor"),
+	}
+
+	filter, err := converter.FromExercisesQuery(&query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.Locale = converter.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+
+	exercises, err := i.workoutService.GetExercises(c.Request.Context(), userID, filter)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToExercisesPageResp(exercises))
+}
+
+func (i *Implementation) GetExercise(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	exerciseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
 
-	exercises, err := i.workoutService.GetExercises(c.Request.Context(), exerciseType)
+	locale := converter.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+	exercise, err := i.workoutService.GetExercise(c.Request.Context(), userID, exerciseID, locale)
 	if err != nil {
 		fmt.Println(err)
 		appErr := apperrors.FromError(err)
@@ -123,18 +328,131 @@ func (i *Implementation) ListExercises(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, converter.ToListExercisesResp(exercises))
+	c.JSON(http.StatusOK, converter.ToExerciseResp(exercise))
+}
+
+func (i *Implementation) ListEquipment(c *gin.Context) {
+	equipment, err := i.workoutService.ListEquipment(c.Request.Context())
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToEquipmentResp(equipment))
+}
+
+func (i *Implementation) ListMuscles(c *gin.Context) {
+	muscles, err := i.workoutService.ListMuscles(c.Request.Context())
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToMusclesResp(muscles))
+}
+
+func (i *Implementation) GetSubstitutes(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	exerciseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	q, err := converter.FromSubstitutesQuery(userID, exerciseID, c.Query("limit"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	q.Locale = converter.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+
+	substitutes, err := i.workoutService.GetSubstitutes(c.Request.Context(), q)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToSubstitutesResp(substitutes))
 }
 
 func (i *Implementation) GetPersonalRecords(c *gin.Context) {
 	userID := c.GetInt64("user_id")
-	records, err := i.workoutService.GetPersonalRecords(c.Request.Context(), userID)
+	locale := converter.FromAcceptLanguage(c.GetHeader("Accept-Language"))
+	records, err := i.workoutService.GetPersonalRecords(c.Request.Context(), userID, locale)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToPersonalRecordsResp(records))
+}
+
+func (i *Implementation) GetStrengthStandards(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	exerciseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	standards, err := i.workoutService.GetStrengthStandards(c.Request.Context(), userID, exerciseID)
+	if err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToStrengthStandardsResp(standards))
+}
+
+func (i *Implementation) SetStrengthStandards(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	exerciseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	var req dto.SetStrengthStandardsRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid input"})
+		return
+	}
+
+	standards := converter.FromSetStrengthStandardsRequest(exerciseID, &req)
+	if err = i.workoutService.SetStrengthStandards(c.Request.Context(), userID, standards); err != nil {
+		fmt.Println(err)
+		appErr := apperrors.FromError(err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, converter.ToStrengthStandardsResp(standards))
+}
+
+func (i *Implementation) DeleteStrengthStandards(c *gin.Context) {
+	userID := c.GetInt64("user_id")
+	exerciseID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err = i.workoutService.DeleteStrengthStandards(c.Request.Context(), userID, exerciseID); err != nil {
 		fmt.Println(err)
 		appErr := apperrors.FromError(err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": appErr.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"records": records})
+	c.JSON(http.StatusOK, gin.H{"exercise_id": exerciseID})
 }
@@ -18,6 +18,14 @@ type JWTConfig interface {
 	TokenExpiration() time.Duration
 }
 
+type IdempotencyConfig interface {
+	TTL() time.Duration
+}
+
+type StorageConfig interface {
+	MediaDir() string
+}
+
 func Load(path string) error {
 	err := godotenv.Load(path)
 	if err != nil {
@@ -0,0 +1,44 @@
+package env
+
+import (
+	"os"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/config"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	idempotencyTTLEnvName = "IDEMPOTENCY_TTL"
+
+	defaultIdempotencyTTL = 24 * time.Hour
+)
+
+type idempotencyConfig struct {
+	ttl time.Duration
+}
+
+// NewIdempotencyConfig keeps idempotency keys for a day unless
+// IDEMPOTENCY_TTL says otherwise.
+func NewIdempotencyConfig() (config.IdempotencyConfig, error) {
+	ttl := defaultIdempotencyTTL
+	if raw := os.Getenv(idempotencyTTLEnvName); len(raw) > 0 {
+		var err error
+		ttl, err = time.ParseDuration(raw)
+		if err != nil {
+			return nil, err
+		}
+		if ttl <= 0 {
+			return nil, errors.New("idempotency ttl must be positive")
+		}
+	}
+
+	return &idempotencyConfig{
+		ttl: ttl,
+	}, nil
+}
+
+func (c *idempotencyConfig) TTL() time.Duration {
+	return c.ttl
+}
@@ -0,0 +1,34 @@
+package env
+
+import (
+	"os"
+
+	"github.com/biryanim/workoutbook/internal/config"
+)
+
+const (
+	mediaDirEnvName = "MEDIA_DIR"
+
+	defaultMediaDir = "media"
+)
+
+type storageConfig struct {
+	mediaDir string
+}
+
+// NewStorageConfig keeps uploaded media in ./media unless MEDIA_DIR says
+// otherwise.
+func NewStorageConfig() (config.StorageConfig, error) {
+	mediaDir := os.Getenv(mediaDirEnvName)
+	if len(mediaDir) == 0 {
+		mediaDir = defaultMediaDir
+	}
+
+	return &storageConfig{
+		mediaDir: mediaDir,
+	}, nil
+}
+
+func (c *storageConfig) MediaDir() string {
+	return c.mediaDir
+}
@@ -0,0 +1,259 @@
+package converter
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultAnalyticsWeeks = 4
+	maxAnalyticsDays      = 366
+	defaultMinWeeklySets  = 10
+	defaultMaxWeeklySets  = 20
+
+	defaultLoadSource        = "volume"
+	defaultACWRThreshold     = 1.5
+	defaultMonotonyThreshold = 2.0
+)
+
+// parseDateRange parses an inclusive [start, end] pair of YYYY-MM-DD dates and
+// returns a half-open [start, end) range. Missing bounds default to the last
+// defaultWeeks weeks up to today.
+func parseDateRange(start, end string, defaultWeeks int) (time.Time, time.Time, error) {
+	var (
+		startDate time.Time
+		endDate   time.Time
+		err       error
+	)
+
+	if len(end) != 0 {
+		endDate, err = time.Parse(time.DateOnly, end)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid date format")
+		}
+	} else {
+		now := time.Now().UTC()
+		endDate = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	endDate = endDate.AddDate(0, 0, 1)
+
+	if len(start) != 0 {
+		startDate, err = time.Parse(time.DateOnly, start)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("invalid date format")
+		}
+	} else {
+		startDate = endDate.AddDate(0, 0, -7*defaultWeeks)
+	}
+
+	if !startDate.Before(endDate) {
+		return time.Time{}, time.Time{}, errors.New("start_date must not be after end_date")
+	}
+	if endDate.Sub(startDate) > maxAnalyticsDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errors.New("date range must not exceed one year")
+	}
+
+	return startDate, endDate, nil
+}
+
+func parseOptionalFloat(s string, def float64) (float64, error) {
+	if len(s) == 0 {
+		return def, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func FromMuscleVolumeQuery(q *dto.MuscleVolumeQuery) (*model.MuscleVolumeFilter, error) {
+	var (
+		filter model.MuscleVolumeFilter
+		err    error
+	)
+
+	filter.StartDate, filter.EndDate, err = parseDateRange(q.StartDate, q.EndDate, defaultAnalyticsWeeks)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.SecondaryWeight, err = parseOptionalFloat(q.SecondaryWeight, 0)
+	if err != nil || filter.SecondaryWeight < 0 || filter.SecondaryWeight > 1 {
+		return nil, errors.New("secondary_weight must be between 0 and 1")
+	}
+
+	filter.MinSets, err = parseOptionalFloat(q.MinSets, defaultMinWeeklySets)
+	if err != nil || filter.MinSets < 0 {
+		return nil, errors.New("invalid min_sets")
+	}
+
+	filter.MaxSets, err = parseOptionalFloat(q.MaxSets, defaultMaxWeeklySets)
+	if err != nil || filter.MaxSets < filter.MinSets {
+		return nil, errors.New("max_sets must be greater or equal than min_sets")
+	}
+
+	filter.Tags, err = FromTagQuery(&q.TagQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filter, nil
+}
+
+func ToMuscleVolumeResp(r *model.MuscleVolumeReport) *dto.MuscleVolumeReport {
+	resp := &dto.MuscleVolumeReport{
+		StartDate: r.StartDate,
+		EndDate:   r.EndDate.AddDate(0, 0, -1),
+		MinSets:   r.MinSets,
+		MaxSets:   r.MaxSets,
+		Weeks:     []*dto.WeeklyMuscleVolume{},
+		Neglected: []string{},
+	}
+
+	for _, w := range r.Weeks {
+		week := &dto.WeeklyMuscleVolume{
+			WeekStart:    w.WeekStart,
+			MuscleGroups: []*dto.MuscleGroupVolume{},
+		}
+		for _, v := range w.MuscleGroups {
+			week.MuscleGroups = append(week.MuscleGroups, &dto.MuscleGroupVolume{
+				MuscleGroup: v.MuscleGroup,
+				Sets:        v.Sets,
+				Reps:        v.Reps,
+				Tonnage:     v.Tonnage,
+				Status:      v.Status,
+			})
+		}
+		resp.Weeks = append(resp.Weeks, week)
+	}
+	resp.Neglected = append(resp.Neglected, r.Neglected...)
+
+	return resp
+}
+
+func FromCalendarYear(year string) (int, error) {
+	if len(year) == 0 {
+		return 0, nil
+	}
+
+	y, err := strconv.Atoi(year)
+	if err != nil || y < 1970 || y > 9999 {
+		return 0, errors.New("invalid year")
+	}
+
+	return y, nil
+}
+
+func ToCalendarResp(c *model.Calendar) *dto.Calendar {
+	resp := &dto.Calendar{
+		Year:          c.Year,
+		Timezone:      c.Timezone,
+		TotalWorkouts: c.TotalWorkouts,
+		TotalVolume:   c.TotalVolume,
+		Days:          make([]*dto.CalendarDay, 0, len(c.Days)),
+	}
+
+	for _, d := range c.Days {
+		resp.Days = append(resp.Days, &dto.CalendarDay{
+			Date:     d.Date.Format(time.DateOnly),
+			Workouts: d.Workouts,
+			Volume:   d.Volume,
+		})
+	}
+
+	return resp
+}
+
+func ToStatsSummaryResp(s *model.StatsSummary) *dto.StatsSummary {
+	resp := &dto.StatsSummary{
+		Timezone:                      s.Timezone,
+		CurrentWeeklyStreak:           s.CurrentWeeklyStreak,
+		LongestWeeklyStreak:           s.LongestWeeklyStreak,
+		WorkoutsThisWeek:              s.WorkoutsThisWeek,
+		WorkoutsThisMonth:             s.WorkoutsThisMonth,
+		WorkoutsThisYear:              s.WorkoutsThisYear,
+		AverageSessionDurationSeconds: int64(s.AverageSessionDuration.Seconds()),
+		TopExercises:                  []*dto.ExerciseUsage{},
+	}
+
+	for _, e := range s.TopExercises {
+		resp.TopExercises = append(resp.TopExercises, &dto.ExerciseUsage{
+			ExerciseID: e.ExerciseID,
+			Name:       e.Name,
+			Workouts:   e.Workouts,
+			Sets:       e.Sets,
+		})
+	}
+
+	return resp
+}
+
+func FromTrainingLoadQuery(q *dto.TrainingLoadQuery) (*model.TrainingLoadFilter, error) {
+	var (
+		filter model.TrainingLoadFilter
+		err    error
+	)
+
+	filter.StartDate, filter.EndDate, err = parseDateRange(q.StartDate, q.EndDate, defaultAnalyticsWeeks)
+	if err != nil {
+		return nil, err
+	}
+
+	filter.Source = q.Source
+	if len(filter.Source) == 0 {
+		filter.Source = defaultLoadSource
+	}
+
+	filter.ACWRThreshold, err = parseOptionalFloat(q.ACWRThreshold, defaultACWRThreshold)
+	if err != nil || filter.ACWRThreshold < 0 {
+		return nil, errors.New("invalid acwr_threshold")
+	}
+
+	filter.MonotonyThreshold, err = parseOptionalFloat(q.MonotonyThreshold, defaultMonotonyThreshold)
+	if err != nil || filter.MonotonyThreshold < 0 {
+		return nil, errors.New("invalid monotony_threshold")
+	}
+
+	filter.StrainThreshold, err = parseOptionalFloat(q.StrainThreshold, 0)
+	if err != nil || filter.StrainThreshold < 0 {
+		return nil, errors.New("invalid strain_threshold")
+	}
+
+	filter.Tags, err = FromTagQuery(&q.TagQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filter, nil
+}
+
+func toTrainingLoadDaysResp(days []*model.TrainingLoadDay) []*dto.TrainingLoadDay {
+	resp := make([]*dto.TrainingLoadDay, 0, len(days))
+	for _, d := range days {
+		resp = append(resp, &dto.TrainingLoadDay{
+			Date:        d.Date.Format(time.DateOnly),
+			Load:        d.Load,
+			AcuteLoad:   d.AcuteLoad,
+			ChronicLoad: d.ChronicLoad,
+			ACWR:        d.ACWR,
+			Monotony:    d.Monotony,
+			Strain:      d.Strain,
+			Flags:       d.Flags,
+		})
+	}
+
+	return resp
+}
+
+func ToTrainingLoadResp(r *model.TrainingLoadReport) *dto.TrainingLoadReport {
+	return &dto.TrainingLoadReport{
+		Source:            r.Source,
+		Timezone:          r.Timezone,
+		ACWRThreshold:     r.ACWRThreshold,
+		MonotonyThreshold: r.MonotonyThreshold,
+		StrainThreshold:   r.StrainThreshold,
+		Days:              toTrainingLoadDaysResp(r.Days),
+		Spikes:            toTrainingLoadDaysResp(r.Spikes),
+	}
+}
@@ -0,0 +1,154 @@
+package converter
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+// checkExercisesQuery validates the catalog filters of q into filter.
+func checkExercisesQuery(q *dto.ExercisesQuery, filter *model.ExercisesFilter) error {
+	if len(q.MovementPattern) != 0 && !slices.Contains(model.MovementPatterns, q.MovementPattern) {
+		return errors.New("invalid movement_pattern")
+	}
+	if len(q.Mechanics) != 0 && q.Mechanics != model.MechanicsCompound && q.Mechanics != model.MechanicsIsolation {
+		return errors.New("mechanics must be compound or isolation")
+	}
+	if len(q.TrackingProfile) != 0 && !slices.Contains(model.TrackingProfiles, q.TrackingProfile) {
+		return errors.New("invalid tracking_profile")
+	}
+	if len(q.Unilateral) != 0 {
+		unilateral, err := strconv.ParseBool(q.Unilateral)
+		if err != nil {
+			return errors.New("invalid unilateral")
+		}
+		filter.Unilateral = &unilateral
+	}
+
+	filter.Equipment = q.Equipment
+	filter.PrimaryMuscle = q.PrimaryMuscle
+	filter.SecondaryMuscle = q.SecondaryMuscle
+	filter.MovementPattern = q.MovementPattern
+	filter.Mechanics = q.Mechanics
+	filter.TrackingProfile = q.TrackingProfile
+
+	return nil
+}
+
+func ToExerciseResp(ex *model.Exercise) *dto.Exercise {
+	e := &dto.Exercise{
+		ID:              ex.ID,
+		Name:            ex.Name,
+		Type:            ex.Type,
+		MuscleGroup:     ex.MuscleGroup,
+		Description:     ex.Description,
+		TrackingProfile: ex.TrackingProfile,
+		MovementPattern: fromNullString(ex.MovementPattern),
+		Mechanics:       fromNullString(ex.Mechanics),
+		Unilateral:      ex.Unilateral,
+	}
+	if ex.Equipment != nil {
+		e.Equipment = toEquipmentResp(ex.Equipment)
+	}
+	for _, m := range ex.PrimaryMuscles {
+		e.PrimaryMuscles = append(e.PrimaryMuscles, toMuscleResp(m))
+	}
+	for _, m := range ex.SecondaryMuscles {
+		e.SecondaryMuscles = append(e.SecondaryMuscles, toMuscleResp(m))
+	}
+
+	return e
+}
+
+func toEquipmentResp(eq *model.Equipment) *dto.Equipment {
+	return &dto.Equipment{
+		Code: eq.Code,
+		Name: eq.Name,
+	}
+}
+
+func toMuscleResp(m *model.Muscle) *dto.Muscle {
+	return &dto.Muscle{
+		Code:        m.Code,
+		Name:        m.Name,
+		MuscleGroup: m.MuscleGroup,
+	}
+}
+
+func ToEquipmentResp(equipment []*model.Equipment) *dto.Page[*dto.Equipment] {
+	resp := make([]*dto.Equipment, 0, len(equipment))
+	for _, eq := range equipment {
+		resp = append(resp, toEquipmentResp(eq))
+	}
+
+	return newPage(resp)
+}
+
+func ToMusclesResp(muscles []*model.Muscle) *dto.Page[*dto.Muscle] {
+	resp := make([]*dto.Muscle, 0, len(muscles))
+	for _, m := range muscles {
+		resp = append(resp, toMuscleResp(m))
+	}
+
+	return newPage(resp)
+}
+
+func ToExerciseMediaResp(m *model.ExerciseMedia) *dto.ExerciseMedia {
+	return &dto.ExerciseMedia{
+		ID:          m.ID,
+		ExerciseID:  m.ExerciseID,
+		Kind:        m.Kind,
+		ContentType: m.ContentType,
+		Size:        m.Size,
+		URL:         "/api/media/" + strconv.FormatInt(m.ID, 10),
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+func ToExerciseMediaListResp(media []*model.ExerciseMedia) *dto.Page[*dto.ExerciseMedia] {
+	resp := make([]*dto.ExerciseMedia, 0, len(media))
+	for _, m := range media {
+		resp = append(resp, ToExerciseMediaResp(m))
+	}
+
+	return newPage(resp)
+}
+
+func FromSubstitutesQuery(userID, exerciseID int64, limit string) (*model.SubstitutesQuery, error) {
+	l, err := parseLimit(limit, 5, 20)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SubstitutesQuery{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+		Limit:      l,
+	}, nil
+}
+
+func ToSubstitutesResp(substitutes []*model.Substitute) *dto.Page[*dto.Substitute] {
+	resp := make([]*dto.Substitute, 0, len(substitutes))
+	for _, s := range substitutes {
+		resp = append(resp, &dto.Substitute{
+			Exercise:      ToExerciseResp(s.Exercise),
+			SharedMuscles: s.SharedMuscles,
+			SamePattern:   s.SamePattern,
+			Score:         s.Score,
+		})
+	}
+
+	return newPage(resp)
+}
+
+func FromSwapExerciseRequest(userID, parentID, entryID int64, r *dto.SwapExerciseRequest) *model.SwapExerciseParams {
+	return &model.SwapExerciseParams{
+		UserID:     userID,
+		ParentID:   parentID,
+		EntryID:    entryID,
+		ExerciseID: r.ExerciseID,
+	}
+}
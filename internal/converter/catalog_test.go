@@ -0,0 +1,58 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func TestFromExercisesQueryCatalogFilters(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name       string
+		query      dto.ExercisesQuery
+		unilateral *bool
+		valid      bool
+	}{
+		{"no filters", dto.ExercisesQuery{}, nil, true},
+		{
+			name: "all filters",
+			query: dto.ExercisesQuery{
+				Equipment:       "barbell",
+				PrimaryMuscle:   "chest",
+				SecondaryMuscle: "triceps",
+				MovementPattern: "horizontal_push",
+				Mechanics:       model.MechanicsCompound,
+				Unilateral:      "false",
+			},
+			unilateral: &no,
+			valid:      true,
+		},
+		{"unilateral", dto.ExercisesQuery{Unilateral: "true"}, &yes, true},
+		{"unknown movement pattern", dto.ExercisesQuery{MovementPattern: "twist"}, nil, false},
+		{"unknown mechanics", dto.ExercisesQuery{Mechanics: "mixed"}, nil, false},
+		{"invalid unilateral", dto.ExercisesQuery{Unilateral: "sometimes"}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := FromExercisesQuery(&tt.query)
+			if (err == nil) != tt.valid {
+				t.Fatalf("FromExercisesQuery() error = %v, want valid %v", err, tt.valid)
+			}
+			if !tt.valid {
+				return
+			}
+			if (filter.Unilateral == nil) != (tt.unilateral == nil) || (filter.Unilateral != nil && *filter.Unilateral != *tt.unilateral) {
+				t.Errorf("unilateral = %v, want %v", filter.Unilateral, tt.unilateral)
+			}
+			q := tt.query
+			if filter.Equipment != q.Equipment || filter.PrimaryMuscle != q.PrimaryMuscle || filter.SecondaryMuscle != q.SecondaryMuscle ||
+				filter.MovementPattern != q.MovementPattern || filter.Mechanics != q.Mechanics {
+				t.Errorf("filter = %+v, want the query %+v", filter, q)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package converter
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+var errInvalidPermission = errors.New("permission must be one of " + strings.Join(model.Permissions, ", "))
+
+func FromInviteAthleteRequest(coachID int64, r *dto.InviteAthleteRequest) (*model.InviteAthleteParams, error) {
+	if !model.IsPermission(r.Permission) {
+		return nil, errInvalidPermission
+	}
+
+	return &model.InviteAthleteParams{
+		CoachID:    coachID,
+		Email:      strings.TrimSpace(r.Email),
+		Permission: r.Permission,
+	}, nil
+}
+
+func FromUpdateCoachRequest(athleteID, coachID int64, r *dto.UpdateCoachRequest) (*model.UpdateCoachingParams, error) {
+	if !model.IsPermission(r.Permission) {
+		return nil, errInvalidPermission
+	}
+
+	return &model.UpdateCoachingParams{
+		CoachID:    coachID,
+		AthleteID:  athleteID,
+		Permission: r.Permission,
+	}, nil
+}
+
+func toCoachingUserResp(u *model.User) *dto.CoachingUser {
+	return &dto.CoachingUser{
+		ID:    u.ID,
+		Name:  u.Name,
+		Email: u.Email,
+	}
+}
+
+func ToCoachingResp(c *model.Coaching) *dto.Coaching {
+	return &dto.Coaching{
+		ID:         c.ID,
+		Coach:      toCoachingUserResp(c.Coach),
+		Athlete:    toCoachingUserResp(c.Athlete),
+		Permission: c.Permission,
+		Status:     c.Status,
+		CreatedAt:  c.CreatedAt,
+		AcceptedAt: fromNullTime(c.AcceptedAt),
+	}
+}
+
+func ToCoachingsResp(coachings []*model.Coaching) *dto.Page[*dto.Coaching] {
+	resp := make([]*dto.Coaching, 0, len(coachings))
+	for _, c := range coachings {
+		resp = append(resp, ToCoachingResp(c))
+	}
+
+	return newPage(resp)
+}
+
+func FromAssignProgramRequest(athleteID int64, r *dto.AssignProgramRequest) (*model.Enrollment, error) {
+	return FromEnrollRequest(athleteID, r.ProgramID, &r.EnrollRequest)
+}
+
+func FromAddCommentRequest(authorID, workoutID int64, r *dto.AddCommentRequest) *model.WorkoutComment {
+	return &model.WorkoutComment{
+		WorkoutID: workoutID,
+		AuthorID:  authorID,
+		Body:      strings.TrimSpace(r.Body),
+	}
+}
+
+func ToWorkoutCommentResp(c *model.WorkoutComment) *dto.WorkoutComment {
+	return &dto.WorkoutComment{
+		ID:         c.ID,
+		WorkoutID:  c.WorkoutID,
+		AuthorID:   c.AuthorID,
+		AuthorName: c.AuthorName,
+		Body:       c.Body,
+		CreatedAt:  c.CreatedAt,
+	}
+}
+
+func ToWorkoutCommentsResp(comments []*model.WorkoutComment) *dto.Page[*dto.WorkoutComment] {
+	resp := make([]*dto.WorkoutComment, 0, len(comments))
+	for _, c := range comments {
+		resp = append(resp, ToWorkoutCommentResp(c))
+	}
+
+	return newPage(resp)
+}
+
+func ToDashboardResp(summaries []*model.AthleteSummary) *dto.Page[*dto.AthleteSummary] {
+	resp := make([]*dto.AthleteSummary, 0, len(summaries))
+	for _, s := range summaries {
+		summary := &dto.AthleteSummary{
+			Athlete:        toCoachingUserResp(s.Coaching.Athlete),
+			Permission:     s.Coaching.Permission,
+			RecentWorkouts: make([]*dto.Workout, 0, len(s.RecentWorkouts)),
+			Adherence:      make([]*dto.AthleteAdherence, 0, len(s.Adherence)),
+		}
+		for _, w := range s.RecentWorkouts {
+			summary.RecentWorkouts = append(summary.RecentWorkouts, ToWorkoutResp(w))
+		}
+		for _, a := range s.Adherence {
+			summary.Adherence = append(summary.Adherence, &dto.AthleteAdherence{
+				EnrollmentID: a.EnrollmentID,
+				Planned:      a.Planned,
+				Completed:    a.Completed,
+				Rate:         a.Rate,
+			})
+		}
+		resp = append(resp, summary)
+	}
+
+	return newPage(resp)
+}
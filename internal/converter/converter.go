@@ -1,11 +1,15 @@
 package converter
 
 import (
+	"database/sql"
 	"github.com/biryanim/workoutbook/internal/api/dto"
 	"github.com/biryanim/workoutbook/internal/model"
 	"github.com/pkg/errors"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 func FromUserRegistrationRequest(u *dto.UserRegisterRequest) *model.CreateUserParams {
@@ -23,46 +27,170 @@ func FromUserLoginRequest(u *dto.UserLoginRequest) *model.LoginUserParams {
 	}
 }
 
+// timestamps are stored without a time zone, so they are always written in UTC
+func toUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func toNullFloat64(f *float64) sql.NullFloat64 {
+	if f == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *f, Valid: true}
+}
+
+func fromNullFloat64(f sql.NullFloat64) *float64 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float64
+}
+
+func fromNullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+func toNullInt32(i *int) sql.NullInt32 {
+	if i == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(*i), Valid: true}
+}
+
+func fromNullInt32(i sql.NullInt32) *int {
+	if !i.Valid {
+		return nil
+	}
+	v := int(i.Int32)
+	return &v
+}
+
 func FromCreateWorkoutRequest(r *dto.Workout) *model.Workout {
 	return &model.Workout{
-		UserID: r.UserId,
-		Date:   r.Date,
-		Name:   r.Name,
-		Notes:  r.Note,
+		UserID:     r.UserId,
+		Date:       r.Date.UTC(),
+		Name:       r.Name,
+		Notes:      r.Note,
+		StartedAt:  toNullTime(r.StartedAt),
+		EndedAt:    toNullTime(r.EndedAt),
+		SessionRPE: toNullFloat64(r.SessionRPE),
+	}
+}
+
+func FromUpdateWorkoutRequest(userID, workoutID int64, r *dto.UpdateWorkoutRequest) *model.UpdateWorkoutParams {
+	return &model.UpdateWorkoutParams{
+		ID:         workoutID,
+		UserID:     userID,
+		Date:       toUTC(r.Date),
+		Name:       r.Name,
+		Notes:      r.Note,
+		StartedAt:  toUTC(r.StartedAt),
+		EndedAt:    toUTC(r.EndedAt),
+		SessionRPE: r.SessionRPE,
+	}
+}
+
+func toWorkoutExerciseResp(ex *model.WorkoutExercise) *dto.WorkoutExercise {
+	dtoEx := &dto.WorkoutExercise{
+		ID:        ex.ID,
+		UUID:      ex.UUID,
+		WorkoutID: ex.WorkoutID,
+		Sets:      ex.Sets,
+		Reps:      ex.Reps,
+		Weight:    ex.Weight,
+		Duration:  ex.Duration,
+		Distance:  ex.Distance,
+		RPE:       fromNullFloat64(ex.RPE),
+		RIR:       fromNullInt32(ex.RIR),
+
+		TargetReps:    fromNullInt32(ex.TargetReps),
+		IsWarmUp:      ex.IsWarmUp,
+		Position:      ex.Position,
+		GroupID:       fromNullInt32(ex.GroupID),
+		CompletedAt:   fromNullTime(ex.CompletedAt),
+		RestSeconds:   fromNullInt32(ex.RestSeconds),
+		Planned:       ex.Planned,
+		AvgHeartRate:  fromNullInt32(ex.AvgHeartRate),
+		MaxHeartRate:  fromNullInt32(ex.MaxHeartRate),
+		ElevationGain: fromNullFloat64(ex.ElevationGain),
+
+		Exercise: dto.Exercise{
+			ID:              ex.ExerciseID,
+			Name:            ex.Exercise.Name,
+			Type:            ex.Exercise.Type,
+			MuscleGroup:     ex.Exercise.MuscleGroup,
+			Description:     ex.Exercise.Description,
+			TrackingProfile: ex.Exercise.TrackingProfile,
+		},
+	}
+	if ex.Cardio != nil {
+		dtoEx.PaceSecondsPerKm = ex.Cardio.PaceSecondsPerKm
+		dtoEx.SpeedKmh = ex.Cardio.SpeedKmh
+		dtoEx.Calories = ex.Cardio.Calories
+	}
+	for _, sp := range ex.Splits {
+		dtoEx.Splits = append(dtoEx.Splits, &dto.Split{
+			Number:           sp.Number,
+			Distance:         sp.Distance,
+			Duration:         sp.Duration,
+			AvgHeartRate:     fromNullInt32(sp.AvgHeartRate),
+			ElevationGain:    fromNullFloat64(sp.ElevationGain),
+			PaceSecondsPerKm: sp.PaceSecondsPerKm,
+		})
 	}
+
+	return dtoEx
 }
 
 func ToGetWorkoutResp(w *model.WorkoutExercises) *dto.WorkoutExercises {
 	var (
 		exercises []*dto.WorkoutExercise
+		blocks    = make([]*dto.ExerciseBlock, 0, len(w.Blocks))
+		byID      = make(map[int64]*dto.WorkoutExercise, len(w.Exercises))
 	)
 	for _, ex := range w.Exercises {
-		dtoEx := &dto.WorkoutExercise{
-			Sets:     ex.Sets,
-			Reps:     ex.Reps,
-			Weight:   ex.Weight,
-			Duration: ex.Duration,
-			Distance: ex.Distance,
-			Exercise: dto.Exercise{
-				Name:        ex.Exercise.Name,
-				Type:        ex.Exercise.Type,
-				MuscleGroup: ex.Exercise.MuscleGroup,
-				Description: ex.Exercise.Description,
-			},
-		}
+		dtoEx := toWorkoutExerciseResp(ex)
+		byID[ex.ID] = dtoEx
 		exercises = append(exercises, dtoEx)
 	}
 
-	workout := dto.Workout{
-		ID:   w.Workout.ID,
-		Date: w.Workout.Date,
-		Name: w.Workout.Name,
-		Note: w.Workout.Notes,
+	for _, b := range w.Blocks {
+		block := &dto.ExerciseBlock{
+			GroupID:   fromNullInt32(b.GroupID),
+			Kind:      b.Kind,
+			Exercises: make([]*dto.WorkoutExercise, 0, len(b.Exercises)),
+		}
+		for _, ex := range b.Exercises {
+			block.Exercises = append(block.Exercises, byID[ex.ID])
+		}
+		blocks = append(blocks, block)
 	}
 
 	return &dto.WorkoutExercises{
-		Workout:   workout,
+		Workout:   *ToWorkoutResp(w.Workout),
 		Exercises: exercises,
+		Blocks:    blocks,
 	}
 
 }
@@ -86,53 +214,82 @@ func FromPaginationToFilter(pag *dto.Pagination) (*model.WorkoutsFilter, error)
 		}
 	}
 
-	if len(pag.Limit) != 0 {
-		filter.Limit, err = strconv.ParseUint(pag.Limit, 10, 64)
-		if err != nil {
+	filter.Limit, err = parseLimit(pag.Limit, 10, 30)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pag.Cursor) != 0 {
+		var c workoutCursor
+		if err = decodeCursor(pag.Cursor, &c); err != nil {
 			return nil, err
 		}
-
-		if filter.Limit > 30 || filter.Limit < 1 {
-			return nil, errors.New("limit must be between 1 and 30")
+		if c.Date.IsZero() || c.ID < 1 {
+			return nil, errInvalidCursor
 		}
-	} else {
-		filter.Limit = 10
+		filter.After = &model.WorkoutCursor{Date: c.Date, ID: c.ID}
 	}
 
-	var page uint64
-	if len(pag.Page) != 0 {
-		page, err = strconv.ParseUint(pag.Page, 10, 64)
-		if err != nil {
-			return nil, err
-		}
-		if page < 1 {
-			return nil, errors.New("page must be greater or equal than 1")
+	if len(pag.ExerciseID) != 0 {
+		filter.ExerciseID, err = strconv.ParseInt(pag.ExerciseID, 10, 64)
+		if err != nil || filter.ExerciseID < 1 {
+			return nil, errors.New("invalid exercise_id")
 		}
-	} else {
-		page = 1
 	}
 
-	filter.Offset = (page - 1) * filter.Limit
+	filter.MuscleGroup = pag.MuscleGroup
+	filter.Search = strings.TrimSpace(pag.Search)
+
+	filter.Tags, err = FromTagQuery(&pag.TagQuery)
+	if err != nil {
+		return nil, err
+	}
+
 	return &filter, nil
 }
 
 func ToWorkoutResp(w *model.Workout) *dto.Workout {
-	return &dto.Workout{
-		ID:     w.ID,
-		Date:   w.Date,
-		Name:   w.Name,
-		UserId: w.UserID,
-		Note:   w.Notes,
+	resp := &dto.Workout{
+		ID:         w.ID,
+		UUID:       w.UUID,
+		Date:       w.Date,
+		Name:       w.Name,
+		UserId:     w.UserID,
+		Note:       w.Notes,
+		StartedAt:  fromNullTime(w.StartedAt),
+		EndedAt:    fromNullTime(w.EndedAt),
+		SessionRPE: fromNullFloat64(w.SessionRPE),
+	}
+
+	if w.AssignedBy.Valid {
+		resp.AssignedBy = &w.AssignedBy.Int64
 	}
+
+	if d, ok := w.Duration(); ok {
+		seconds := int64(d.Seconds())
+		resp.DurationSeconds = &seconds
+	}
+
+	for _, t := range w.Tags {
+		resp.Tags = append(resp.Tags, ToTagResp(t))
+	}
+
+	return resp
 }
 
-func ToWorkoutsResp(workouts []*model.Workout) []*dto.Workout {
-	var wrks []*dto.Workout
-	for _, w := range workouts {
-		wrks = append(wrks, ToWorkoutResp(w))
+func ToWorkoutsResp(page *model.WorkoutsPage) *dto.Page[*dto.Workout] {
+	resp := &dto.Page[*dto.Workout]{
+		Items: make([]*dto.Workout, 0, len(page.Workouts)),
+		Total: page.Total,
+	}
+	for _, w := range page.Workouts {
+		resp.Items = append(resp.Items, ToWorkoutResp(w))
+	}
+	if page.Next != nil {
+		resp.NextCursor = encodeCursor(workoutCursor{Date: page.Next.Date, ID: page.Next.ID})
 	}
 
-	return wrks
+	return resp
 }
 
 func FromAddExerciseToWorkout(d *dto.WorkoutExercise) *model.WorkoutExercise {
@@ -144,6 +301,19 @@ func FromAddExerciseToWorkout(d *dto.WorkoutExercise) *model.WorkoutExercise {
 		Weight:     d.Weight,
 		Duration:   d.Duration,
 		Distance:   d.Distance,
+		RPE:        toNullFloat64(d.RPE),
+		RIR:        toNullInt32(d.RIR),
+		TargetReps: toNullInt32(d.TargetReps),
+		IsWarmUp:   d.IsWarmUp,
+		Position:   d.Position,
+		GroupID:    toNullInt32(d.GroupID),
+		GroupKind:  d.GroupKind,
+
+		AvgHeartRate:  toNullInt32(d.AvgHeartRate),
+		MaxHeartRate:  toNullInt32(d.MaxHeartRate),
+		ElevationGain: toNullFloat64(d.ElevationGain),
+		Splits:        fromSplits(d.Splits),
+
 		Exercise: model.Exercise{
 			ID:          d.Exercise.ID,
 			Name:        d.Exercise.Name,
@@ -153,19 +323,179 @@ func FromAddExerciseToWorkout(d *dto.WorkoutExercise) *model.WorkoutExercise {
 		},
 	}
 }
+func fromSplits(splits []*dto.Split) []*model.Split {
+	var res []*model.Split
+	for _, sp := range splits {
+		res = append(res, &model.Split{
+			Distance:      sp.Distance,
+			Duration:      sp.Duration,
+			AvgHeartRate:  toNullInt32(sp.AvgHeartRate),
+			ElevationGain: toNullFloat64(sp.ElevationGain),
+		})
+	}
+	return res
+}
+
 func ToListExercisesResp(exercises []*model.Exercise) []*dto.Exercise {
-	var wrks []*dto.Exercise
+	wrks := make([]*dto.Exercise, 0, len(exercises))
 	for _, ex := range exercises {
-		e := &dto.Exercise{
-			ID:          ex.ID,
-			Name:        ex.Name,
-			Type:        ex.Type,
-			MuscleGroup: ex.MuscleGroup,
-			Description: ex.Description,
+		wrks = append(wrks, ToExerciseResp(ex))
+	}
+
+	return wrks
+}
+
+func ToPersonalRecordsResp(r *model.PersonalRecords) *dto.PersonalRecords {
+	resp := &dto.PersonalRecords{
+		Page: dto.Page[*dto.PersonalRecord]{
+			Items: make([]*dto.PersonalRecord, 0, len(r.Records)),
+			Total: int64(len(r.Records)),
+		},
+	}
+
+	for _, rec := range r.Records {
+		record := &dto.PersonalRecord{
+			ID:         rec.ID,
+			ExerciseID: rec.ExerciseID,
+			Weight:     rec.Weight,
+			Reps:       rec.Reps,
+			Duration:   rec.Duration,
+			Distance:   rec.Distance,
+			Date:       rec.Date,
+			Exercise: dto.Exercise{
+				ID:              rec.ExerciseID,
+				Name:            rec.Exercise.Name,
+				Type:            rec.Exercise.Type,
+				MuscleGroup:     rec.Exercise.MuscleGroup,
+				Description:     rec.Exercise.Description,
+				TrackingProfile: rec.Exercise.TrackingProfile,
+			},
+		}
+		if rec.Strength != nil {
+			record.Strength = &dto.StrengthScore{
+				EstimatedOneRM:     rec.Strength.EstimatedOneRM,
+				BodyweightMultiple: rec.Strength.BodyweightMultiple,
+				Wilks:              rec.Strength.Wilks,
+				DOTS:               rec.Strength.DOTS,
+				IPFGL:              rec.Strength.IPFGL,
+				Level:              rec.Strength.Level,
+				NextLevel:          rec.Strength.NextLevel,
+				NextLevelOneRM:     rec.Strength.NextLevelOneRM,
+			}
 		}
+		resp.Items = append(resp.Items, record)
+	}
 
-		wrks = append(wrks, e)
+	if r.Summary != nil {
+		resp.Summary = &dto.StrengthSummary{
+			Sex:        r.Summary.Sex,
+			Bodyweight: r.Summary.Bodyweight,
+			Total:      r.Summary.Total,
+			Wilks:      r.Summary.Wilks,
+			DOTS:       r.Summary.DOTS,
+			IPFGL:      r.Summary.IPFGL,
+		}
 	}
 
-	return wrks
+	return resp
+}
+
+func ToStrengthStandardsResp(s *model.ExerciseStandards) *dto.StrengthStandards {
+	resp := &dto.StrengthStandards{
+		ExerciseID: s.ExerciseID,
+		Custom:     s.Custom,
+		Levels:     make([]*dto.StrengthLevel, 0, len(s.Levels)),
+	}
+	for _, level := range s.Levels {
+		resp.Levels = append(resp.Levels, &dto.StrengthLevel{
+			Level:              level.Level,
+			BodyweightMultiple: level.BodyweightMultiple,
+		})
+	}
+
+	return resp
+}
+
+func FromSetStrengthStandardsRequest(exerciseID int64, r *dto.SetStrengthStandardsRequest) *model.ExerciseStandards {
+	standards := &model.ExerciseStandards{
+		ExerciseID: exerciseID,
+		Custom:     true,
+		Levels:     make([]*model.StrengthStandard, 0, len(r.Levels)),
+	}
+	for _, level := range r.Levels {
+		standards.Levels = append(standards.Levels, &model.StrengthStandard{
+			ExerciseID:         exerciseID,
+			Level:              strings.TrimSpace(level.Level),
+			BodyweightMultiple: level.BodyweightMultiple,
+			Custom:             true,
+		})
+	}
+
+	return standards
+}
+
+func FromActivityImportRequest(userID int64, r *dto.ActivityImportRequest, format string, raw []byte) *model.ActivityImport {
+	return &model.ActivityImport{
+		UserID:     userID,
+		ExerciseID: r.ExerciseID,
+		Name:       r.Name,
+		Format:     format,
+		Raw:        raw,
+	}
+}
+
+// FromSetExerciseMappingRequest maps the external name, lowercased as imports
+// look it up, of source onto the requested exercise.
+func FromSetExerciseMappingRequest(source, externalName string, r *dto.SetExerciseMappingRequest) (*model.ExerciseMapping, error) {
+	if !slices.Contains(model.MappingSources, source) {
+		return nil, errors.New("invalid source")
+	}
+	externalName = strings.ToLower(strings.TrimSpace(externalName))
+	if externalName == "" || utf8.RuneCountInString(externalName) > 100 {
+		return nil, errors.New("external name must be 1 to 100 characters")
+	}
+
+	return &model.ExerciseMapping{
+		Source:       source,
+		ExternalName: externalName,
+		ExerciseID:   r.ExerciseID,
+	}, nil
+}
+
+func ToExerciseMappingResp(m *model.ExerciseMapping) *dto.ExerciseMapping {
+	return &dto.ExerciseMapping{
+		Source:       m.Source,
+		ExternalName: m.ExternalName,
+		ExerciseID:   m.ExerciseID,
+	}
+}
+
+func ToExerciseMappingsResp(mappings []*model.ExerciseMapping) *dto.Page[*dto.ExerciseMapping] {
+	resp := make([]*dto.ExerciseMapping, 0, len(mappings))
+	for _, m := range mappings {
+		resp = append(resp, ToExerciseMappingResp(m))
+	}
+
+	return newPage(resp)
+}
+
+func FromReorderExercisesRequest(userID, workoutID int64, r *dto.ReorderExercisesRequest) *model.ReorderExercisesParams {
+	params := &model.ReorderExercisesParams{
+		UserID:    userID,
+		WorkoutID: workoutID,
+	}
+	for _, o := range r.Order {
+		params.Order = append(params.Order, &model.ExerciseOrder{
+			WorkoutExerciseID: o.WorkoutExerciseID,
+			GroupID:           toNullInt32(o.GroupID),
+		})
+	}
+	for _, g := range r.Groups {
+		params.Groups = append(params.Groups, &model.ExerciseGroup{
+			GroupID: int32(g.GroupID),
+			Kind:    g.Kind,
+		})
+	}
+
+	return params
 }
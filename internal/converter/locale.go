@@ -0,0 +1,26 @@
+package converter
+
+import (
+	"slices"
+
+	"github.com/biryanim/workoutbook/internal/model"
+	"golang.org/x/text/language"
+)
+
+// FromAcceptLanguage returns the most preferred supported locale of an
+// Accept-Language header, or an empty string when none is supported.
+func FromAcceptLanguage(header string) string {
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return ""
+	}
+
+	for _, tag := range tags {
+		base, _ := tag.Base()
+		if slices.Contains(model.Locales, base.String()) {
+			return base.String()
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,33 @@
+package converter
+
+import (
+	"testing"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"ru", model.LocaleRussian},
+		{"en-US", model.LocaleEnglish},
+		{"en-GB,en;q=0.9", model.LocaleEnglish},
+		{"de-DE,de;q=0.9,ru;q=0.8,en;q=0.7", model.LocaleRussian},
+		{"ru;q=0.5,en;q=0.8", model.LocaleEnglish},
+		{"ru-RU;q=0.9,*;q=0.1", model.LocaleRussian},
+		{"fr,de", ""},
+		{"*", ""},
+		{"not a language;q=x", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := FromAcceptLanguage(tt.header); got != tt.want {
+				t.Errorf("FromAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package converter
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// Cursors are opaque to clients: the position of the last item of a page,
+// encoded so that its shape can change without breaking them.
+type workoutCursor struct {
+	Date time.Time `json:"d"`
+	ID   int64     `json:"i"`
+}
+
+type exerciseCursor struct {
+	Name string `json:"n"`
+	ID   int64  `json:"i"`
+}
+
+func encodeCursor(v interface{}) string {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(s string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return errInvalidCursor
+	}
+	if err = json.Unmarshal(raw, v); err != nil {
+		return errInvalidCursor
+	}
+	return nil
+}
+
+func parseLimit(s string, def, max uint64) (uint64, error) {
+	if len(s) == 0 {
+		return def, nil
+	}
+	limit, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || limit < 1 || limit > max {
+		return 0, errors.New("limit must be between 1 and " + strconv.FormatUint(max, 10))
+	}
+	return limit, nil
+}
+
+// newPage wraps a complete list; there is never a next page.
+func newPage[T any](items []T) *dto.Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return &dto.Page[T]{Items: items, Total: int64(len(items))}
+}
+
+func FromExercisesQuery(q *dto.ExercisesQuery) (*model.ExercisesFilter, error) {
+	limit, err := parseLimit(q.Limit, 50, 200)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &model.ExercisesFilter{
+		Type:        q.Type,
+		MuscleGroup: q.MuscleGroup,
+		Limit:       limit,
+	}
+	if err = checkExercisesQuery(q, filter); err != nil {
+		return nil, err
+	}
+	if len(q.Cursor) != 0 {
+		var c exerciseCursor
+		if err = decodeCursor(q.Cursor, &c); err != nil {
+			return nil, err
+		}
+		if c.Name == "" || c.ID < 1 {
+			return nil, errInvalidCursor
+		}
+		filter.After = &model.ExerciseCursor{Name: c.Name, ID: c.ID}
+	}
+
+	return filter, nil
+}
+
+func ToExercisesPageResp(page *model.ExercisesPage) *dto.Page[*dto.Exercise] {
+	resp := &dto.Page[*dto.Exercise]{
+		Items: ToListExercisesResp(page.Exercises),
+		Total: page.Total,
+	}
+	if page.Next != nil {
+		resp.NextCursor = encodeCursor(exerciseCursor{Name: page.Next.Name, ID: page.Next.ID})
+	}
+	return resp
+}
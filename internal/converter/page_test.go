@@ -0,0 +1,136 @@
+package converter
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func TestWorkoutCursorRoundTrip(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name string
+		next *model.WorkoutCursor
+	}{
+		{"utc", &model.WorkoutCursor{Date: time.Date(2025, 9, 1, 6, 30, 0, 0, time.UTC), ID: 42}},
+		{"sub-second precision", &model.WorkoutCursor{Date: time.Date(2025, 9, 1, 6, 30, 0, 123456000, time.UTC), ID: 7}},
+		{"other timezone", &model.WorkoutCursor{Date: time.Date(2025, 1, 1, 0, 15, 0, 0, moscow), ID: 1 << 40}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := ToWorkoutsResp(&model.WorkoutsPage{Next: tt.next})
+			if resp.NextCursor == "" {
+				t.Fatal("next cursor is empty")
+			}
+
+			filter, err := FromPaginationToFilter(&dto.Pagination{Cursor: resp.NextCursor})
+			if err != nil {
+				t.Fatalf("FromPaginationToFilter() error = %v", err)
+			}
+			if filter.After == nil || !filter.After.Date.Equal(tt.next.Date) || filter.After.ID != tt.next.ID {
+				t.Errorf("after = %+v, want %+v", filter.After, tt.next)
+			}
+		})
+	}
+}
+
+func TestWorkoutsRespLastPage(t *testing.T) {
+	if resp := ToWorkoutsResp(&model.WorkoutsPage{}); resp.NextCursor != "" {
+		t.Errorf("next cursor = %q, want none on the last page", resp.NextCursor)
+	}
+}
+
+func TestFromPaginationToFilterInvalidCursor(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+	valid := encodeCursor(workoutCursor{Date: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), ID: 42})
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "not a cursor!"},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte(`{"d":"2025-09-01T00:00:00Z","i":4}`))},
+		{"truncated", valid[:len(valid)-3]},
+		{"flipped character", "X" + valid[1:]},
+		{"not json", encode("42")},
+		{"wrong types", encode(`{"d":1,"i":"42"}`)},
+		{"bad date", encode(`{"d":"yesterday","i":42}`)},
+		{"empty object", encode(`{}`)},
+		{"missing id", encode(`{"d":"2025-09-01T00:00:00Z"}`)},
+		{"negative id", encode(`{"d":"2025-09-01T00:00:00Z","i":-1}`)},
+		{"missing date", encode(`{"i":42}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if filter, err := FromPaginationToFilter(&dto.Pagination{Cursor: tt.cursor}); err == nil {
+				t.Errorf("FromPaginationToFilter() after = %+v, want an error", filter.After)
+			}
+		})
+	}
+}
+
+func TestExerciseCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		next *model.ExerciseCursor
+	}{
+		{"ascii", &model.ExerciseCursor{Name: "Bench press", ID: 3}},
+		{"cyrillic", &model.ExerciseCursor{Name: "Жим лёжа", ID: 17}},
+		{"quotes and separators", &model.ExerciseCursor{Name: `Farmer's "walk", 1/2`, ID: 1 << 40}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := ToExercisesPageResp(&model.ExercisesPage{Next: tt.next})
+			if resp.NextCursor == "" {
+				t.Fatal("next cursor is empty")
+			}
+
+			filter, err := FromExercisesQuery(&dto.ExercisesQuery{Cursor: resp.NextCursor})
+			if err != nil {
+				t.Fatalf("FromExercisesQuery() error = %v", err)
+			}
+			if filter.After == nil || *filter.After != *tt.next {
+				t.Errorf("after = %+v, want %+v", filter.After, tt.next)
+			}
+		})
+	}
+}
+
+func TestFromExercisesQueryInvalidCursor(t *testing.T) {
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+	valid := encodeCursor(exerciseCursor{Name: "Bench press", ID: 3})
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "not a cursor!"},
+		{"truncated", valid[:len(valid)-3]},
+		{"not json", encode(`"Bench press"`)},
+		{"wrong types", encode(`{"n":3,"i":"3"}`)},
+		{"empty object", encode(`{}`)},
+		{"missing name", encode(`{"i":3}`)},
+		{"missing id", encode(`{"n":"Bench press"}`)},
+		{"zero id", encode(`{"n":"Bench press","i":0}`)},
+		{"workout cursor", encodeCursor(workoutCursor{Date: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC), ID: 42})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if filter, err := FromExercisesQuery(&dto.ExercisesQuery{Cursor: tt.cursor}); err == nil {
+				t.Errorf("FromExercisesQuery() after = %+v, want an error", filter.After)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package converter
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func FromPlateInventoryRequest(userID int64, r *dto.PlateInventory) *model.PlateInventory {
+	inv := &model.PlateInventory{
+		UserID:    userID,
+		Unit:      r.Unit,
+		BarWeight: r.BarWeight,
+	}
+	for _, p := range r.Plates {
+		inv.Plates = append(inv.Plates, &model.PlateCount{Weight: p.Weight, Count: p.Count})
+	}
+
+	return inv
+}
+
+func toPlateCountsResp(plates []*model.PlateCount) []*dto.PlateCount {
+	resp := make([]*dto.PlateCount, 0, len(plates))
+	for _, p := range plates {
+		resp = append(resp, &dto.PlateCount{Weight: p.Weight, Count: p.Count})
+	}
+
+	return resp
+}
+
+func ToPlateInventoryResp(inv *model.PlateInventory) *dto.PlateInventory {
+	return &dto.PlateInventory{
+		Unit:      inv.Unit,
+		BarWeight: inv.BarWeight,
+		Plates:    toPlateCountsResp(inv.Plates),
+	}
+}
+
+func parseUnit(s string) (string, error) {
+	switch s {
+	case "", model.UnitKg, model.UnitLb:
+		return s, nil
+	default:
+		return "", errors.New("unit must be kg or lb")
+	}
+}
+
+func parseBarWeight(s string) (sql.NullFloat64, error) {
+	if len(s) == 0 {
+		return sql.NullFloat64{}, nil
+	}
+
+	bar, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(bar) || bar < 0 || bar > 100 {
+		return sql.NullFloat64{}, errors.New("bar_weight must be between 0 and 100")
+	}
+
+	return sql.NullFloat64{Float64: bar, Valid: true}, nil
+}
+
+func FromPlateLoadQuery(userID int64, q *dto.PlateLoadQuery) (*model.PlateLoadParams, error) {
+	target, err := strconv.ParseFloat(q.Weight, 64)
+	if err != nil || math.IsNaN(target) || target <= 0 || target >= 1000 {
+		return nil, errors.New("weight must be between 0 and 1000")
+	}
+
+	unit, err := parseUnit(q.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	bar, err := parseBarWeight(q.BarWeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PlateLoadParams{
+		UserID:    userID,
+		Target:    target,
+		Unit:      unit,
+		BarWeight: bar,
+	}, nil
+}
+
+func FromWarmUpQuery(userID int64, q *dto.PlateLoadQuery) (*model.WarmUpParams, error) {
+	params, err := FromPlateLoadQuery(userID, q)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.WarmUpParams{
+		UserID:        userID,
+		WorkingWeight: params.Target,
+		Unit:          params.Unit,
+		BarWeight:     params.BarWeight,
+	}, nil
+}
+
+func FromWarmUpRequest(userID, workoutID int64, r *dto.WarmUpRequest) *model.InsertWarmUpParams {
+	return &model.InsertWarmUpParams{
+		WarmUpParams: model.WarmUpParams{
+			UserID:        userID,
+			WorkingWeight: r.WorkingWeight,
+			Unit:          r.Unit,
+			BarWeight:     toNullFloat64(r.BarWeight),
+		},
+		WorkoutID:  workoutID,
+		ExerciseID: r.ExerciseID,
+	}
+}
+
+func ToPlateLoadingResp(l *model.PlateLoading) *dto.PlateLoading {
+	return &dto.PlateLoading{
+		Unit:      l.Unit,
+		Target:    l.Target,
+		BarWeight: l.BarWeight,
+		Achieved:  l.Achieved,
+		PerSide:   toPlateCountsResp(l.PerSide),
+		Exact:     l.Exact,
+	}
+}
+
+func ToWarmUpResp(sets []*model.WarmUpSet) []*dto.WarmUpSet {
+	resp := make([]*dto.WarmUpSet, 0, len(sets))
+	for _, s := range sets {
+		resp = append(resp, &dto.WarmUpSet{
+			Percent: s.Percent,
+			Reps:    s.Reps,
+			Loading: ToPlateLoadingResp(s.Loading),
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,58 @@
+package converter
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func TestFromPlateLoadQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   dto.PlateLoadQuery
+		want    *model.PlateLoadParams
+		wantErr bool
+	}{
+		{
+			name:  "weight only",
+			query: dto.PlateLoadQuery{Weight: "100"},
+			want:  &model.PlateLoadParams{UserID: 1, Target: 100},
+		},
+		{
+			name:  "unit and bar",
+			query: dto.PlateLoadQuery{Weight: "225", Unit: model.UnitLb, BarWeight: "45"},
+			want:  &model.PlateLoadParams{UserID: 1, Target: 225, Unit: model.UnitLb, BarWeight: sql.NullFloat64{Float64: 45, Valid: true}},
+		},
+		{
+			name:  "zero bar",
+			query: dto.PlateLoadQuery{Weight: "50", BarWeight: "0"},
+			want:  &model.PlateLoadParams{UserID: 1, Target: 50, BarWeight: sql.NullFloat64{Valid: true}},
+		},
+		{name: "missing weight", query: dto.PlateLoadQuery{}, wantErr: true},
+		{name: "not a number", query: dto.PlateLoadQuery{Weight: "heavy"}, wantErr: true},
+		{name: "NaN weight", query: dto.PlateLoadQuery{Weight: "NaN"}, wantErr: true},
+		{name: "lowercase nan weight", query: dto.PlateLoadQuery{Weight: "nan"}, wantErr: true},
+		{name: "infinite weight", query: dto.PlateLoadQuery{Weight: "Inf"}, wantErr: true},
+		{name: "zero weight", query: dto.PlateLoadQuery{Weight: "0"}, wantErr: true},
+		{name: "negative weight", query: dto.PlateLoadQuery{Weight: "-20"}, wantErr: true},
+		{name: "too heavy", query: dto.PlateLoadQuery{Weight: "1000"}, wantErr: true},
+		{name: "unknown unit", query: dto.PlateLoadQuery{Weight: "100", Unit: "st"}, wantErr: true},
+		{name: "NaN bar", query: dto.PlateLoadQuery{Weight: "100", BarWeight: "NaN"}, wantErr: true},
+		{name: "negative bar", query: dto.PlateLoadQuery{Weight: "100", BarWeight: "-1"}, wantErr: true},
+		{name: "heavy bar", query: dto.PlateLoadQuery{Weight: "100", BarWeight: "101"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FromPlateLoadQuery(1, &tt.query)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FromPlateLoadQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && *got != *tt.want {
+				t.Errorf("FromPlateLoadQuery() = %+v, want %+v", *got, *tt.want)
+			}
+		})
+	}
+}
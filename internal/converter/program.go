@@ -0,0 +1,248 @@
+package converter
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func FromProgramRequest(userID int64, r *dto.Program) *model.Program {
+	program := &model.Program{
+		UserID:      sql.NullInt64{Int64: userID, Valid: true},
+		Name:        r.Name,
+		Description: r.Description,
+		Weeks:       r.Weeks,
+	}
+
+	for _, d := range r.Days {
+		day := &model.ProgramDay{
+			Week: d.Week,
+			Day:  d.Day,
+			Name: d.Name,
+		}
+		for _, e := range d.Exercises {
+			day.Exercises = append(day.Exercises, &model.ProgramExercise{
+				ExerciseID: e.ExerciseID,
+				Sets:       e.Sets,
+				Reps:       e.Reps,
+				Type:       e.Type,
+				Value:      e.Value,
+				AMRAP:      e.AMRAP,
+				Notes:      e.Notes,
+			})
+		}
+		program.Days = append(program.Days, day)
+	}
+
+	return program
+}
+
+func ToProgramResp(p *model.Program) *dto.Program {
+	resp := &dto.Program{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Weeks:       p.Weeks,
+		Shared:      !p.UserID.Valid,
+		Generator:   p.Generator.String,
+		CreatedAt:   p.CreatedAt,
+	}
+
+	for _, d := range p.Days {
+		day := &dto.ProgramDay{
+			Week:      d.Week,
+			Day:       d.Day,
+			Name:      d.Name,
+			Exercises: make([]*dto.ProgramExercise, 0, len(d.Exercises)),
+		}
+		for _, e := range d.Exercises {
+			day.Exercises = append(day.Exercises, &dto.ProgramExercise{
+				ExerciseID: e.ExerciseID,
+				Exercise: &dto.Exercise{
+					ID:          e.Exercise.ID,
+					Name:        e.Exercise.Name,
+					Type:        e.Exercise.Type,
+					MuscleGroup: e.Exercise.MuscleGroup,
+					Description: e.Exercise.Description,
+				},
+				Sets:  e.Sets,
+				Reps:  e.Reps,
+				Type:  e.Type,
+				Value: e.Value,
+				AMRAP: e.AMRAP,
+				Notes: e.Notes,
+			})
+		}
+		resp.Days = append(resp.Days, day)
+	}
+
+	return resp
+}
+
+func ToProgramsResp(programs []*model.Program) *dto.Page[*dto.Program] {
+	resp := make([]*dto.Program, 0, len(programs))
+	for _, p := range programs {
+		resp = append(resp, ToProgramResp(p))
+	}
+
+	return newPage(resp)
+}
+
+func parseDate(s string) (time.Time, error) {
+	date, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return time.Time{}, errors.New("invalid date format")
+	}
+
+	return date, nil
+}
+
+// FromEnrollRequest takes at most one training max per exercise.
+func FromEnrollRequest(userID, programID int64, r *dto.EnrollRequest) (*model.Enrollment, error) {
+	start, err := parseDate(r.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	enrollment := &model.Enrollment{
+		UserID:    userID,
+		ProgramID: programID,
+		StartDate: start,
+	}
+	seen := make(map[int64]bool, len(r.TrainingMaxes))
+	for _, tm := range r.TrainingMaxes {
+		if seen[tm.ExerciseID] {
+			return nil, fmt.Errorf("duplicate training max for exercise %d", tm.ExerciseID)
+		}
+		seen[tm.ExerciseID] = true
+		enrollment.TrainingMaxes = append(enrollment.TrainingMaxes, &model.TrainingMax{
+			ExerciseID:  tm.ExerciseID,
+			TrainingMax: tm.TrainingMax,
+		})
+	}
+
+	return enrollment, nil
+}
+
+func ToEnrollmentsResp(enrollments []*model.Enrollment) *dto.Page[*dto.Enrollment] {
+	resp := make([]*dto.Enrollment, 0, len(enrollments))
+	for _, e := range enrollments {
+		resp = append(resp, &dto.Enrollment{
+			ID:        e.ID,
+			ProgramID: e.ProgramID,
+			StartDate: e.StartDate.Format(time.DateOnly),
+			Active:    e.Active,
+		})
+	}
+
+	return newPage(resp)
+}
+
+// FromScheduleDate parses the requested date, defaulting to today.
+func FromScheduleDate(s string) (time.Time, error) {
+	if len(s) == 0 {
+		return time.Now().UTC(), nil
+	}
+
+	return parseDate(s)
+}
+
+func FromLinkSessionRequest(enrollmentID int64, r *dto.LinkSessionRequest) (*model.EnrollmentSession, error) {
+	date, err := parseDate(r.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.EnrollmentSession{
+		EnrollmentID: enrollmentID,
+		PlannedDate:  date,
+		WorkoutID:    r.WorkoutID,
+	}, nil
+}
+
+func ToScheduledSessionResp(s *model.ScheduledSession) *dto.ScheduledSession {
+	resp := &dto.ScheduledSession{
+		Date:      s.Date.Format(time.DateOnly),
+		Week:      s.Week,
+		Day:       s.Day,
+		Name:      s.Name,
+		Exercises: make([]*dto.ScheduledExercise, 0, len(s.Exercises)),
+	}
+	if s.WorkoutID.Valid {
+		resp.WorkoutID = &s.WorkoutID.Int64
+	}
+
+	for _, e := range s.Exercises {
+		resp.Exercises = append(resp.Exercises, ToScheduledExerciseResp(e))
+	}
+
+	return resp
+}
+
+func ToScheduledExerciseResp(e *model.ScheduledExercise) *dto.ScheduledExercise {
+	resp := &dto.ScheduledExercise{
+		ProgramExerciseID: e.ProgramExerciseID,
+		ExerciseID:        e.ExerciseID,
+		Name:              e.Exercise.Name,
+		Sets:              e.Sets,
+		Reps:              e.Reps,
+		Type:              e.Type,
+		Value:             e.Value,
+		Weight:            fromNullFloat64(e.Weight),
+		TargetRPE:         fromNullFloat64(e.TargetRPE),
+		AMRAP:             e.AMRAP,
+		Notes:             e.Notes,
+	}
+	if e.ReplacedExerciseID.Valid {
+		resp.ReplacedExerciseID = &e.ReplacedExerciseID.Int64
+	}
+
+	return resp
+}
+
+func ToAdherenceResp(a *model.Adherence) *dto.Adherence {
+	resp := &dto.Adherence{
+		EnrollmentID: a.EnrollmentID,
+		Planned:      a.Planned,
+		Completed:    a.Completed,
+		Rate:         a.Rate,
+		Sessions:     make([]*dto.ScheduledSession, 0, len(a.Sessions)),
+	}
+	for _, s := range a.Sessions {
+		resp.Sessions = append(resp.Sessions, ToScheduledSessionResp(s))
+	}
+
+	return resp
+}
+
+func ToGeneratorsResp(infos []model.GeneratorInfo) *dto.Page[*dto.Generator] {
+	resp := make([]*dto.Generator, 0, len(infos))
+	for _, info := range infos {
+		resp = append(resp, &dto.Generator{
+			Name:         info.Name,
+			Description:  info.Description,
+			DefaultWeeks: info.DefaultWeeks,
+		})
+	}
+
+	return newPage(resp)
+}
+
+func FromGenerateProgramRequest(userID int64, r *dto.GenerateProgramRequest) (*model.GenerateProgramParams, error) {
+	start, err := FromScheduleDate(r.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.GenerateProgramParams{
+		UserID:    userID,
+		Generator: r.Generator,
+		Weeks:     r.Weeks,
+		StartDate: start,
+		Failures:  r.Failures,
+	}, nil
+}
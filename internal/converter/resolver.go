@@ -0,0 +1,76 @@
+package converter
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+// maxResolveQueryLength bounds the resolved name in characters, the longest
+// name an exercise or alias may have.
+const maxResolveQueryLength = 100
+
+func FromResolveQuery(userID int64, q *dto.ResolveQuery) (*model.ResolveQuery, error) {
+	text := strings.TrimSpace(q.Query)
+	if len(text) == 0 {
+		return nil, errors.New("q is required")
+	}
+	if utf8.RuneCountInString(text) > maxResolveQueryLength {
+		return nil, errors.New("q is too long")
+	}
+
+	limit, err := parseLimit(q.Limit, 5, 20)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ResolveQuery{
+		UserID: userID,
+		Text:   text,
+		Limit:  limit,
+	}, nil
+}
+
+func ToExerciseCandidatesResp(candidates []*model.ExerciseCandidate) *dto.Page[*dto.ExerciseCandidate] {
+	resp := make([]*dto.ExerciseCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		resp = append(resp, &dto.ExerciseCandidate{
+			Exercise: ToExerciseResp(c.Exercise),
+			Matched:  c.Matched,
+			Score:    c.Score,
+		})
+	}
+
+	return newPage(resp)
+}
+
+func FromAddAliasRequest(userID, exerciseID int64, r *dto.AddAliasRequest) *model.ExerciseAlias {
+	return &model.ExerciseAlias{
+		ExerciseID: exerciseID,
+		Alias:      strings.TrimSpace(r.Alias),
+		CreatedBy:  sql.NullInt64{Int64: userID, Valid: true},
+	}
+}
+
+func ToExerciseAliasResp(alias *model.ExerciseAlias) *dto.ExerciseAlias {
+	return &dto.ExerciseAlias{
+		ID:         alias.ID,
+		ExerciseID: alias.ExerciseID,
+		Alias:      alias.Alias,
+		BuiltIn:    !alias.CreatedBy.Valid,
+		CreatedAt:  alias.CreatedAt,
+	}
+}
+
+func ToExerciseAliasesResp(aliases []*model.ExerciseAlias) *dto.Page[*dto.ExerciseAlias] {
+	resp := make([]*dto.ExerciseAlias, 0, len(aliases))
+	for _, a := range aliases {
+		resp = append(resp, ToExerciseAliasResp(a))
+	}
+
+	return newPage(resp)
+}
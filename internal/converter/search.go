@@ -0,0 +1,57 @@
+package converter
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+// maxSearchQueryLength bounds the query text in characters.
+const maxSearchQueryLength = 200
+
+func FromSearchQuery(userID int64, q *dto.SearchQuery) (*model.SearchQuery, error) {
+	text := strings.TrimSpace(q.Query)
+	if len(text) == 0 {
+		return nil, errors.New("q is required")
+	}
+	if utf8.RuneCountInString(text) > maxSearchQueryLength {
+		return nil, errors.New("q is too long")
+	}
+
+	limit, err := parseLimit(q.Limit, 20, 50)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SearchQuery{
+		UserID: userID,
+		Text:   text,
+		Limit:  limit,
+	}, nil
+}
+
+func ToSearchResultsResp(results *model.SearchResults) *dto.Page[*dto.SearchResult] {
+	resp := &dto.Page[*dto.SearchResult]{
+		Items: make([]*dto.SearchResult, 0, len(results.Results)),
+		Total: results.Total,
+	}
+	for _, r := range results.Results {
+		res := &dto.SearchResult{
+			Kind:    r.Kind,
+			ID:      r.ID,
+			Title:   r.Title,
+			Snippet: r.Snippet,
+			Rank:    r.Rank,
+			Date:    fromNullTime(r.Date),
+		}
+		if r.WorkoutID.Valid {
+			res.WorkoutID = &r.WorkoutID.Int64
+		}
+		resp.Items = append(resp.Items, res)
+	}
+
+	return resp
+}
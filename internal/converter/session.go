@@ -0,0 +1,54 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func FromLogSetRequest(userID, workoutID int64, req *dto.LogSetRequest) *model.LogSetParams {
+	set := FromAddExerciseToWorkout(&req.WorkoutExercise)
+	set.WorkoutID = workoutID
+
+	return &model.LogSetParams{
+		UserID:      userID,
+		Set:         set,
+		RestSeconds: req.RestTimer,
+	}
+}
+
+func FromFinishSessionRequest(userID, workoutID int64, req *dto.FinishSessionRequest) *model.FinishSessionParams {
+	return &model.FinishSessionParams{
+		UserID:     userID,
+		WorkoutID:  workoutID,
+		SessionRPE: req.SessionRPE,
+	}
+}
+
+func ToLoggedSetResp(set *model.WorkoutExercise) *dto.WorkoutExercise {
+	return toWorkoutExerciseResp(set)
+}
+
+func ToSessionEventResp(e *model.SessionEvent) *dto.SessionEvent {
+	resp := &dto.SessionEvent{
+		Type:      e.Type,
+		WorkoutID: e.WorkoutID,
+		At:        e.At,
+	}
+	if e.Workout != nil {
+		resp.Workout = ToWorkoutResp(e.Workout)
+	}
+	if e.Set != nil {
+		resp.Set = toWorkoutExerciseResp(e.Set)
+	}
+	if e.Rest != nil {
+		resp.RestTimer = &dto.RestTimer{
+			StartedAt: e.Rest.StartedAt,
+			EndsAt:    e.Rest.StartedAt.Add(time.Duration(e.Rest.Seconds) * time.Second),
+			Seconds:   e.Rest.Seconds,
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,170 @@
+package converter
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func FromSyncRequest(userID int64, req *dto.SyncRequest) (*model.SyncRequest, error) {
+	var cursor int64
+	if req.Cursor != "" {
+		var err error
+		cursor, err = strconv.ParseInt(req.Cursor, 10, 64)
+		if err != nil || cursor < 0 {
+			return nil, errors.New("invalid cursor")
+		}
+	}
+
+	changes := make([]*model.SyncChange, 0, len(req.Changes))
+	for _, c := range req.Changes {
+		change := &model.SyncChange{
+			Entity:          c.Entity,
+			Op:              c.Op,
+			UUID:            c.UUID,
+			ClientUpdatedAt: c.ClientUpdatedAt.UTC(),
+		}
+		if c.Workout != nil {
+			change.Workout = fromSyncWorkoutData(c.Workout)
+		}
+		if c.Entry != nil {
+			change.Entry = fromSyncEntryData(c.Entry)
+		}
+		changes = append(changes, change)
+	}
+
+	return &model.SyncRequest{
+		UserID:  userID,
+		Cursor:  cursor,
+		Limit:   uint64(req.Limit),
+		Changes: changes,
+	}, nil
+}
+
+func fromSyncWorkoutData(d *dto.SyncWorkoutData) *model.SyncWorkout {
+	return &model.SyncWorkout{
+		Workout: &model.Workout{
+			Date:       d.Date.UTC(),
+			Name:       d.Name,
+			Notes:      d.Notes,
+			StartedAt:  toNullTime(d.StartedAt),
+			EndedAt:    toNullTime(d.EndedAt),
+			SessionRPE: toNullFloat64(d.SessionRPE),
+		},
+	}
+}
+
+func fromSyncEntryData(d *dto.SyncEntryData) *model.SyncEntry {
+	return &model.SyncEntry{
+		WorkoutUUID: d.WorkoutUUID,
+		Entry: &model.WorkoutExercise{
+			ExerciseID:    d.ExerciseID,
+			Sets:          d.Sets,
+			Reps:          d.Reps,
+			Weight:        d.Weight,
+			Duration:      d.Duration,
+			Distance:      d.Distance,
+			RPE:           toNullFloat64(d.RPE),
+			RIR:           toNullInt32(d.RIR),
+			TargetReps:    toNullInt32(d.TargetReps),
+			IsWarmUp:      d.IsWarmUp,
+			Position:      d.Position,
+			GroupID:       toNullInt32(d.GroupID),
+			CompletedAt:   toNullTime(d.CompletedAt),
+			RestSeconds:   toNullInt32(d.RestSeconds),
+			AvgHeartRate:  toNullInt32(d.AvgHeartRate),
+			MaxHeartRate:  toNullInt32(d.MaxHeartRate),
+			ElevationGain: toNullFloat64(d.ElevationGain),
+		},
+	}
+}
+
+func toSyncWorkoutResp(sw *model.SyncWorkout) *dto.SyncWorkout {
+	w := sw.Workout
+	return &dto.SyncWorkout{
+		ID:              w.ID,
+		UUID:            w.UUID,
+		ClientUpdatedAt: sw.ClientUpdatedAt,
+		SyncWorkoutData: dto.SyncWorkoutData{
+			Date:       w.Date,
+			Name:       w.Name,
+			Notes:      w.Notes,
+			StartedAt:  fromNullTime(w.StartedAt),
+			EndedAt:    fromNullTime(w.EndedAt),
+			SessionRPE: fromNullFloat64(w.SessionRPE),
+		},
+	}
+}
+
+func toSyncEntryResp(se *model.SyncEntry) *dto.SyncEntry {
+	e := se.Entry
+	return &dto.SyncEntry{
+		ID:              e.ID,
+		UUID:            e.UUID,
+		ClientUpdatedAt: se.ClientUpdatedAt,
+		SyncEntryData: dto.SyncEntryData{
+			WorkoutUUID:   se.WorkoutUUID,
+			ExerciseID:    e.ExerciseID,
+			Sets:          e.Sets,
+			Reps:          e.Reps,
+			Weight:        e.Weight,
+			Duration:      e.Duration,
+			Distance:      e.Distance,
+			RPE:           fromNullFloat64(e.RPE),
+			RIR:           fromNullInt32(e.RIR),
+			TargetReps:    fromNullInt32(e.TargetReps),
+			IsWarmUp:      e.IsWarmUp,
+			Position:      e.Position,
+			GroupID:       fromNullInt32(e.GroupID),
+			CompletedAt:   fromNullTime(e.CompletedAt),
+			RestSeconds:   fromNullInt32(e.RestSeconds),
+			AvgHeartRate:  fromNullInt32(e.AvgHeartRate),
+			MaxHeartRate:  fromNullInt32(e.MaxHeartRate),
+			ElevationGain: fromNullFloat64(e.ElevationGain),
+		},
+	}
+}
+
+func ToSyncResp(resp *model.SyncResponse) *dto.SyncResponse {
+	out := &dto.SyncResponse{
+		Results:  make([]*dto.SyncResult, 0, len(resp.Results)),
+		Workouts: make([]*dto.SyncWorkout, 0, len(resp.Workouts)),
+		Entries:  make([]*dto.SyncEntry, 0, len(resp.Entries)),
+		Deleted:  make([]*dto.SyncTombstone, 0, len(resp.Deleted)),
+		Cursor:   strconv.FormatInt(resp.Cursor, 10),
+		HasMore:  resp.HasMore,
+	}
+
+	for _, r := range resp.Results {
+		res := &dto.SyncResult{
+			Entity: r.Entity,
+			UUID:   r.UUID,
+			Status: r.Status,
+			Error:  r.Error,
+		}
+		if r.Workout != nil {
+			res.Workout = toSyncWorkoutResp(r.Workout)
+		}
+		if r.Entry != nil {
+			res.Entry = toSyncEntryResp(r.Entry)
+		}
+		out.Results = append(out.Results, res)
+	}
+	for _, w := range resp.Workouts {
+		out.Workouts = append(out.Workouts, toSyncWorkoutResp(w))
+	}
+	for _, e := range resp.Entries {
+		out.Entries = append(out.Entries, toSyncEntryResp(e))
+	}
+	for _, t := range resp.Deleted {
+		out.Deleted = append(out.Deleted, &dto.SyncTombstone{
+			Entity:    t.Entity,
+			UUID:      t.UUID,
+			DeletedAt: t.DeletedAt,
+		})
+	}
+
+	return out
+}
@@ -0,0 +1,121 @@
+package converter
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+// maxFilterTags bounds the tags a single filter may list.
+const maxFilterTags = 20
+
+func FromTagQuery(q *dto.TagQuery) (model.TagFilter, error) {
+	var (
+		filter model.TagFilter
+		err    error
+	)
+
+	filter.Include, err = parseTagIDs(q.Tag)
+	if err != nil {
+		return model.TagFilter{}, errors.New("invalid tag")
+	}
+
+	filter.Exclude, err = parseTagIDs(q.ExcludeTag)
+	if err != nil {
+		return model.TagFilter{}, errors.New("invalid exclude_tag")
+	}
+
+	return filter, nil
+}
+
+func parseTagIDs(s string) ([]int64, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, ",")
+	if len(parts) > maxFilterTags {
+		return nil, errors.New("too many tags")
+	}
+
+	ids := make([]int64, 0, len(parts))
+	for _, p := range parts {
+		id, err := strconv.ParseInt(strings.TrimSpace(p), 10, 64)
+		if err != nil || id < 1 {
+			return nil, errors.New("invalid tag id")
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func FromCreateTagRequest(userID int64, r *dto.CreateTagRequest) *model.Tag {
+	return &model.Tag{
+		UserID: userID,
+		Name:   strings.TrimSpace(r.Name),
+		Color:  sql.NullString{String: r.Color, Valid: len(r.Color) != 0},
+	}
+}
+
+func FromUpdateTagRequest(userID, tagID int64, r *dto.UpdateTagRequest) *model.UpdateTagParams {
+	params := &model.UpdateTagParams{
+		ID:     tagID,
+		UserID: userID,
+		Color:  r.Color,
+	}
+	if r.Name != nil {
+		name := strings.TrimSpace(*r.Name)
+		params.Name = &name
+	}
+	return params
+}
+
+func FromSetWorkoutTagsRequest(userID, workoutID int64, r *dto.SetWorkoutTagsRequest) *model.SetWorkoutTagsParams {
+	return &model.SetWorkoutTagsParams{
+		UserID:    userID,
+		WorkoutID: workoutID,
+		TagIDs:    r.TagIDs,
+	}
+}
+
+func ToTagResp(t *model.Tag) *dto.Tag {
+	resp := &dto.Tag{
+		ID:        t.ID,
+		Name:      t.Name,
+		CreatedAt: t.CreatedAt,
+	}
+	if t.Color.Valid {
+		resp.Color = &t.Color.String
+	}
+	return resp
+}
+
+func ToTagsResp(tags []*model.Tag) *dto.Page[*dto.Tag] {
+	resp := make([]*dto.Tag, 0, len(tags))
+	for _, t := range tags {
+		resp = append(resp, ToTagResp(t))
+	}
+
+	return newPage(resp)
+}
+
+func ToTagUsageResp(usage []*model.TagUsage) *dto.Page[*dto.TagUsage] {
+	resp := make([]*dto.TagUsage, 0, len(usage))
+	for _, u := range usage {
+		resp = append(resp, &dto.TagUsage{
+			Tag:       ToTagResp(u.Tag),
+			Workouts:  u.Workouts,
+			Sets:      u.Sets,
+			Volume:    u.Volume,
+			FirstUsed: fromNullTime(u.FirstUsed),
+			LastUsed:  fromNullTime(u.LastUsed),
+		})
+	}
+
+	return newPage(resp)
+}
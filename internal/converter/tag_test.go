@@ -0,0 +1,43 @@
+package converter
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+)
+
+func TestFromTagQuery(t *testing.T) {
+	tooMany := strings.TrimSuffix(strings.Repeat("1,", maxFilterTags+1), ",")
+
+	tests := []struct {
+		name    string
+		query   dto.TagQuery
+		include []int64
+		exclude []int64
+		valid   bool
+	}{
+		{"no filter", dto.TagQuery{}, nil, nil, true},
+		{"include", dto.TagQuery{Tag: "3"}, []int64{3}, nil, true},
+		{"include and exclude", dto.TagQuery{Tag: "1, 2", ExcludeTag: "5"}, []int64{1, 2}, []int64{5}, true},
+		{"most tags", dto.TagQuery{ExcludeTag: tooMany[2:]}, nil, slices.Repeat([]int64{1}, maxFilterTags), true},
+		{"too many tags", dto.TagQuery{Tag: tooMany}, nil, nil, false},
+		{"not a number", dto.TagQuery{Tag: "legs"}, nil, nil, false},
+		{"zero id", dto.TagQuery{Tag: "0"}, nil, nil, false},
+		{"empty item", dto.TagQuery{Tag: "1,,2"}, nil, nil, false},
+		{"invalid exclude", dto.TagQuery{Tag: "1", ExcludeTag: "-2"}, nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := FromTagQuery(&tt.query)
+			if (err == nil) != tt.valid {
+				t.Fatalf("FromTagQuery() error = %v, want valid %v", err, tt.valid)
+			}
+			if !slices.Equal(filter.Include, tt.include) || !slices.Equal(filter.Exclude, tt.exclude) {
+				t.Errorf("filter = %+v, want include %v and exclude %v", filter, tt.include, tt.exclude)
+			}
+		})
+	}
+}
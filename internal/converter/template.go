@@ -0,0 +1,135 @@
+package converter
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func FromTemplateRequest(userID int64, r *dto.WorkoutTemplate) *model.WorkoutTemplate {
+	template := &model.WorkoutTemplate{
+		UserID: userID,
+		Name:   r.Name,
+		Notes:  r.Notes,
+	}
+	for _, e := range r.Exercises {
+		template.Exercises = append(template.Exercises, &model.TemplateExercise{
+			ExerciseID: e.ExerciseID,
+			Sets:       e.Sets,
+			Reps:       e.Reps,
+			Weight:     toNullFloat64(e.Weight),
+			Notes:      e.Notes,
+		})
+	}
+
+	return template
+}
+
+func ToTemplateExerciseResp(e *model.TemplateExercise) *dto.TemplateExercise {
+	return &dto.TemplateExercise{
+		ID:         e.ID,
+		ExerciseID: e.ExerciseID,
+		Exercise: &dto.Exercise{
+			ID:          e.Exercise.ID,
+			Name:        e.Exercise.Name,
+			Type:        e.Exercise.Type,
+			MuscleGroup: e.Exercise.MuscleGroup,
+			Description: e.Exercise.Description,
+		},
+		Sets:   e.Sets,
+		Reps:   e.Reps,
+		Weight: fromNullFloat64(e.Weight),
+		Notes:  e.Notes,
+	}
+}
+
+func ToTemplateResp(t *model.WorkoutTemplate) *dto.WorkoutTemplate {
+	resp := &dto.WorkoutTemplate{
+		ID:        t.ID,
+		Name:      t.Name,
+		Notes:     t.Notes,
+		CreatedAt: t.CreatedAt,
+	}
+	for _, e := range t.Exercises {
+		resp.Exercises = append(resp.Exercises, ToTemplateExerciseResp(e))
+	}
+
+	return resp
+}
+
+func ToTemplatesResp(templates []*model.WorkoutTemplate) *dto.Page[*dto.WorkoutTemplate] {
+	resp := make([]*dto.WorkoutTemplate, 0, len(templates))
+	for _, t := range templates {
+		resp = append(resp, ToTemplateResp(t))
+	}
+
+	return newPage(resp)
+}
+
+func FromInstantiateTemplateRequest(userID, templateID int64, r *dto.InstantiateTemplateRequest) *model.InstantiateTemplateParams {
+	params := &model.InstantiateTemplateParams{
+		UserID:     userID,
+		TemplateID: templateID,
+		Name:       r.Name,
+	}
+	if r.Date != nil {
+		params.Date = r.Date.UTC()
+	}
+
+	return params
+}
+
+// FromTargetReps parses the optional reps query parameter.
+func FromTargetReps(s string) (int, error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+
+	reps, err := strconv.Atoi(s)
+	if err != nil || reps < 1 || reps > 100 {
+		return 0, errors.New("reps must be between 1 and 100")
+	}
+
+	return reps, nil
+}
+
+func ToSuggestionResp(s *model.ProgressionSuggestion) *dto.ProgressionSuggestion {
+	resp := &dto.ProgressionSuggestion{
+		ExerciseID:        s.ExerciseID,
+		Action:            s.Action,
+		Sets:              s.Sets,
+		Reps:              s.Reps,
+		Weight:            s.Weight,
+		Increment:         s.Increment,
+		ConsecutiveMisses: s.ConsecutiveMisses,
+	}
+	if s.Last != nil {
+		resp.Last = &dto.SessionSummary{
+			WorkoutID:  s.Last.WorkoutID,
+			Date:       s.Last.Date.UTC(),
+			Sets:       s.Last.Sets,
+			Reps:       s.Last.Reps,
+			Weight:     s.Last.Weight,
+			TargetReps: s.Last.TargetReps,
+		}
+	}
+
+	return resp
+}
+
+func ToTemplateWorkoutResp(w *model.TemplateWorkout) *dto.TemplateWorkout {
+	resp := &dto.TemplateWorkout{
+		WorkoutID: w.WorkoutID,
+		Exercises: make([]*dto.PlannedExercise, 0, len(w.Exercises)),
+	}
+	for _, e := range w.Exercises {
+		resp.Exercises = append(resp.Exercises, &dto.PlannedExercise{
+			TemplateExercise: *ToTemplateExerciseResp(e.TemplateExercise),
+			Suggestion:       ToSuggestionResp(e.Suggestion),
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,47 @@
+package converter
+
+import (
+	"github.com/biryanim/workoutbook/internal/api/dto"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func ToProfileResp(u *model.User) *dto.ProfileResponse {
+	return &dto.ProfileResponse{
+		ID:       u.ID,
+		Username: u.Name,
+		Email:    u.Email,
+		Timezone: u.Timezone,
+		Sex:      u.Sex.String,
+		Locale:   u.Locale.String,
+	}
+}
+
+func FromUpdateProfileRequest(userID int64, r *dto.UpdateProfileRequest) *model.UpdateProfileParams {
+	return &model.UpdateProfileParams{
+		UserID:   userID,
+		Timezone: r.Timezone,
+		Sex:      r.Sex,
+		Locale:   r.Locale,
+	}
+}
+
+func FromBodyWeightRequest(userID int64, r *dto.BodyWeight) *model.BodyWeight {
+	return &model.BodyWeight{
+		UserID:     userID,
+		Weight:     r.Weight,
+		MeasuredAt: r.MeasuredAt.UTC(),
+	}
+}
+
+func ToBodyWeightsResp(weights []*model.BodyWeight) *dto.Page[*dto.BodyWeight] {
+	resp := make([]*dto.BodyWeight, 0, len(weights))
+	for _, w := range weights {
+		resp = append(resp, &dto.BodyWeight{
+			ID:         w.ID,
+			Weight:     w.Weight,
+			MeasuredAt: w.MeasuredAt,
+		})
+	}
+
+	return newPage(resp)
+}
@@ -14,6 +14,51 @@ var (
 	ErrSessionNotFound    = errors.New("session not found")
 	ErrInvalidInput       = errors.New("invalid input")
 	ErrInternal           = errors.New("internal error")
+	ErrInvalidTimezone    = errors.New("invalid timezone")
+	ErrInvalidLoadSource  = errors.New("invalid load source")
+	ErrWorkoutNotFound    = errors.New("workout not found")
+	ErrInvalidWorkoutTime = errors.New("workout must not end before it starts")
+	ErrWorkoutNotStarted  = errors.New("workout not started")
+	ErrWorkoutFinished    = errors.New("workout already finished")
+	ErrEntryNotFound      = errors.New("workout entry not found")
+	ErrInvalidSync        = errors.New("invalid sync change")
+	ErrIdempotencyMissing = errors.New("idempotency key not found")
+	ErrIdempotencyReused  = errors.New("idempotency key reused with a different request")
+	ErrRequestInProgress  = errors.New("request with this idempotency key is in progress")
+	ErrRecordNotFound     = errors.New("record not found")
+	ErrBodyWeightNotFound = errors.New("body weight not found")
+	ErrInvalidStandards   = errors.New("invalid strength standards")
+	ErrExerciseNotMapped  = errors.New("exercise not mapped")
+	ErrInvalidActivity    = errors.New("invalid activity file")
+	ErrMappingNotFound    = errors.New("exercise mapping not found")
+	ErrMappingForbidden   = errors.New("exercise mapping change not allowed")
+	ErrExerciseNotFound   = errors.New("exercise not found")
+	ErrTemplateNotFound   = errors.New("template not found")
+	ErrInvalidOrder       = errors.New("invalid exercise order")
+	ErrPlatesNotFound     = errors.New("plate inventory not found")
+	ErrInvalidPlates      = errors.New("invalid plate inventory")
+	ErrProgramNotFound    = errors.New("program not found")
+	ErrInvalidProgram     = errors.New("invalid program")
+	ErrUnknownGenerator   = errors.New("unknown program generator")
+	ErrMissingLiftRecord  = errors.New("missing lift record")
+	ErrEnrollmentNotFound = errors.New("enrollment not found")
+	ErrNoSessionScheduled = errors.New("no session scheduled")
+	ErrSessionLinked      = errors.New("session already linked")
+	ErrTagNotFound        = errors.New("tag not found")
+	ErrTagAlreadyExists   = errors.New("tag already exists")
+	ErrMediaNotFound      = errors.New("media not found")
+	ErrInvalidMedia       = errors.New("unsupported media type")
+	ErrMediaForbidden     = errors.New("media upload not allowed")
+	ErrInvalidMetrics     = errors.New("invalid set metrics")
+	ErrAliasNotFound      = errors.New("alias not found")
+	ErrAliasAlreadyExists = errors.New("alias already exists")
+	ErrInvalidAlias       = errors.New("invalid alias")
+	ErrInvalidEquipment   = errors.New("invalid equipment")
+	ErrPlanEntryNotFound  = errors.New("planned exercise not found")
+	ErrInvalidSubstitute  = errors.New("invalid substitute")
+	ErrCoachingNotFound   = errors.New("coaching not found")
+	ErrCoachingExists     = errors.New("coaching already exists")
+	ErrInvalidCoaching    = errors.New("invalid coaching")
 
 	ErrUserAndTaskAlreadyExists = errors.New("user and task already exists")
 	ErrUserAlreadyHasReferrer   = errors.New("user already has referrer")
@@ -78,6 +123,94 @@ func FromError(err error) *AppError {
 		return New(http.StatusUnauthorized, "Session not found")
 	case errors.Is(err, ErrInvalidInput):
 		return New(http.StatusBadRequest, "Invalid input")
+	case errors.Is(err, ErrInvalidTimezone):
+		return New(http.StatusBadRequest, "Invalid timezone")
+	case errors.Is(err, ErrInvalidLoadSource):
+		return New(http.StatusBadRequest, "Invalid load source")
+	case errors.Is(err, ErrWorkoutNotFound):
+		return New(http.StatusNotFound, "Workout not found")
+	case errors.Is(err, ErrInvalidWorkoutTime):
+		return New(http.StatusBadRequest, "Workout must not end before it starts")
+	case errors.Is(err, ErrWorkoutNotStarted):
+		return New(http.StatusConflict, "Workout not started")
+	case errors.Is(err, ErrWorkoutFinished):
+		return New(http.StatusConflict, "Workout already finished")
+	case errors.Is(err, ErrEntryNotFound):
+		return New(http.StatusNotFound, "Workout entry not found")
+	case errors.Is(err, ErrInvalidSync):
+		return New(http.StatusBadRequest, "Invalid sync change")
+	case errors.Is(err, ErrIdempotencyMissing):
+		return New(http.StatusNotFound, "Idempotency key not found")
+	case errors.Is(err, ErrIdempotencyReused):
+		return New(http.StatusUnprocessableEntity, "Idempotency key reused with a different request")
+	case errors.Is(err, ErrRequestInProgress):
+		return New(http.StatusConflict, "Request with this idempotency key is in progress")
+	case errors.Is(err, ErrRecordNotFound):
+		return New(http.StatusNotFound, "Record not found")
+	case errors.Is(err, ErrBodyWeightNotFound):
+		return New(http.StatusNotFound, "Body weight not found")
+	case errors.Is(err, ErrInvalidStandards):
+		return New(http.StatusBadRequest, "Strength standards must be distinct levels of a known exercise with growing bodyweight multiples")
+	case errors.Is(err, ErrExerciseNotMapped):
+		return New(http.StatusBadRequest, "Unable to match an exercise, pass exercise_id")
+	case errors.Is(err, ErrMappingNotFound):
+		return New(http.StatusNotFound, "Exercise mapping not found")
+	case errors.Is(err, ErrMappingForbidden):
+		return New(http.StatusForbidden, "Only administrators can change exercise mappings")
+	case errors.Is(err, ErrInvalidActivity):
+		return New(http.StatusBadRequest, "Invalid activity file")
+	case errors.Is(err, ErrExerciseNotFound):
+		return New(http.StatusNotFound, "Exercise not found")
+	case errors.Is(err, ErrInvalidOrder):
+		return New(http.StatusBadRequest, "Order must list every exercise of the workout once")
+	case errors.Is(err, ErrTemplateNotFound):
+		return New(http.StatusNotFound, "Template not found")
+	case errors.Is(err, ErrInvalidPlates):
+		return New(http.StatusBadRequest, "Plate weights must be unique")
+	case errors.Is(err, ErrProgramNotFound):
+		return New(http.StatusNotFound, "Program not found")
+	case errors.Is(err, ErrInvalidProgram):
+		return New(http.StatusBadRequest, "Program days must fit within its weeks and use a known prescription type")
+	case errors.Is(err, ErrUnknownGenerator):
+		return New(http.StatusBadRequest, "Unknown program generator")
+	case errors.Is(err, ErrMissingLiftRecord):
+		return New(http.StatusBadRequest, "Log personal records for squat, bench press, deadlift and overhead press first")
+	case errors.Is(err, ErrEnrollmentNotFound):
+		return New(http.StatusNotFound, "Enrollment not found")
+	case errors.Is(err, ErrNoSessionScheduled):
+		return New(http.StatusNotFound, "No session scheduled for this date")
+	case errors.Is(err, ErrSessionLinked):
+		return New(http.StatusConflict, "Session or workout already linked")
+	case errors.Is(err, ErrTagNotFound):
+		return New(http.StatusNotFound, "Tag not found")
+	case errors.Is(err, ErrTagAlreadyExists):
+		return New(http.StatusConflict, "Tag with this name already exists")
+	case errors.Is(err, ErrMediaNotFound):
+		return New(http.StatusNotFound, "Media not found")
+	case errors.Is(err, ErrInvalidMedia):
+		return New(http.StatusBadRequest, "Only JPEG, PNG, WebP and GIF images and MP4 and WebM videos are supported")
+	case errors.Is(err, ErrMediaForbidden):
+		return New(http.StatusForbidden, "Only administrators can upload exercise media")
+	case errors.Is(err, ErrInvalidMetrics):
+		return New(http.StatusBadRequest, "Set metrics do not match the tracking profile of the exercise")
+	case errors.Is(err, ErrAliasNotFound):
+		return New(http.StatusNotFound, "Alias not found")
+	case errors.Is(err, ErrAliasAlreadyExists):
+		return New(http.StatusConflict, "The exercise already has this alias")
+	case errors.Is(err, ErrInvalidAlias):
+		return New(http.StatusBadRequest, "Alias must contain letters or digits")
+	case errors.Is(err, ErrInvalidEquipment):
+		return New(http.StatusBadRequest, "Unknown equipment")
+	case errors.Is(err, ErrPlanEntryNotFound):
+		return New(http.StatusNotFound, "Planned exercise not found")
+	case errors.Is(err, ErrInvalidSubstitute):
+		return New(http.StatusBadRequest, "Substitute must be another exercise of the same type and tracking profile")
+	case errors.Is(err, ErrCoachingNotFound):
+		return New(http.StatusNotFound, "Coach or athlete not found")
+	case errors.Is(err, ErrCoachingExists):
+		return New(http.StatusConflict, "Already coaching this athlete")
+	case errors.Is(err, ErrInvalidCoaching):
+		return New(http.StatusBadRequest, "Users cannot coach themselves")
 	case errors.Is(err, ErrUserAndTaskAlreadyExists):
 		return New(http.StatusConflict, "User and task already exists")
 	case errors.Is(err, ErrUserAlreadyHasReferrer):
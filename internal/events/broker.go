@@ -0,0 +1,65 @@
+// Package events fans out live workout session events to the clients
+// following them. Subscriptions live in process memory, so every client of a
+// session has to reach the same instance.
+package events
+
+import (
+	"sync"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+// bufferSize events are kept for a slow subscriber before newer ones are
+// dropped for it.
+const bufferSize = 16
+
+type Broker struct {
+	mu   sync.Mutex
+	subs map[int64]map[chan *model.SessionEvent]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[int64]map[chan *model.SessionEvent]struct{})}
+}
+
+// Subscribe follows the events of a workout until the returned cancel func is
+// called.
+func (b *Broker) Subscribe(workoutID int64) (<-chan *model.SessionEvent, func()) {
+	ch := make(chan *model.SessionEvent, bufferSize)
+
+	b.mu.Lock()
+	if b.subs[workoutID] == nil {
+		b.subs[workoutID] = make(map[chan *model.SessionEvent]struct{})
+	}
+	b.subs[workoutID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subs[workoutID], ch)
+			if len(b.subs[workoutID]) == 0 {
+				delete(b.subs, workoutID)
+			}
+			close(ch)
+		})
+	}
+
+	return ch, cancel
+}
+
+// Publish never blocks: subscribers whose buffer is full miss the event.
+func (b *Broker) Publish(e *model.SessionEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subs[e.WorkoutID] {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
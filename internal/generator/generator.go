@@ -0,0 +1,116 @@
+// Package generator builds week-by-week training programs from the lifter's
+// current personal records.
+package generator
+
+import (
+	"math"
+	"sort"
+
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrUnknownGenerator = errors.New("unknown program generator")
+	ErrMissingLift      = errors.New("missing personal record for a required lift")
+)
+
+// Generator is a programming scheme. Generate must not keep state between
+// calls; the same input always yields the same program.
+type Generator interface {
+	Info() model.GeneratorInfo
+	Generate(in *model.GeneratorInput) (*model.GeneratedProgram, error)
+}
+
+var generators = map[string]Generator{}
+
+func init() {
+	Register(wendler{})
+	Register(linear{})
+	Register(gzclp{})
+}
+
+// Register makes a generator available under its name, replacing any
+// generator registered before with the same name.
+func Register(g Generator) {
+	generators[g.Info().Name] = g
+}
+
+func Get(name string) (Generator, error) {
+	g, ok := generators[name]
+	if !ok {
+		return nil, ErrUnknownGenerator
+	}
+	return g, nil
+}
+
+func List() []model.GeneratorInfo {
+	infos := make([]model.GeneratorInfo, 0, len(generators))
+	for _, g := range generators {
+		infos = append(infos, g.Info())
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+
+	return infos
+}
+
+// loadIncrement is the smallest jump loadable with standard plates.
+const loadIncrement = 2.5
+
+var liftNames = map[string]string{
+	model.LiftSquat:    "Squat",
+	model.LiftBench:    "Bench press",
+	model.LiftDeadlift: "Deadlift",
+	model.LiftOHP:      "Overhead press",
+}
+
+func roundLoad(weight float64) float64 {
+	return math.Round(weight/loadIncrement) * loadIncrement
+}
+
+// repMax inverts the Epley formula to estimate the load for a given number
+// of reps from a one-rep max.
+func repMax(oneRM float64, reps int) float64 {
+	if reps <= 1 {
+		return oneRM
+	}
+	return oneRM / (1 + float64(reps)/30)
+}
+
+// increment is the usual load jump: 2.5 kg for upper body, 5 kg for lower
+// body lifts.
+func increment(lift string) float64 {
+	if lift == model.LiftSquat || lift == model.LiftDeadlift {
+		return 5
+	}
+	return 2.5
+}
+
+func requireLifts(in *model.GeneratorInput, lifts ...string) error {
+	for _, lift := range lifts {
+		if m, ok := in.Lifts[lift]; !ok || m.OneRM <= 0 {
+			return errors.Wrap(ErrMissingLift, lift)
+		}
+	}
+	return nil
+}
+
+func weeks(in *model.GeneratorInput, def int) int {
+	if in.Weeks > 0 {
+		return in.Weeks
+	}
+	return def
+}
+
+// addSets appends sets of a lift at a fixed load to the day.
+func addSets(day *model.ProgramDay, lift *model.LiftMax, sets, reps int, weight float64, amrap bool, notes string) {
+	day.Exercises = append(day.Exercises, &model.ProgramExercise{
+		ExerciseID: lift.ExerciseID,
+		Sets:       sets,
+		Reps:       reps,
+		Type:       model.PrescriptionFixed,
+		Value:      weight,
+		AMRAP:      amrap,
+		Notes:      notes,
+	})
+}
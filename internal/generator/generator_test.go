@@ -0,0 +1,411 @@
+package generator
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+const (
+	squatID int64 = iota + 1
+	benchID
+	deadliftID
+	ohpID
+)
+
+func testLifts() map[string]*model.LiftMax {
+	return map[string]*model.LiftMax{
+		model.LiftSquat:    {ExerciseID: squatID, Lift: model.LiftSquat, OneRM: 200},
+		model.LiftBench:    {ExerciseID: benchID, Lift: model.LiftBench, OneRM: 120},
+		model.LiftDeadlift: {ExerciseID: deadliftID, Lift: model.LiftDeadlift, OneRM: 240},
+		model.LiftOHP:      {ExerciseID: ohpID, Lift: model.LiftOHP, OneRM: 80},
+	}
+}
+
+// prescription is the part of a program exercise the generators decide.
+type prescription struct {
+	exerciseID int64
+	sets, reps int
+	value      float64
+	amrap      bool
+}
+
+func prescriptions(day *model.ProgramDay) []prescription {
+	res := make([]prescription, 0, len(day.Exercises))
+	for _, e := range day.Exercises {
+		res = append(res, prescription{e.ExerciseID, e.Sets, e.Reps, e.Value, e.AMRAP})
+	}
+	return res
+}
+
+func findDay(t *testing.T, p *model.Program, week, day int) *model.ProgramDay {
+	t.Helper()
+	for _, d := range p.Days {
+		if d.Week == week && d.Day == day {
+			return d
+		}
+	}
+	t.Fatalf("week %d day %d not generated", week, day)
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	names := make([]string, 0)
+	for _, info := range List() {
+		names = append(names, info.Name)
+	}
+	if want := []string{"531", "gzclp", "linear"}; !slices.Equal(names, want) {
+		t.Errorf("List() names = %v, want %v", names, want)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"531", nil},
+		{"linear", nil},
+		{"gzclp", nil},
+		{"", ErrUnknownGenerator},
+		{"texas", ErrUnknownGenerator},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Get(tt.name)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Get(%q) error = %v, want %v", tt.name, err, tt.err)
+			}
+			if err == nil && g.Info().Name != tt.name {
+				t.Errorf("Get(%q) returned %q", tt.name, g.Info().Name)
+			}
+		})
+	}
+}
+
+func TestHelpers(t *testing.T) {
+	roundTests := []struct {
+		weight, want float64
+	}{
+		{0, 0},
+		{46.8, 47.5},
+		{61.2, 60},
+		{101.25, 102.5},
+	}
+	for _, tt := range roundTests {
+		if got := roundLoad(tt.weight); got != tt.want {
+			t.Errorf("roundLoad(%v) = %v, want %v", tt.weight, got, tt.want)
+		}
+	}
+
+	repTests := []struct {
+		oneRM float64
+		reps  int
+		want  float64
+	}{
+		{100, 0, 100},
+		{100, 1, 100},
+		{135, 15, 90},
+		{80, 10, 60},
+	}
+	for _, tt := range repTests {
+		if got := repMax(tt.oneRM, tt.reps); got != tt.want {
+			t.Errorf("repMax(%v, %d) = %v, want %v", tt.oneRM, tt.reps, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateMissingLift(t *testing.T) {
+	tests := []struct {
+		name  string
+		lifts func(map[string]*model.LiftMax)
+	}{
+		{"no record", func(l map[string]*model.LiftMax) { delete(l, model.LiftOHP) }},
+		{"zero max", func(l map[string]*model.LiftMax) { l[model.LiftDeadlift].OneRM = 0 }},
+	}
+
+	for _, g := range List() {
+		for _, tt := range tests {
+			t.Run(g.Name+"/"+tt.name, func(t *testing.T) {
+				lifts := testLifts()
+				tt.lifts(lifts)
+
+				gen, _ := Get(g.Name)
+				if _, err := gen.Generate(&model.GeneratorInput{Lifts: lifts}); !errors.Is(err, ErrMissingLift) {
+					t.Errorf("Generate() error = %v, want %v", err, ErrMissingLift)
+				}
+			})
+		}
+	}
+}
+
+func TestGenerateWeeks(t *testing.T) {
+	tests := []struct {
+		generator   string
+		weeks       int
+		wantWeeks   int
+		daysPerWeek int
+	}{
+		{"531", 0, 12, 4},
+		{"531", 5, 5, 4},
+		{"linear", 0, 12, 3},
+		{"linear", 2, 2, 3},
+		{"gzclp", 0, 12, 4},
+		{"gzclp", 1, 1, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.generator, func(t *testing.T) {
+			gen, _ := Get(tt.generator)
+			res, err := gen.Generate(&model.GeneratorInput{Weeks: tt.weeks, Lifts: testLifts()})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if res.Program.Weeks != tt.wantWeeks {
+				t.Errorf("weeks = %d, want %d", res.Program.Weeks, tt.wantWeeks)
+			}
+			if got, want := len(res.Program.Days), tt.wantWeeks*tt.daysPerWeek; got != want {
+				t.Errorf("days = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestWendler(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  map[string]int
+		week, day int
+		want      []prescription
+	}{
+		{
+			name: "first week with amrap top set",
+			week: 1, day: 1,
+			want: []prescription{
+				{ohpID, 1, 5, 47.5, false},
+				{ohpID, 1, 5, 55, false},
+				{ohpID, 1, 5, 62.5, true},
+			},
+		},
+		{
+			name: "deload week has no amrap",
+			week: 4, day: 5,
+			want: []prescription{
+				{squatID, 1, 5, 72.5, false},
+				{squatID, 1, 5, 90, false},
+				{squatID, 1, 5, 107.5, false},
+			},
+		},
+		{
+			name: "second cycle raises the training max",
+			week: 5, day: 5,
+			want: []prescription{
+				{squatID, 1, 5, 120, false},
+				{squatID, 1, 5, 140, false},
+				{squatID, 1, 5, 157.5, true},
+			},
+		},
+		{
+			name:     "failed amrap lowers the training max",
+			failures: map[string]int{model.LiftBench: 1},
+			week:     3, day: 4,
+			want: []prescription{
+				{benchID, 1, 5, 72.5, false},
+				{benchID, 1, 3, 82.5, false},
+				{benchID, 1, 1, 92.5, true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := wendler{}.Generate(&model.GeneratorInput{Lifts: testLifts(), Failures: tt.failures})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if got := prescriptions(findDay(t, res.Program, tt.week, tt.day)); !slices.Equal(got, tt.want) {
+				t.Errorf("week %d day %d = %v, want %v", tt.week, tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWendlerTrainingMaxes(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures map[string]int
+		want     map[int64]float64
+	}{
+		{
+			name: "ninety percent of the max",
+			want: map[int64]float64{ohpID: 72.5, deadliftID: 215, benchID: 107.5, squatID: 180},
+		},
+		{
+			name:     "failure takes another ten percent",
+			failures: map[string]int{model.LiftBench: 2},
+			want:     map[int64]float64{ohpID: 72.5, deadliftID: 215, benchID: 97.5, squatID: 180},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := wendler{}.Generate(&model.GeneratorInput{Lifts: testLifts(), Failures: tt.failures})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			got := make(map[int64]float64, len(res.TrainingMaxes))
+			for _, tm := range res.TrainingMaxes {
+				got[tm.ExerciseID] = tm.TrainingMax
+			}
+			for id, want := range tt.want {
+				if got[id] != want {
+					t.Errorf("training max of %d = %v, want %v", id, got[id], want)
+				}
+			}
+		})
+	}
+}
+
+func TestLinear(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  map[string]int
+		week, day int
+		dayName   string
+		want      []prescription
+	}{
+		{
+			name: "workout A",
+			week: 1, day: 1, dayName: "A",
+			want: []prescription{
+				{squatID, 3, 5, 155, false},
+				{benchID, 3, 5, 92.5, false},
+				{deadliftID, 1, 5, 185, false},
+			},
+		},
+		{
+			name: "workout B adds load",
+			week: 1, day: 3, dayName: "B",
+			want: []prescription{
+				{squatID, 3, 5, 157.5, false},
+				{ohpID, 3, 5, 62.5, false},
+				{deadliftID, 1, 5, 190, false},
+			},
+		},
+		{
+			name: "workouts keep alternating",
+			week: 2, day: 1, dayName: "B",
+			want: []prescription{
+				{squatID, 3, 5, 162.5, false},
+				{ohpID, 3, 5, 65, false},
+				{deadliftID, 1, 5, 200, false},
+			},
+		},
+		{
+			name:     "three failures deload",
+			failures: map[string]int{model.LiftSquat: 3, model.LiftBench: 2},
+			week:     1, day: 1, dayName: "A",
+			want: []prescription{
+				{squatID, 3, 5, 140, false},
+				{benchID, 3, 5, 92.5, false},
+				{deadliftID, 1, 5, 185, false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := linear{}.Generate(&model.GeneratorInput{Lifts: testLifts(), Failures: tt.failures})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			day := findDay(t, res.Program, tt.week, tt.day)
+			if day.Name != tt.dayName {
+				t.Errorf("day name = %q, want %q", day.Name, tt.dayName)
+			}
+			if got := prescriptions(day); !slices.Equal(got, tt.want) {
+				t.Errorf("week %d day %d = %v, want %v", tt.week, tt.day, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGZCLP(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  map[string]int
+		week, day int
+		want      []prescription
+		t1Notes   string
+	}{
+		{
+			name: "first stage",
+			week: 1, day: 1,
+			want: []prescription{
+				{squatID, 4, 3, 145, false},
+				{squatID, 1, 3, 145, true},
+				{benchID, 3, 10, 67.5, false},
+			},
+			t1Notes: "T1 stage 1: on failure keep the load and move to 6x2",
+		},
+		{
+			name:     "one failure moves to the second stage",
+			failures: map[string]int{model.LiftSquat: 1, model.LiftBench: 1},
+			week:     1, day: 1,
+			want: []prescription{
+				{squatID, 5, 2, 145, false},
+				{squatID, 1, 2, 145, true},
+				{benchID, 3, 8, 67.5, false},
+			},
+			t1Notes: "T1 stage 2: on failure keep the load and move to 10x1",
+		},
+		{
+			name:     "last stage restarts on failure",
+			failures: map[string]int{model.LiftSquat: 2},
+			week:     1, day: 1,
+			want: []prescription{
+				{squatID, 9, 1, 145, false},
+				{squatID, 1, 1, 145, true},
+				{benchID, 3, 10, 67.5, false},
+			},
+			t1Notes: "T1 stage 3: on failure retest your max and restart at stage 1",
+		},
+		{
+			name:     "failures wrap around the stages",
+			failures: map[string]int{model.LiftSquat: 3},
+			week:     1, day: 1,
+			want: []prescription{
+				{squatID, 4, 3, 145, false},
+				{squatID, 1, 3, 145, true},
+				{benchID, 3, 10, 67.5, false},
+			},
+			t1Notes: "T1 stage 1: on failure keep the load and move to 6x2",
+		},
+		{
+			name: "rotation adds load to both tiers",
+			week: 2, day: 4,
+			want: []prescription{
+				{benchID, 4, 3, 90, false},
+				{benchID, 1, 3, 90, true},
+				{squatID, 3, 10, 115, false},
+			},
+			t1Notes: "T1 stage 1: on failure keep the load and move to 6x2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := gzclp{}.Generate(&model.GeneratorInput{Lifts: testLifts(), Failures: tt.failures})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			day := findDay(t, res.Program, tt.week, tt.day)
+			if got := prescriptions(day); !slices.Equal(got, tt.want) {
+				t.Errorf("week %d day %d = %v, want %v", tt.week, tt.day, got, tt.want)
+			}
+			if got := day.Exercises[0].Notes; got != tt.t1Notes {
+				t.Errorf("T1 notes = %q, want %q", got, tt.t1Notes)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+type stage struct {
+	sets int
+	reps int
+}
+
+// Failing a stage keeps the load and moves to the next one; failing the last
+// stage restarts from the first one at a retested max.
+var (
+	gzclpT1Stages = []stage{{5, 3}, {6, 2}, {10, 1}}
+	gzclpT2Stages = []stage{{3, 10}, {3, 8}, {3, 6}}
+)
+
+// gzclpSessions pairs a T1 and a T2 lift in each of the four rotating
+// sessions.
+var gzclpSessions = [4][2]string{
+	{model.LiftSquat, model.LiftBench},
+	{model.LiftOHP, model.LiftDeadlift},
+	{model.LiftBench, model.LiftSquat},
+	{model.LiftDeadlift, model.LiftOHP},
+}
+
+// gzclp is Cody Lefever's GZCL linear progression with T1 and T2 tiers.
+// Failures select the stage each lift starts in.
+type gzclp struct{}
+
+func (gzclp) Info() model.GeneratorInfo {
+	return model.GeneratorInfo{
+		Name:         "gzclp",
+		Description:  "GZCLP: heavy T1 and volume T2 tiers with stage changes on failure",
+		DefaultWeeks: 12,
+	}
+}
+
+func (g gzclp) Generate(in *model.GeneratorInput) (*model.GeneratedProgram, error) {
+	if err := requireLifts(in, model.LiftSquat, model.LiftBench, model.LiftDeadlift, model.LiftOHP); err != nil {
+		return nil, err
+	}
+
+	res := &model.GeneratedProgram{
+		Program: &model.Program{
+			Name:        "GZCLP",
+			Description: g.Info().Description,
+			Weeks:       weeks(in, g.Info().DefaultWeeks),
+		},
+	}
+
+	t1 := make(map[string]float64, len(in.Lifts))
+	t2 := make(map[string]float64, len(in.Lifts))
+	for _, lifts := range gzclpSessions {
+		fiveRM := repMax(in.Lifts[lifts[0]].OneRM, 5)
+		t1[lifts[0]] = roundLoad(0.85 * fiveRM)
+		t2[lifts[0]] = roundLoad(0.65 * fiveRM)
+	}
+
+	session := 0
+	for w := 1; w <= res.Program.Weeks; w++ {
+		for _, d := range []int{1, 2, 4, 5} {
+			lifts := gzclpSessions[session%len(gzclpSessions)]
+			day := &model.ProgramDay{
+				Week: w,
+				Day:  d,
+				Name: fmt.Sprintf("T1 %s, T2 %s", liftNames[lifts[0]], liftNames[lifts[1]]),
+			}
+
+			t1Lift, t2Lift := in.Lifts[lifts[0]], in.Lifts[lifts[1]]
+			s := gzclpT1Stages[in.Failures[lifts[0]]%len(gzclpT1Stages)]
+			notes := gzclpNotes("T1", gzclpT1Stages, in.Failures[lifts[0]])
+			addSets(day, t1Lift, s.sets-1, s.reps, t1[lifts[0]], false, notes)
+			addSets(day, t1Lift, 1, s.reps, t1[lifts[0]], true, notes)
+			t1[lifts[0]] += increment(lifts[0])
+
+			s = gzclpT2Stages[in.Failures[lifts[1]]%len(gzclpT2Stages)]
+			addSets(day, t2Lift, s.sets, s.reps, t2[lifts[1]], false, gzclpNotes("T2", gzclpT2Stages, in.Failures[lifts[1]]))
+			t2[lifts[1]] += loadIncrement
+
+			res.Program.Days = append(res.Program.Days, day)
+			session++
+		}
+	}
+
+	return res, nil
+}
+
+func gzclpNotes(tier string, stages []stage, failures int) string {
+	cur := failures % len(stages)
+	if cur == len(stages)-1 {
+		return fmt.Sprintf("%s stage %d: on failure retest your max and restart at stage 1", tier, cur+1)
+	}
+	next := stages[cur+1]
+	return fmt.Sprintf("%s stage %d: on failure keep the load and move to %dx%d", tier, cur+1, next.sets, next.reps)
+}
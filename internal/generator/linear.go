@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+var linearSessions = [2][]struct {
+	lift string
+	sets int
+}{
+	{{model.LiftSquat, 3}, {model.LiftBench, 3}, {model.LiftDeadlift, 1}},
+	{{model.LiftSquat, 3}, {model.LiftOHP, 3}, {model.LiftDeadlift, 1}},
+}
+
+// linearIncrements are added every time a lift is trained.
+var linearIncrements = map[string]float64{
+	model.LiftSquat:    2.5,
+	model.LiftBench:    2.5,
+	model.LiftOHP:      2.5,
+	model.LiftDeadlift: 5,
+}
+
+// linear is a Starting Strength style novice program: workouts A and B
+// alternate three days a week with sets of five, adding load every session.
+// Three failed sessions in a row on a lift deload it by 10%.
+type linear struct{}
+
+func (linear) Info() model.GeneratorInfo {
+	return model.GeneratorInfo{
+		Name:         "linear",
+		Description:  "Linear progression: alternating A/B workouts of 3x5, adding load every session",
+		DefaultWeeks: 12,
+	}
+}
+
+func (g linear) Generate(in *model.GeneratorInput) (*model.GeneratedProgram, error) {
+	if err := requireLifts(in, model.LiftSquat, model.LiftBench, model.LiftDeadlift, model.LiftOHP); err != nil {
+		return nil, err
+	}
+
+	res := &model.GeneratedProgram{
+		Program: &model.Program{
+			Name:        "Linear progression",
+			Description: g.Info().Description,
+			Weeks:       weeks(in, g.Info().DefaultWeeks),
+		},
+	}
+
+	loads := make(map[string]float64, len(linearIncrements))
+	for lift := range linearIncrements {
+		load := 0.9 * repMax(in.Lifts[lift].OneRM, 5)
+		if in.Failures[lift] >= 3 {
+			load *= 0.9
+		}
+		loads[lift] = roundLoad(load)
+	}
+
+	session := 0
+	for w := 1; w <= res.Program.Weeks; w++ {
+		for _, d := range []int{1, 3, 5} {
+			workout := linearSessions[session%2]
+			day := &model.ProgramDay{
+				Week: w,
+				Day:  d,
+				Name: string(rune('A' + session%2)),
+			}
+			for _, e := range workout {
+				addSets(day, in.Lifts[e.lift], e.sets, 5, loads[e.lift], false,
+					"Add load next session; after three failed sessions in a row deload 10%")
+				loads[e.lift] += linearIncrements[e.lift]
+			}
+			res.Program.Days = append(res.Program.Days, day)
+			session++
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+type wave struct {
+	percent float64
+	reps    int
+}
+
+// wendlerWaves are the three working sets of each week of a 5/3/1 cycle, the
+// fourth week being a deload.
+var wendlerWaves = [4][3]wave{
+	{{65, 5}, {75, 5}, {85, 5}},
+	{{70, 3}, {80, 3}, {90, 3}},
+	{{75, 5}, {85, 3}, {95, 1}},
+	{{40, 5}, {50, 5}, {60, 5}},
+}
+
+// wendlerDays trains one main lift per session, four days a week.
+var wendlerDays = []struct {
+	day  int
+	lift string
+}{
+	{1, model.LiftOHP},
+	{2, model.LiftDeadlift},
+	{4, model.LiftBench},
+	{5, model.LiftSquat},
+}
+
+// wendler is Jim Wendler's 5/3/1: four-week cycles off a training max of 90%
+// of the one-rep max, raised after every cycle. A failed AMRAP set lowers the
+// training max by 10%.
+type wendler struct{}
+
+func (wendler) Info() model.GeneratorInfo {
+	return model.GeneratorInfo{
+		Name:         "531",
+		Description:  "Wendler 5/3/1: four-week waves with AMRAP top sets and a deload week",
+		DefaultWeeks: 12,
+	}
+}
+
+func (g wendler) Generate(in *model.GeneratorInput) (*model.GeneratedProgram, error) {
+	if err := requireLifts(in, model.LiftSquat, model.LiftBench, model.LiftDeadlift, model.LiftOHP); err != nil {
+		return nil, err
+	}
+
+	res := &model.GeneratedProgram{
+		Program: &model.Program{
+			Name:        "5/3/1",
+			Description: g.Info().Description,
+			Weeks:       weeks(in, g.Info().DefaultWeeks),
+		},
+	}
+
+	tms := make(map[string]float64, len(wendlerDays))
+	for _, d := range wendlerDays {
+		lift := in.Lifts[d.lift]
+		tm := 0.9 * lift.OneRM
+		if in.Failures[d.lift] > 0 {
+			tm *= 0.9
+		}
+		tms[d.lift] = roundLoad(tm)
+		res.TrainingMaxes = append(res.TrainingMaxes, &model.TrainingMax{ExerciseID: lift.ExerciseID, TrainingMax: tms[d.lift]})
+	}
+
+	for w := 0; w < res.Program.Weeks; w++ {
+		cycle, week := w/4, w%4
+		for _, d := range wendlerDays {
+			lift := in.Lifts[d.lift]
+			tm := tms[d.lift] + float64(cycle)*increment(d.lift)
+
+			day := &model.ProgramDay{
+				Week: w + 1,
+				Day:  d.day,
+				Name: liftNames[d.lift],
+			}
+			for i, set := range wendlerWaves[week] {
+				amrap := week < 3 && i == len(wendlerWaves[week])-1
+				addSets(day, lift, 1, set.reps, roundLoad(tm*set.percent/100), amrap, fmt.Sprintf("%.0f%% of %.1f kg TM", set.percent, tm))
+			}
+			res.Program.Days = append(res.Program.Days, day)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,414 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/pkg/errors"
+)
+
+// FIT global message numbers and field numbers used by the importer, as
+// defined in the Garmin FIT SDK profile.
+const (
+	fitMsgFileID  = 0
+	fitMsgSession = 18
+	fitMsgLap     = 19
+	fitMsgRecord  = 20
+	fitMsgSet     = 225
+
+	fitFieldTimestamp = 253
+
+	fitFileIDTimeCreated = 4
+
+	fitSessionStartTime  = 2
+	fitSessionSport      = 5
+	fitSessionSubSport   = 6
+	fitSessionElapsed    = 7
+	fitSessionDistance   = 9
+	fitSubSportStrength  = 20
+	fitLapStartTime      = 2
+	fitLapTimerTime      = 8
+	fitLapDistance       = 9
+	fitLapAvgHeartRate   = 15
+	fitRecordLat         = 0
+	fitRecordLong        = 1
+	fitRecordAltitude    = 2
+	fitRecordHeartRate   = 3
+	fitRecordDistance    = 5
+	fitRecordEnhancedAlt = 78
+	fitSetTimestamp      = 254
+	fitSetDuration       = 0
+	fitSetRepetitions    = 3
+	fitSetWeight         = 4
+	fitSetType           = 5
+	fitSetStartTime      = 6
+	fitSetCategory       = 7
+	fitSetSubtype        = 8
+	fitSetTypeActive     = 1
+)
+
+// fitEpoch is the FIT time origin, 1989-12-31T00:00:00Z.
+var fitEpoch = time.Date(1989, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+var fitSports = map[int64]string{
+	1:  model.SportRunning,
+	2:  model.SportCycling,
+	5:  model.SportSwimming,
+	11: model.SportWalking,
+	15: model.SportRowing,
+	17: model.SportWalking,
+}
+
+// fitExerciseCategories names the FIT exercise_category values that a set
+// message refers to. The names are looked up in the exercise mappings table.
+var fitExerciseCategories = map[int64]string{
+	0: "bench_press", 1: "calf_raise", 2: "cardio", 3: "carry", 4: "chop",
+	5: "core", 6: "crunch", 7: "curl", 8: "deadlift", 9: "flye",
+	10: "hip_raise", 11: "hip_stability", 12: "hip_swing", 13: "hyperextension",
+	14: "lateral_raise", 15: "leg_curl", 16: "leg_raise", 17: "lunge",
+	18: "olympic_lift", 19: "plank", 20: "plyo", 21: "pull_up", 22: "push_up",
+	23: "row", 24: "shoulder_press", 25: "shoulder_stability", 26: "shrug",
+	27: "sit_up", 28: "squat", 29: "total_body", 30: "triceps_extension",
+	31: "warm_up", 32: "run",
+}
+
+type fitFieldDef struct {
+	num      byte
+	size     byte
+	baseType byte
+}
+
+type fitDefinition struct {
+	global    uint16
+	order     binary.ByteOrder
+	fields    []fitFieldDef
+	devFields int
+}
+
+// fitMessage keeps the integer fields of a data message. Array fields keep all
+// their valid elements; strings and floats are not needed and are skipped.
+type fitMessage struct {
+	global uint16
+	fields map[byte][]int64
+}
+
+func (m *fitMessage) get(num byte) (int64, bool) {
+	v, ok := m.fields[num]
+	if !ok || len(v) == 0 {
+		return 0, false
+	}
+	return v[0], true
+}
+
+func (m *fitMessage) time(num byte) (time.Time, bool) {
+	v, ok := m.get(num)
+	if !ok {
+		return time.Time{}, false
+	}
+	return fitEpoch.Add(time.Duration(v) * time.Second), true
+}
+
+func decodeFIT(data []byte) ([]*fitMessage, error) {
+	if len(data) < 12 {
+		return nil, errors.New("fit file too short")
+	}
+	headerSize := int(data[0])
+	if headerSize < 12 || len(data) < headerSize || string(data[8:12]) != ".FIT" {
+		return nil, errors.New("invalid fit header")
+	}
+	dataSize := int(binary.LittleEndian.Uint32(data[4:8]))
+	if len(data) < headerSize+dataSize {
+		return nil, errors.New("truncated fit file")
+	}
+
+	var (
+		r             = bytes.NewReader(data[headerSize : headerSize+dataSize])
+		defs          = make(map[byte]*fitDefinition)
+		messages      []*fitMessage
+		lastTimestamp int64
+	)
+
+	for r.Len() > 0 {
+		header, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case header&0x80 != 0:
+			// compressed timestamp header: a 5 bit offset from the last timestamp
+			local := (header >> 5) & 0x03
+			offset := int64(header & 0x1F)
+			ts := lastTimestamp&^0x1F + offset
+			if offset < lastTimestamp&0x1F {
+				ts += 0x20
+			}
+			lastTimestamp = ts
+
+			msg, err := readFITData(r, defs[local])
+			if err != nil {
+				return nil, err
+			}
+			msg.fields[fitFieldTimestamp] = []int64{ts}
+			messages = append(messages, msg)
+		case header&0x40 != 0:
+			def, err := readFITDefinition(r, header&0x20 != 0)
+			if err != nil {
+				return nil, err
+			}
+			defs[header&0x0F] = def
+		default:
+			msg, err := readFITData(r, defs[header&0x0F])
+			if err != nil {
+				return nil, err
+			}
+			if ts, ok := msg.get(fitFieldTimestamp); ok {
+				lastTimestamp = ts
+			}
+			messages = append(messages, msg)
+		}
+	}
+
+	return messages, nil
+}
+
+func readFITDefinition(r *bytes.Reader, developer bool) (*fitDefinition, error) {
+	head := make([]byte, 5)
+	if _, err := io.ReadFull(r, head); err != nil {
+		return nil, errors.Wrap(err, "invalid fit definition")
+	}
+
+	def := &fitDefinition{order: binary.LittleEndian}
+	if head[1] == 1 {
+		def.order = binary.BigEndian
+	}
+	def.global = def.order.Uint16(head[2:4])
+
+	fields := make([]byte, 3*int(head[4]))
+	if _, err := io.ReadFull(r, fields); err != nil {
+		return nil, errors.Wrap(err, "invalid fit definition")
+	}
+	for i := 0; i < len(fields); i += 3 {
+		def.fields = append(def.fields, fitFieldDef{num: fields[i], size: fields[i+1], baseType: fields[i+2]})
+	}
+
+	if developer {
+		n, err := r.ReadByte()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid fit definition")
+		}
+		devFields := make([]byte, 3*int(n))
+		if _, err := io.ReadFull(r, devFields); err != nil {
+			return nil, errors.Wrap(err, "invalid fit definition")
+		}
+		for i := 0; i < len(devFields); i += 3 {
+			def.devFields += int(devFields[i+1])
+		}
+	}
+
+	return def, nil
+}
+
+func readFITData(r *bytes.Reader, def *fitDefinition) (*fitMessage, error) {
+	if def == nil {
+		return nil, errors.New("fit data message without definition")
+	}
+
+	msg := &fitMessage{global: def.global, fields: make(map[byte][]int64)}
+	for _, f := range def.fields {
+		buf := make([]byte, f.size)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, errors.Wrap(err, "truncated fit message")
+		}
+		if values := decodeFITValues(buf, f.baseType, def.order); len(values) > 0 {
+			msg.fields[f.num] = values
+		}
+	}
+
+	if _, err := r.Seek(int64(def.devFields), io.SeekCurrent); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// decodeFITValues decodes an integer field, dropping the elements that hold
+// the base type's invalid value.
+func decodeFITValues(buf []byte, baseType byte, order binary.ByteOrder) []int64 {
+	var (
+		size    int
+		decode  func(b []byte) int64
+		invalid int64
+	)
+
+	switch baseType & 0x1F {
+	case 0x00, 0x02, 0x0A, 0x0D: // enum, uint8, uint8z, byte
+		size, invalid = 1, 0xFF
+		decode = func(b []byte) int64 { return int64(b[0]) }
+	case 0x01: // sint8
+		size, invalid = 1, 0x7F
+		decode = func(b []byte) int64 { return int64(int8(b[0])) }
+	case 0x04, 0x0B: // uint16, uint16z
+		size, invalid = 2, 0xFFFF
+		decode = func(b []byte) int64 { return int64(order.Uint16(b)) }
+	case 0x03: // sint16
+		size, invalid = 2, 0x7FFF
+		decode = func(b []byte) int64 { return int64(int16(order.Uint16(b))) }
+	case 0x06, 0x0C: // uint32, uint32z
+		size, invalid = 4, 0xFFFFFFFF
+		decode = func(b []byte) int64 { return int64(order.Uint32(b)) }
+	case 0x05: // sint32
+		size, invalid = 4, 0x7FFFFFFF
+		decode = func(b []byte) int64 { return int64(int32(order.Uint32(b))) }
+	default:
+		return nil
+	}
+
+	z := baseType&0x1F == 0x0A || baseType&0x1F == 0x0B || baseType&0x1F == 0x0C
+	var values []int64
+	for i := 0; i+size <= len(buf); i += size {
+		v := decode(buf[i : i+size])
+		if v == invalid || (z && v == 0) {
+			continue
+		}
+		values = append(values, v)
+	}
+
+	return values
+}
+
+func parseFIT(data []byte) (*model.Activity, error) {
+	messages, err := decodeFIT(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid fit file")
+	}
+
+	activity := &model.Activity{
+		Format: model.ActivityFormatFIT,
+		Sport:  model.SportOther,
+	}
+
+	var created time.Time
+	for _, msg := range messages {
+		switch msg.global {
+		case fitMsgFileID:
+			created, _ = msg.time(fitFileIDTimeCreated)
+		case fitMsgSession:
+			if sport, ok := msg.get(fitSessionSport); ok {
+				if s, ok := fitSports[sport]; ok {
+					activity.Sport = s
+				}
+			}
+			if sub, ok := msg.get(fitSessionSubSport); ok && sub == fitSubSportStrength {
+				activity.Sport = model.SportStrength
+			}
+			if start, ok := msg.time(fitSessionStartTime); ok {
+				activity.StartedAt = start
+				if elapsed, ok := msg.get(fitSessionElapsed); ok {
+					activity.EndedAt = start.Add(time.Duration(elapsed) * time.Millisecond)
+				}
+			}
+		case fitMsgLap:
+			lap := &model.ActivityLap{}
+			lap.StartedAt, _ = msg.time(fitLapStartTime)
+			if v, ok := msg.get(fitLapTimerTime); ok {
+				lap.Duration = int(v / 1000)
+			}
+			if v, ok := msg.get(fitLapDistance); ok {
+				lap.Distance = float64(v) / 100 / 1000
+			}
+			if v, ok := msg.get(fitLapAvgHeartRate); ok {
+				lap.AvgHeartRate.Int32, lap.AvgHeartRate.Valid = int32(v), true
+			}
+			activity.Laps = append(activity.Laps, lap)
+		case fitMsgRecord:
+			activity.Points = append(activity.Points, fitTrackPoint(msg))
+		case fitMsgSet:
+			if set := fitSet(msg); set != nil {
+				activity.Sets = append(activity.Sets, set)
+			}
+		}
+	}
+
+	// a file of sets without a session starts with its first timed set or,
+	// failing that, when the file was created
+	if activity.StartedAt.IsZero() {
+		activity.StartedAt = created
+		for _, set := range activity.Sets {
+			if !set.StartedAt.IsZero() {
+				activity.StartedAt = set.StartedAt
+				break
+			}
+		}
+	}
+
+	return activity, nil
+}
+
+func fitTrackPoint(msg *fitMessage) *model.TrackPoint {
+	point := &model.TrackPoint{}
+	point.Time, _ = msg.time(fitFieldTimestamp)
+
+	lat, okLat := msg.get(fitRecordLat)
+	lon, okLon := msg.get(fitRecordLong)
+	if okLat && okLon {
+		// positions are stored in semicircles
+		point.HasPosition = true
+		point.Latitude = float64(lat) * 180 / (1 << 31)
+		point.Longitude = float64(lon) * 180 / (1 << 31)
+	}
+
+	alt, ok := msg.get(fitRecordEnhancedAlt)
+	if !ok {
+		alt, ok = msg.get(fitRecordAltitude)
+	}
+	if ok {
+		point.Elevation.Float64, point.Elevation.Valid = float64(alt)/5-500, true
+	}
+
+	if hr, ok := msg.get(fitRecordHeartRate); ok {
+		point.HeartRate.Int32, point.HeartRate.Valid = int32(hr), true
+	}
+	if d, ok := msg.get(fitRecordDistance); ok {
+		point.Distance = float64(d) / 100 / 1000
+	}
+
+	return point
+}
+
+// fitSet converts an active set message; rest periods are dropped.
+func fitSet(msg *fitMessage) *model.ActivitySet {
+	if t, ok := msg.get(fitSetType); !ok || t != fitSetTypeActive {
+		return nil
+	}
+
+	set := &model.ActivitySet{Category: "unknown"}
+	if v, ok := msg.get(fitSetCategory); ok {
+		if name, ok := fitExerciseCategories[v]; ok {
+			set.Category = name
+		}
+	}
+	if v, ok := msg.get(fitSetSubtype); ok {
+		set.Subtype = int(v)
+		set.HasSubtype = true
+	}
+	if v, ok := msg.get(fitSetRepetitions); ok {
+		set.Reps = int(v)
+	}
+	if v, ok := msg.get(fitSetWeight); ok {
+		set.Weight = float64(v) / 16
+	}
+	if v, ok := msg.get(fitSetDuration); ok {
+		set.Duration = int(v / 1000)
+	}
+	if start, ok := msg.time(fitSetStartTime); ok {
+		set.StartedAt = start
+	} else if end, ok := msg.time(fitSetTimestamp); ok {
+		set.StartedAt = end.Add(-time.Duration(set.Duration) * time.Second)
+	}
+
+	return set
+}
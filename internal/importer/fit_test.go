@@ -0,0 +1,277 @@
+package importer
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+const (
+	fitEnum   = 0x00
+	fitUint8  = 0x02
+	fitSint16 = 0x83
+	fitUint16 = 0x84
+	fitUint32 = 0x86
+)
+
+type fitTestField struct {
+	num      byte
+	baseType byte
+	value    int64
+}
+
+type fitTestMessage struct {
+	global uint16
+	fields []fitTestField
+}
+
+func fitSize(baseType byte) int {
+	switch baseType {
+	case fitSint16, fitUint16:
+		return 2
+	case fitUint32:
+		return 4
+	default:
+		return 1
+	}
+}
+
+// encodeFIT writes every message as a little-endian definition and data
+// message pair of local message 0.
+func encodeFIT(messages ...fitTestMessage) []byte {
+	var records []byte
+	for _, m := range messages {
+		records = append(records, 0x40, 0, 0)
+		records = binary.LittleEndian.AppendUint16(records, m.global)
+		records = append(records, byte(len(m.fields)))
+		for _, f := range m.fields {
+			records = append(records, f.num, byte(fitSize(f.baseType)), f.baseType)
+		}
+
+		records = append(records, 0)
+		for _, f := range m.fields {
+			switch fitSize(f.baseType) {
+			case 1:
+				records = append(records, byte(f.value))
+			case 2:
+				records = binary.LittleEndian.AppendUint16(records, uint16(f.value))
+			case 4:
+				records = binary.LittleEndian.AppendUint32(records, uint32(f.value))
+			}
+		}
+	}
+
+	header := []byte{12, 0x20, 0, 0}
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(records)))
+	header = append(header, ".FIT"...)
+
+	return append(header, records...)
+}
+
+func fitTime(seconds int64) time.Time {
+	return fitEpoch.Add(time.Duration(seconds) * time.Second)
+}
+
+func fileID(created int64) fitTestMessage {
+	return fitTestMessage{fitMsgFileID, []fitTestField{{fitFileIDTimeCreated, fitUint32, created}}}
+}
+
+func session(start int64, sport, subSport int64) fitTestMessage {
+	return fitTestMessage{fitMsgSession, []fitTestField{
+		{fitSessionStartTime, fitUint32, start},
+		{fitSessionSport, fitEnum, sport},
+		{fitSessionSubSport, fitEnum, subSport},
+		{fitSessionElapsed, fitUint32, 3_600_000},
+	}}
+}
+
+func set(fields ...fitTestField) fitTestMessage {
+	return fitTestMessage{fitMsgSet, fields}
+}
+
+func TestDecodeFITValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		buf      []byte
+		baseType byte
+		want     []int64
+	}{
+		{"uint8", []byte{42}, fitUint8, []int64{42}},
+		{"invalid uint8", []byte{0xFF}, fitUint8, nil},
+		{"uint8 array", []byte{1, 0xFF, 3}, fitUint8, []int64{1, 3}},
+		{"sint8", []byte{0xFE}, 0x01, []int64{-2}},
+		{"uint16", []byte{0x34, 0x12}, fitUint16, []int64{0x1234}},
+		{"invalid uint16", []byte{0xFF, 0xFF}, fitUint16, nil},
+		{"sint16", []byte{0xFE, 0xFF}, fitSint16, []int64{-2}},
+		{"uint16z zero", []byte{0, 0}, 0x8B, nil},
+		{"uint32", []byte{1, 0, 0, 0}, fitUint32, []int64{1}},
+		{"invalid sint32", []byte{0xFF, 0xFF, 0xFF, 0x7F}, 0x85, nil},
+		{"string", []byte("abc"), 0x07, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeFITValues(tt.buf, tt.baseType, binary.LittleEndian); !slices.Equal(got, tt.want) {
+				t.Errorf("decodeFITValues() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFIT(t *testing.T) {
+	const created, start = 1_000_000_000, 1_000_000_600
+
+	tests := []struct {
+		name      string
+		data      []byte
+		sport     string
+		startedAt time.Time
+		endedAt   time.Time
+		sets      []model.ActivitySet
+	}{
+		{
+			name: "strength session",
+			data: encodeFIT(
+				fileID(created),
+				session(start, 10, fitSubSportStrength),
+				set(
+					fitTestField{fitSetType, fitEnum, fitSetTypeActive},
+					fitTestField{fitSetCategory, fitUint16, 0},
+					fitTestField{fitSetSubtype, fitUint16, 3},
+					fitTestField{fitSetRepetitions, fitUint16, 5},
+					fitTestField{fitSetWeight, fitUint16, 100 * 16},
+					fitTestField{fitSetDuration, fitUint32, 30_000},
+					fitTestField{fitSetStartTime, fitUint32, start + 60},
+				),
+				set(
+					fitTestField{fitSetType, fitEnum, 0},
+					fitTestField{fitSetDuration, fitUint32, 90_000},
+				),
+			),
+			sport:     model.SportStrength,
+			startedAt: fitTime(start),
+			endedAt:   fitTime(start + 3600),
+			sets: []model.ActivitySet{
+				{Category: "bench_press", Subtype: 3, HasSubtype: true, Reps: 5, Weight: 100, Duration: 30, StartedAt: fitTime(start + 60)},
+			},
+		},
+		{
+			name:      "running session",
+			data:      encodeFIT(session(start, 1, 0)),
+			sport:     model.SportRunning,
+			startedAt: fitTime(start),
+			endedAt:   fitTime(start + 3600),
+		},
+		{
+			name: "sets without a session start with the first timed set",
+			data: encodeFIT(
+				fileID(created),
+				set(
+					fitTestField{fitSetType, fitEnum, fitSetTypeActive},
+					fitTestField{fitSetCategory, fitUint16, 1000},
+					fitTestField{fitSetRepetitions, fitUint16, 8},
+				),
+				set(
+					fitTestField{fitSetType, fitEnum, fitSetTypeActive},
+					fitTestField{fitSetCategory, fitUint16, 28},
+					fitTestField{fitSetDuration, fitUint32, 45_000},
+					fitTestField{fitSetTimestamp, fitUint32, start + 45},
+				),
+			),
+			sport:     model.SportOther,
+			startedAt: fitTime(start),
+			sets: []model.ActivitySet{
+				{Category: "unknown", Reps: 8},
+				{Category: "squat", Duration: 45, StartedAt: fitTime(start)},
+			},
+		},
+		{
+			name: "untimed sets start when the file was created",
+			data: encodeFIT(
+				fileID(created),
+				set(
+					fitTestField{fitSetType, fitEnum, fitSetTypeActive},
+					fitTestField{fitSetCategory, fitUint16, 21},
+					fitTestField{fitSetRepetitions, fitUint16, 10},
+				),
+			),
+			sport:     model.SportOther,
+			startedAt: fitTime(created),
+			sets: []model.ActivitySet{
+				{Category: "pull_up", Reps: 10},
+			},
+		},
+		{
+			name: "nothing to date",
+			data: encodeFIT(
+				set(
+					fitTestField{fitSetType, fitEnum, fitSetTypeActive},
+					fitTestField{fitSetRepetitions, fitUint16, 10},
+				),
+			),
+			sport: model.SportOther,
+			sets: []model.ActivitySet{
+				{Category: "unknown", Reps: 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFIT(tt.data)
+			if err != nil {
+				t.Fatalf("parseFIT() error = %v", err)
+			}
+
+			if got.Format != model.ActivityFormatFIT || got.Sport != tt.sport {
+				t.Errorf("format, sport = %s, %s, want %s, %s", got.Format, got.Sport, model.ActivityFormatFIT, tt.sport)
+			}
+			if !got.StartedAt.Equal(tt.startedAt) || !got.EndedAt.Equal(tt.endedAt) {
+				t.Errorf("started, ended = %v, %v, want %v, %v", got.StartedAt, got.EndedAt, tt.startedAt, tt.endedAt)
+			}
+			if len(got.Sets) != len(tt.sets) {
+				t.Fatalf("got %d sets, want %d", len(got.Sets), len(tt.sets))
+			}
+			for i, s := range got.Sets {
+				if *s != tt.sets[i] {
+					t.Errorf("set %d = %+v, want %+v", i, *s, tt.sets[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseFITInvalid(t *testing.T) {
+	valid := encodeFIT(fileID(1))
+
+	badMagic := slices.Clone(valid)
+	copy(badMagic[8:12], ".TXT")
+
+	truncated := slices.Clone(valid[:len(valid)-1])
+
+	orphan := []byte{12, 0x20, 0, 0, 1, 0, 0, 0}
+	orphan = append(orphan, ".FIT"...)
+	orphan = append(orphan, 0)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"too short", valid[:8]},
+		{"not a fit file", badMagic},
+		{"truncated", truncated},
+		{"data without definition", orphan},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseFIT(tt.data); err == nil {
+				t.Error("parseFIT() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/pkg/errors"
+)
+
+type gpxFile struct {
+	Metadata struct {
+		Time string `xml:"time"`
+	} `xml:"metadata"`
+	Tracks []struct {
+		Name     string `xml:"name"`
+		Type     string `xml:"type"`
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// gpxPoint reads heart rate from the Garmin TrackPointExtension, which most
+// devices and apps use.
+type gpxPoint struct {
+	Lat       float64  `xml:"lat,attr"`
+	Lon       float64  `xml:"lon,attr"`
+	Elevation *float64 `xml:"ele"`
+	Time      string   `xml:"time"`
+	HeartRate *int     `xml:"extensions>TrackPointExtension>hr"`
+}
+
+func parseGPX(data []byte) (*model.Activity, error) {
+	var file gpxFile
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&file); err != nil {
+		return nil, errors.Wrap(err, "invalid gpx file")
+	}
+
+	activity := &model.Activity{
+		Format: model.ActivityFormatGPX,
+		Sport:  model.SportOther,
+	}
+
+	for _, trk := range file.Tracks {
+		if activity.Name == "" {
+			activity.Name = trk.Name
+		}
+		if activity.Sport == model.SportOther {
+			activity.Sport = normalizeSport(trk.Type)
+		}
+
+		for _, seg := range trk.Segments {
+			for _, p := range seg.Points {
+				t, err := time.Parse(time.RFC3339, p.Time)
+				if err != nil {
+					return nil, errors.Wrap(err, "invalid gpx track point time")
+				}
+
+				point := &model.TrackPoint{
+					Time:        t.UTC(),
+					HasPosition: true,
+					Latitude:    p.Lat,
+					Longitude:   p.Lon,
+				}
+				if p.Elevation != nil {
+					point.Elevation.Float64, point.Elevation.Valid = *p.Elevation, true
+				}
+				if p.HeartRate != nil {
+					point.HeartRate.Int32, point.HeartRate.Valid = int32(*p.HeartRate), true
+				}
+				activity.Points = append(activity.Points, point)
+			}
+		}
+	}
+
+	return activity, nil
+}
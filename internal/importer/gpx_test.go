@@ -0,0 +1,149 @@
+package importer
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+const distanceEpsilon = 0.001
+
+// three points 0.0045° of latitude, about 500 m, apart
+const gpxRun = `<?xml version="1.0" encoding="UTF-8"?>
+<gpx version="1.1" creator="test" xmlns="http://www.topografix.com/GPX/1/1"
+  xmlns:gpxtpx="http://www.garmin.com/xmlschemas/TrackPointExtension/v1">
+  <metadata><time>2025-09-01T05:59:00Z</time></metadata>
+  <trk>
+    <name>Morning Run</name>
+    <type>running</type>
+    <trkseg>
+      <trkpt lat="55.0000" lon="37.0000">
+        <ele>150.0</ele>
+        <time>2025-09-01T09:00:00+03:00</time>
+        <extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>140</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions>
+      </trkpt>
+      <trkpt lat="55.0045" lon="37.0000">
+        <ele>150.4</ele>
+        <time>2025-09-01T06:02:30Z</time>
+        <extensions><gpxtpx:TrackPointExtension><gpxtpx:hr>150</gpxtpx:hr></gpxtpx:TrackPointExtension></extensions>
+      </trkpt>
+      <trkpt lat="55.0090" lon="37.0000">
+        <ele>155.0</ele>
+        <time>2025-09-01T06:05:00Z</time>
+      </trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+const gpxBare = `<gpx version="1.1">
+  <trk>
+    <trkseg>
+      <trkpt lat="55.0000" lon="37.0000"><time>2025-09-01T06:00:00Z</time></trkpt>
+      <trkpt lat="55.0045" lon="37.0000"><time>2025-09-01T06:03:00Z</time></trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+func TestParseGPX(t *testing.T) {
+	activity, err := Parse(model.ActivityFormatGPX, []byte(gpxRun))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if activity.Name != "Morning Run" || activity.Sport != model.SportRunning {
+		t.Errorf("name, sport = %q, %q, want %q, %q", activity.Name, activity.Sport, "Morning Run", model.SportRunning)
+	}
+	start := time.Date(2025, 9, 1, 6, 0, 0, 0, time.UTC)
+	if !activity.StartedAt.Equal(start) || !activity.EndedAt.Equal(start.Add(5*time.Minute)) {
+		t.Errorf("started, ended = %v, %v, want %v, %v", activity.StartedAt, activity.EndedAt, start, start.Add(5*time.Minute))
+	}
+	if activity.StartedAt.Location() != time.UTC {
+		t.Errorf("started at %v, want it in UTC", activity.StartedAt)
+	}
+	if len(activity.Points) != 3 {
+		t.Fatalf("got %d points, want 3", len(activity.Points))
+	}
+
+	wantDistances := []float64{0, 0.5004, 1.0008}
+	for i, p := range activity.Points {
+		if math.Abs(p.Distance-wantDistances[i]) > distanceEpsilon {
+			t.Errorf("point %d distance = %v, want %v", i, p.Distance, wantDistances[i])
+		}
+	}
+	if hr := activity.Points[2].HeartRate; hr.Valid {
+		t.Errorf("point without extensions has heart rate %d", hr.Int32)
+	}
+
+	we := Summarize(activity)
+	if we.Duration != 300 {
+		t.Errorf("duration = %d, want 300", we.Duration)
+	}
+	if math.Abs(we.Distance-1.0008) > distanceEpsilon {
+		t.Errorf("distance = %v, want 1.0008", we.Distance)
+	}
+	// the 0.4 m step is noise, the 4.6 m after it is counted from 150.0
+	if !we.ElevationGain.Valid || math.Abs(we.ElevationGain.Float64-5) > distanceEpsilon {
+		t.Errorf("elevation gain = %+v, want 5", we.ElevationGain)
+	}
+	if we.AvgHeartRate != (sql.NullInt32{Int32: 145, Valid: true}) || we.MaxHeartRate != (sql.NullInt32{Int32: 150, Valid: true}) {
+		t.Errorf("heart rate avg, max = %+v, %+v, want 145, 150", we.AvgHeartRate, we.MaxHeartRate)
+	}
+
+	if len(we.Splits) != 1 {
+		t.Fatalf("got %d splits, want 1", len(we.Splits))
+	}
+	sp := we.Splits[0]
+	if math.Abs(sp.Distance-1.0008) > distanceEpsilon || sp.Duration != 300 {
+		t.Errorf("split distance, duration = %v, %d, want 1.0008, 300", sp.Distance, sp.Duration)
+	}
+	if !sp.ElevationGain.Valid || math.Abs(sp.ElevationGain.Float64-5) > distanceEpsilon {
+		t.Errorf("split elevation gain = %+v, want 5", sp.ElevationGain)
+	}
+}
+
+func TestParseGPXWithoutExtensions(t *testing.T) {
+	activity, err := Parse(model.ActivityFormatGPX, []byte(gpxBare))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if activity.Sport != model.SportOther || activity.Name != "" {
+		t.Errorf("name, sport = %q, %q, want none", activity.Name, activity.Sport)
+	}
+
+	we := Summarize(activity)
+	if we.ElevationGain.Valid {
+		t.Errorf("elevation gain = %v, want none", we.ElevationGain.Float64)
+	}
+	if we.AvgHeartRate.Valid || we.MaxHeartRate.Valid {
+		t.Errorf("heart rate avg, max = %+v, %+v, want none", we.AvgHeartRate, we.MaxHeartRate)
+	}
+	if we.Duration != 180 || math.Abs(we.Distance-0.5004) > distanceEpsilon {
+		t.Errorf("duration, distance = %d, %v, want 180, 0.5004", we.Duration, we.Distance)
+	}
+	// shorter than a kilometre, so the whole run is one partial split
+	if len(we.Splits) != 1 || we.Splits[0].ElevationGain.Valid || we.Splits[0].AvgHeartRate.Valid {
+		t.Errorf("splits = %+v, want one split without elevation and heart rate", we.Splits)
+	}
+}
+
+func TestParseGPXInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not xml", "GPX"},
+		{"no track points", `<gpx><trk><trkseg></trkseg></trk></gpx>`},
+		{"bad time", `<gpx><trk><trkseg><trkpt lat="1" lon="1"><time>yesterday</time></trkpt></trkseg></trk></gpx>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(model.ActivityFormatGPX, []byte(tt.data)); err == nil {
+				t.Error("Parse() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Package importer parses activity files recorded by GPS watches and bike
+// computers into model.Activity.
+package importer
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/pkg/errors"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported activity file format")
+
+// Heart rates outside these bounds are sensor dropouts, recorded as 0 by
+// many devices, rather than readings; the database rejects them too.
+const (
+	minHeartRate = 20
+	maxHeartRate = 250
+)
+
+type parser func(data []byte) (*model.Activity, error)
+
+var parsers = map[string]parser{
+	model.ActivityFormatGPX: parseGPX,
+	model.ActivityFormatTCX: parseTCX,
+	model.ActivityFormatFIT: parseFIT,
+}
+
+// FormatFromFilename guesses the activity format from the file extension.
+func FormatFromFilename(name string) string {
+	return strings.ToLower(strings.TrimPrefix(filepath.Ext(name), "."))
+}
+
+// Parse decodes data of the given format, drops the heart rates that are no
+// readings and fills in the cumulative distance of every track point.
+func Parse(format string, data []byte) (*model.Activity, error) {
+	parse, ok := parsers[strings.ToLower(format)]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	activity, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(activity.Points) == 0 && len(activity.Laps) == 0 && len(activity.Sets) == 0 {
+		return nil, errors.New("activity has no track points")
+	}
+
+	dropHeartRateDropouts(activity)
+	fillDistance(activity.Points)
+	if activity.StartedAt.IsZero() && len(activity.Points) > 0 {
+		activity.StartedAt = activity.Points[0].Time
+	}
+	if activity.EndedAt.IsZero() && len(activity.Points) > 0 {
+		activity.EndedAt = activity.Points[len(activity.Points)-1].Time
+	}
+
+	return activity, nil
+}
+
+// dropHeartRateDropouts clears the heart rates of the points and laps that
+// are out of bounds, so they count neither towards the averages nor the
+// maximum.
+func dropHeartRateDropouts(a *model.Activity) {
+	valid := func(hr int32) bool { return hr >= minHeartRate && hr <= maxHeartRate }
+	for _, p := range a.Points {
+		if p.HeartRate.Valid && !valid(p.HeartRate.Int32) {
+			p.HeartRate = sql.NullInt32{}
+		}
+	}
+	for _, lap := range a.Laps {
+		if lap.AvgHeartRate.Valid && !valid(lap.AvgHeartRate.Int32) {
+			lap.AvgHeartRate = sql.NullInt32{}
+		}
+	}
+}
+
+// normalizeSport maps the sport names used by devices and apps onto the
+// model.Sport* constants.
+func normalizeSport(sport string) string {
+	switch strings.ToLower(strings.TrimSpace(sport)) {
+	case "running", "run", "trail_running", "treadmill_running":
+		return model.SportRunning
+	case "biking", "cycling", "bike", "ride", "road_biking", "mountain_biking":
+		return model.SportCycling
+	case "walking", "walk", "hiking", "hike":
+		return model.SportWalking
+	case "swimming", "swim", "open_water_swimming", "lap_swimming":
+		return model.SportSwimming
+	case "rowing", "indoor_rowing":
+		return model.SportRowing
+	default:
+		return model.SportOther
+	}
+}
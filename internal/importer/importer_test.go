@@ -0,0 +1,41 @@
+package importer
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func TestDropHeartRateDropouts(t *testing.T) {
+	tests := []struct {
+		name string
+		hr   sql.NullInt32
+		want sql.NullInt32
+	}{
+		{"no reading", sql.NullInt32{}, sql.NullInt32{}},
+		{"dropout", sql.NullInt32{Valid: true}, sql.NullInt32{}},
+		{"below range", sql.NullInt32{Int32: minHeartRate - 1, Valid: true}, sql.NullInt32{}},
+		{"lowest", sql.NullInt32{Int32: minHeartRate, Valid: true}, sql.NullInt32{Int32: minHeartRate, Valid: true}},
+		{"resting", sql.NullInt32{Int32: 60, Valid: true}, sql.NullInt32{Int32: 60, Valid: true}},
+		{"highest", sql.NullInt32{Int32: maxHeartRate, Valid: true}, sql.NullInt32{Int32: maxHeartRate, Valid: true}},
+		{"above range", sql.NullInt32{Int32: maxHeartRate + 1, Valid: true}, sql.NullInt32{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity := &model.Activity{
+				Points: []*model.TrackPoint{{HeartRate: tt.hr}},
+				Laps:   []*model.ActivityLap{{AvgHeartRate: tt.hr}},
+			}
+			dropHeartRateDropouts(activity)
+
+			if got := activity.Points[0].HeartRate; got != tt.want {
+				t.Errorf("point heart rate = %+v, want %+v", got, tt.want)
+			}
+			if got := activity.Laps[0].AvgHeartRate; got != tt.want {
+				t.Errorf("lap heart rate = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
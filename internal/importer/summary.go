@@ -0,0 +1,176 @@
+package importer
+
+import (
+	"math"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+const (
+	earthRadiusKm = 6371.0
+	splitKm       = 1.0
+	// elevation changes below this threshold are treated as GPS and barometer
+	// noise and do not count towards elevation gain
+	elevationNoiseMeters = 1.0
+)
+
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// fillDistance sets the cumulative distance of every point. Distances recorded
+// by the device (wheel sensors, foot pods) are kept; otherwise they are
+// computed from GPS positions.
+func fillDistance(points []*model.TrackPoint) {
+	recorded := false
+	for _, p := range points {
+		if p.Distance > 0 {
+			recorded = true
+			break
+		}
+	}
+
+	var (
+		total float64
+		prev  *model.TrackPoint
+	)
+	for _, p := range points {
+		switch {
+		case recorded && p.Distance > 0:
+			total = p.Distance
+		case !recorded && prev != nil && p.HasPosition:
+			total += haversine(prev.Latitude, prev.Longitude, p.Latitude, p.Longitude)
+		}
+		p.Distance = total
+		if p.HasPosition {
+			prev = p
+		}
+	}
+}
+
+// Summarize aggregates an activity into a cardio exercise entry with its
+// splits. Laps recorded by the device are used as splits when there is more
+// than one; otherwise the track is split every kilometre.
+func Summarize(a *model.Activity) *model.WorkoutExercise {
+	we := &model.WorkoutExercise{
+		Sets:     1,
+		Duration: int(a.EndedAt.Sub(a.StartedAt).Seconds()),
+	}
+
+	if n := len(a.Points); n > 0 {
+		we.Distance = a.Points[n-1].Distance
+	}
+	if we.Distance == 0 {
+		for _, lap := range a.Laps {
+			we.Distance += lap.Distance
+		}
+	}
+
+	var (
+		hrSum, hrCount int
+		hrMax          int32
+		gain, lastEle  float64
+		haveEle        bool
+	)
+	for _, p := range a.Points {
+		if p.HeartRate.Valid {
+			hrSum += int(p.HeartRate.Int32)
+			hrCount++
+			if p.HeartRate.Int32 > hrMax {
+				hrMax = p.HeartRate.Int32
+			}
+		}
+		if p.Elevation.Valid {
+			switch {
+			case !haveEle:
+				lastEle, haveEle = p.Elevation.Float64, true
+			case math.Abs(p.Elevation.Float64-lastEle) >= elevationNoiseMeters:
+				if p.Elevation.Float64 > lastEle {
+					gain += p.Elevation.Float64 - lastEle
+				}
+				lastEle = p.Elevation.Float64
+			}
+		}
+	}
+
+	if hrCount > 0 {
+		we.AvgHeartRate.Int32, we.AvgHeartRate.Valid = int32(hrSum/hrCount), true
+		we.MaxHeartRate.Int32, we.MaxHeartRate.Valid = hrMax, true
+	}
+	if haveEle {
+		we.ElevationGain.Float64, we.ElevationGain.Valid = gain, true
+	}
+
+	if len(a.Laps) > 1 {
+		for _, lap := range a.Laps {
+			we.Splits = append(we.Splits, &model.Split{
+				Distance:     lap.Distance,
+				Duration:     lap.Duration,
+				AvgHeartRate: lap.AvgHeartRate,
+			})
+		}
+	} else {
+		we.Splits = distanceSplits(a.Points)
+	}
+
+	return we
+}
+
+// distanceSplits cuts the track into splitKm segments plus a trailing partial
+// one.
+func distanceSplits(points []*model.TrackPoint) []*model.Split {
+	if len(points) < 2 {
+		return nil
+	}
+
+	var (
+		splits  []*model.Split
+		start   = points[0]
+		prev    = points[0]
+		hrSum   int
+		hrCount int
+		gain    float64
+		haveEle bool
+	)
+
+	flush := func(end *model.TrackPoint) {
+		sp := &model.Split{
+			Distance: end.Distance - start.Distance,
+			Duration: int(end.Time.Sub(start.Time).Seconds()),
+		}
+		if hrCount > 0 {
+			sp.AvgHeartRate.Int32, sp.AvgHeartRate.Valid = int32(hrSum/hrCount), true
+		}
+		if haveEle {
+			sp.ElevationGain.Float64, sp.ElevationGain.Valid = gain, true
+		}
+		splits = append(splits, sp)
+		start, hrSum, hrCount, gain, haveEle = end, 0, 0, 0, false
+	}
+
+	for _, p := range points[1:] {
+		if p.HeartRate.Valid {
+			hrSum += int(p.HeartRate.Int32)
+			hrCount++
+		}
+		if p.Elevation.Valid && prev.Elevation.Valid {
+			gain += math.Max(0, p.Elevation.Float64-prev.Elevation.Float64)
+			haveEle = true
+		}
+		prev = p
+		if p.Distance-start.Distance >= splitKm {
+			flush(p)
+		}
+	}
+
+	if last := points[len(points)-1]; last != start && last.Distance > start.Distance {
+		flush(last)
+	}
+
+	return splits
+}
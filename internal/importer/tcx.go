@@ -0,0 +1,102 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/pkg/errors"
+)
+
+type tcxFile struct {
+	Activities []struct {
+		Sport string `xml:"Sport,attr"`
+		ID    string `xml:"Id"`
+		Notes string `xml:"Notes"`
+		Laps  []struct {
+			StartTime        string  `xml:"StartTime,attr"`
+			TotalTimeSeconds float64 `xml:"TotalTimeSeconds"`
+			DistanceMeters   float64 `xml:"DistanceMeters"`
+			AvgHeartRate     *int    `xml:"AverageHeartRateBpm>Value"`
+			Points           []struct {
+				Time           string   `xml:"Time"`
+				Latitude       *float64 `xml:"Position>LatitudeDegrees"`
+				Longitude      *float64 `xml:"Position>LongitudeDegrees"`
+				AltitudeMeters *float64 `xml:"AltitudeMeters"`
+				DistanceMeters *float64 `xml:"DistanceMeters"`
+				HeartRate      *int     `xml:"HeartRateBpm>Value"`
+			} `xml:"Track>Trackpoint"`
+		} `xml:"Lap"`
+	} `xml:"Activities>Activity"`
+}
+
+func parseTCX(data []byte) (*model.Activity, error) {
+	var file tcxFile
+	if err := xml.NewDecoder(bytes.NewReader(data)).Decode(&file); err != nil {
+		return nil, errors.Wrap(err, "invalid tcx file")
+	}
+	if len(file.Activities) == 0 {
+		return nil, errors.New("tcx file has no activities")
+	}
+
+	// multi-sport files are rare; only the first activity is imported
+	act := file.Activities[0]
+	activity := &model.Activity{
+		Format: model.ActivityFormatTCX,
+		Sport:  normalizeSport(act.Sport),
+		Name:   act.Notes,
+	}
+
+	for _, lap := range act.Laps {
+		start, err := time.Parse(time.RFC3339, lap.StartTime)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid tcx lap start time")
+		}
+
+		l := &model.ActivityLap{
+			StartedAt: start.UTC(),
+			Duration:  int(lap.TotalTimeSeconds),
+			Distance:  lap.DistanceMeters / 1000,
+		}
+		if lap.AvgHeartRate != nil {
+			l.AvgHeartRate.Int32, l.AvgHeartRate.Valid = int32(*lap.AvgHeartRate), true
+		}
+		activity.Laps = append(activity.Laps, l)
+
+		for _, p := range lap.Points {
+			t, err := time.Parse(time.RFC3339, p.Time)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid tcx track point time")
+			}
+
+			point := &model.TrackPoint{Time: t.UTC()}
+			if p.Latitude != nil && p.Longitude != nil {
+				point.HasPosition = true
+				point.Latitude, point.Longitude = *p.Latitude, *p.Longitude
+			}
+			if p.AltitudeMeters != nil {
+				point.Elevation.Float64, point.Elevation.Valid = *p.AltitudeMeters, true
+			}
+			if p.DistanceMeters != nil {
+				point.Distance = *p.DistanceMeters / 1000
+			}
+			if p.HeartRate != nil {
+				point.HeartRate.Int32, point.HeartRate.Valid = int32(*p.HeartRate), true
+			}
+			activity.Points = append(activity.Points, point)
+		}
+	}
+
+	// without track points the end is estimated from the timer time of the
+	// last lap; otherwise Parse takes it from the last point
+	if len(activity.Laps) > 0 {
+		activity.StartedAt = activity.Laps[0].StartedAt
+		if len(activity.Points) == 0 {
+			last := activity.Laps[len(activity.Laps)-1]
+			activity.EndedAt = last.StartedAt.Add(time.Duration(last.Duration) * time.Second)
+		}
+	}
+
+	return activity, nil
+}
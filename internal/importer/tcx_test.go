@@ -0,0 +1,164 @@
+package importer
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+// two laps with the distance recorded by a foot pod; the last point has
+// neither a position nor a heart rate
+const tcxRun = `<?xml version="1.0" encoding="UTF-8"?>
+<TrainingCenterDatabase xmlns="http://www.garmin.com/xmlschemas/TrainingCenterDatabase/v2">
+  <Activities>
+    <Activity Sport="Running">
+      <Id>2025-09-01T06:00:00Z</Id>
+      <Notes>Intervals</Notes>
+      <Lap StartTime="2025-09-01T06:00:00Z">
+        <TotalTimeSeconds>300</TotalTimeSeconds>
+        <DistanceMeters>1000</DistanceMeters>
+        <AverageHeartRateBpm><Value>150</Value></AverageHeartRateBpm>
+        <Track>
+          <Trackpoint>
+            <Time>2025-09-01T06:00:00Z</Time>
+            <Position><LatitudeDegrees>55.0</LatitudeDegrees><LongitudeDegrees>37.0</LongitudeDegrees></Position>
+            <AltitudeMeters>100</AltitudeMeters>
+            <DistanceMeters>0</DistanceMeters>
+            <HeartRateBpm><Value>140</Value></HeartRateBpm>
+          </Trackpoint>
+          <Trackpoint>
+            <Time>2025-09-01T06:05:00Z</Time>
+            <Position><LatitudeDegrees>55.1</LatitudeDegrees><LongitudeDegrees>37.0</LongitudeDegrees></Position>
+            <AltitudeMeters>103</AltitudeMeters>
+            <DistanceMeters>1000</DistanceMeters>
+            <HeartRateBpm><Value>160</Value></HeartRateBpm>
+          </Trackpoint>
+        </Track>
+      </Lap>
+      <Lap StartTime="2025-09-01T06:05:00Z">
+        <TotalTimeSeconds>240</TotalTimeSeconds>
+        <DistanceMeters>800</DistanceMeters>
+        <Track>
+          <Trackpoint>
+            <Time>2025-09-01T06:09:00Z</Time>
+            <AltitudeMeters>101</AltitudeMeters>
+            <DistanceMeters>1800</DistanceMeters>
+          </Trackpoint>
+        </Track>
+      </Lap>
+    </Activity>
+  </Activities>
+</TrainingCenterDatabase>`
+
+const tcxLapsOnly = `<TrainingCenterDatabase>
+  <Activities>
+    <Activity Sport="Biking">
+      <Lap StartTime="2025-09-01T06:00:00Z">
+        <TotalTimeSeconds>1800</TotalTimeSeconds>
+        <DistanceMeters>15000</DistanceMeters>
+      </Lap>
+    </Activity>
+  </Activities>
+</TrainingCenterDatabase>`
+
+func TestParseTCX(t *testing.T) {
+	activity, err := Parse(model.ActivityFormatTCX, []byte(tcxRun))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if activity.Name != "Intervals" || activity.Sport != model.SportRunning {
+		t.Errorf("name, sport = %q, %q, want %q, %q", activity.Name, activity.Sport, "Intervals", model.SportRunning)
+	}
+	start := time.Date(2025, 9, 1, 6, 0, 0, 0, time.UTC)
+	if !activity.StartedAt.Equal(start) || !activity.EndedAt.Equal(start.Add(9*time.Minute)) {
+		t.Errorf("started, ended = %v, %v, want %v, %v", activity.StartedAt, activity.EndedAt, start, start.Add(9*time.Minute))
+	}
+	if len(activity.Points) != 3 || len(activity.Laps) != 2 {
+		t.Fatalf("got %d points and %d laps, want 3 and 2", len(activity.Points), len(activity.Laps))
+	}
+
+	// the recorded distances win over the ~11 km between the GPS positions
+	wantDistances := []float64{0, 1, 1.8}
+	for i, p := range activity.Points {
+		if math.Abs(p.Distance-wantDistances[i]) > distanceEpsilon {
+			t.Errorf("point %d distance = %v, want %v", i, p.Distance, wantDistances[i])
+		}
+	}
+	last := activity.Points[2]
+	if last.HasPosition || last.HeartRate.Valid {
+		t.Errorf("last point = %+v, want no position and heart rate", last)
+	}
+
+	we := Summarize(activity)
+	if we.Duration != 540 || math.Abs(we.Distance-1.8) > distanceEpsilon {
+		t.Errorf("duration, distance = %d, %v, want 540, 1.8", we.Duration, we.Distance)
+	}
+	// climbs 3 m, then the 2 m descent only lowers the reference
+	if !we.ElevationGain.Valid || math.Abs(we.ElevationGain.Float64-3) > distanceEpsilon {
+		t.Errorf("elevation gain = %+v, want 3", we.ElevationGain)
+	}
+	if we.AvgHeartRate != (sql.NullInt32{Int32: 150, Valid: true}) || we.MaxHeartRate != (sql.NullInt32{Int32: 160, Valid: true}) {
+		t.Errorf("heart rate avg, max = %+v, %+v, want 150, 160", we.AvgHeartRate, we.MaxHeartRate)
+	}
+
+	// laps are the splits
+	want := []*model.Split{
+		{Distance: 1, Duration: 300, AvgHeartRate: sql.NullInt32{Int32: 150, Valid: true}},
+		{Distance: 0.8, Duration: 240},
+	}
+	if len(we.Splits) != len(want) {
+		t.Fatalf("got %d splits, want %d", len(we.Splits), len(want))
+	}
+	for i, sp := range we.Splits {
+		if math.Abs(sp.Distance-want[i].Distance) > distanceEpsilon || sp.Duration != want[i].Duration || sp.AvgHeartRate != want[i].AvgHeartRate {
+			t.Errorf("split %d = %+v, want %+v", i, sp, want[i])
+		}
+	}
+}
+
+func TestParseTCXLapsOnly(t *testing.T) {
+	activity, err := Parse(model.ActivityFormatTCX, []byte(tcxLapsOnly))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	start := time.Date(2025, 9, 1, 6, 0, 0, 0, time.UTC)
+	if !activity.StartedAt.Equal(start) || !activity.EndedAt.Equal(start.Add(30*time.Minute)) {
+		t.Errorf("started, ended = %v, %v, want %v, %v", activity.StartedAt, activity.EndedAt, start, start.Add(30*time.Minute))
+	}
+	if activity.Sport != model.SportCycling {
+		t.Errorf("sport = %q, want %q", activity.Sport, model.SportCycling)
+	}
+
+	we := Summarize(activity)
+	if we.Duration != 1800 || math.Abs(we.Distance-15) > distanceEpsilon {
+		t.Errorf("duration, distance = %d, %v, want 1800, 15", we.Duration, we.Distance)
+	}
+	if we.ElevationGain.Valid || we.AvgHeartRate.Valid || len(we.Splits) != 0 {
+		t.Errorf("summary = %+v, want no elevation, heart rate or splits", we)
+	}
+}
+
+func TestParseTCXInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not xml", "TCX"},
+		{"no activities", `<TrainingCenterDatabase><Activities></Activities></TrainingCenterDatabase>`},
+		{"bad lap start", `<TrainingCenterDatabase><Activities><Activity Sport="Running"><Lap StartTime="now"></Lap></Activity></Activities></TrainingCenterDatabase>`},
+		{"bad point time", `<TrainingCenterDatabase><Activities><Activity Sport="Running"><Lap StartTime="2025-09-01T06:00:00Z"><Track><Trackpoint><Time>later</Time></Trackpoint></Track></Lap></Activity></Activities></TrainingCenterDatabase>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(model.ActivityFormatTCX, []byte(tt.data)); err == nil {
+				t.Error("Parse() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	ActivityFormatGPX = "gpx"
+	ActivityFormatTCX = "tcx"
+	ActivityFormatFIT = "fit"
+
+	SportRunning  = "running"
+	SportCycling  = "cycling"
+	SportWalking  = "walking"
+	SportSwimming = "swimming"
+	SportRowing   = "rowing"
+	SportStrength = "strength"
+	SportOther    = "other"
+
+	MappingSourceSport       = "sport"
+	MappingSourceFITCategory = "fit_category"
+)
+
+var MappingSources = []string{MappingSourceSport, MappingSourceFITCategory}
+
+// ExerciseMapping maps a name used by devices and other apps onto a catalog
+// exercise. FIT strength sets are mapped by "category" or, more specifically,
+// by "category:subtype".
+type ExerciseMapping struct {
+	Source       string
+	ExternalName string
+	ExerciseID   int64
+}
+
+// TrackPoint is a single sample of a recorded activity. Distance is the
+// cumulative distance from the start in km.
+type TrackPoint struct {
+	Time        time.Time
+	HasPosition bool
+	Latitude    float64
+	Longitude   float64
+	Elevation   sql.NullFloat64
+	HeartRate   sql.NullInt32
+	Distance    float64
+}
+
+type ActivityLap struct {
+	StartedAt    time.Time
+	Duration     int
+	Distance     float64
+	AvgHeartRate sql.NullInt32
+}
+
+// ActivitySet is a strength set recorded by a watch. Category is the FIT
+// exercise category name, e.g. "bench_press"; Weight is in kg and Duration in
+// seconds.
+type ActivitySet struct {
+	Category   string
+	Subtype    int
+	HasSubtype bool
+	Reps       int
+	Weight     float64
+	Duration   int
+	StartedAt  time.Time
+}
+
+// Activity is a device recording parsed from an uploaded file.
+type Activity struct {
+	Format    string
+	Sport     string
+	Name      string
+	StartedAt time.Time
+	EndedAt   time.Time
+	Points    []*TrackPoint
+	Laps      []*ActivityLap
+	Sets      []*ActivitySet
+}
+
+type ActivityImport struct {
+	UserID     int64
+	ExerciseID int64
+	Name       string
+	Format     string
+	Raw        []byte
+}
+
+// WorkoutTrack is a raw uploaded file. WorkoutExerciseID is empty for strength
+// recordings, which are spread over several exercise entries.
+type WorkoutTrack struct {
+	ID                int64
+	WorkoutID         int64
+	WorkoutExerciseID sql.NullInt64
+	Format            string
+	Raw               []byte
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ExerciseAlias is an alternative name of an exercise: an abbreviation, gym
+// slang or another spelling. Built-in aliases have no creator.
+type ExerciseAlias struct {
+	ID         int64
+	ExerciseID int64
+	Alias      string
+	Normalized string
+	CreatedBy  sql.NullInt64
+	CreatedAt  time.Time
+}
+
+// ResolveQuery looks up the exercises a free-form name may refer to. Text is
+// normalized with NormalizeExerciseName.
+type ResolveQuery struct {
+	UserID int64
+	Text   string
+	Locale string
+	Limit  uint64
+}
+
+// ExerciseCandidate is an exercise the resolved name may refer to. Matched is
+// the name or alias it matched by and Score the trigram similarity to it,
+// from 0 to 1.
+type ExerciseCandidate struct {
+	Exercise *Exercise
+	Matched  string
+	Score    float64
+}
+
+// ConfidentMatchScore is the score above which a candidate is taken without
+// asking the user, e.g. when importing.
+const ConfidentMatchScore = 0.8
+
+// NormalizeExerciseName lowercases the name, replaces "ё" with "е", drops
+// apostrophes and turns any other run of punctuation and spaces into a single
+// space, so "Farmer's walk", "farmers-walk" and "FARMERS  WALK" are the same.
+func NormalizeExerciseName(name string) string {
+	name = strings.NewReplacer("'", "", "’", "", "ё", "е").Replace(strings.ToLower(name))
+	fields := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(fields, " ")
+}
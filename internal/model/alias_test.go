@@ -0,0 +1,33 @@
+package model
+
+import "testing"
+
+func TestNormalizeExerciseName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"", ""},
+		{"   ", ""},
+		{"Bench Press", "bench press"},
+		{"FARMERS  WALK", "farmers walk"},
+		{"farmers-walk", "farmers walk"},
+		{"Farmer's walk", "farmers walk"},
+		{"Farmer’s walk", "farmers walk"},
+		{"  T-Bar row (chest supported)  ", "t bar row chest supported"},
+		{"bench_press", "bench press"},
+		{"21s", "21s"},
+		{"DB press!!", "db press"},
+		{"ЖИМ ЛЁЖА", "жим лежа"},
+		{"Жим   гантелей, сидя", "жим гантелей сидя"},
+		{"--", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeExerciseName(tt.name); got != tt.want {
+				t.Errorf("NormalizeExerciseName(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
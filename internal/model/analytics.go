@@ -0,0 +1,111 @@
+package model
+
+import "time"
+
+type MuscleVolumeFilter struct {
+	StartDate time.Time
+	EndDate   time.Time
+	// SecondaryWeight is the share of a set credited to each secondary muscle
+	// group of an exercise. Zero counts primary muscle groups only.
+	SecondaryWeight float64
+	MinSets         float64
+	MaxSets         float64
+	Tags            TagFilter
+}
+
+type MuscleGroupVolume struct {
+	WeekStart   time.Time
+	MuscleGroup string
+	Sets        float64
+	Reps        float64
+	Tonnage     float64
+	Status      string
+}
+
+type WeeklyMuscleVolume struct {
+	WeekStart    time.Time
+	MuscleGroups []*MuscleGroupVolume
+}
+
+type MuscleVolumeReport struct {
+	StartDate time.Time
+	EndDate   time.Time
+	MinSets   float64
+	MaxSets   float64
+	Weeks     []*WeeklyMuscleVolume
+	Neglected []string
+}
+
+// CalendarDay holds the training done on a single day of the user's local
+// calendar. Date is the local date at midnight UTC; Volume leaves warm-ups out.
+type CalendarDay struct {
+	Date     time.Time
+	Workouts int
+	Volume   float64
+}
+
+type Calendar struct {
+	Year          int
+	Timezone      string
+	Days          []*CalendarDay
+	TotalWorkouts int
+	TotalVolume   float64
+}
+
+type ExerciseUsage struct {
+	ExerciseID int64
+	Name       string
+	Workouts   int
+	Sets       int
+}
+
+type StatsSummary struct {
+	Timezone               string
+	CurrentWeeklyStreak    int
+	LongestWeeklyStreak    int
+	WorkoutsThisWeek       int
+	WorkoutsThisMonth      int
+	WorkoutsThisYear       int
+	AverageSessionDuration time.Duration
+	TopExercises           []*ExerciseUsage
+}
+
+type TrainingLoadFilter struct {
+	StartDate         time.Time
+	EndDate           time.Time
+	Source            string
+	ACWRThreshold     float64
+	MonotonyThreshold float64
+	// StrainThreshold of zero disables strain flags, since strain limits are
+	// individual to each athlete.
+	StrainThreshold float64
+	Tags            TagFilter
+}
+
+type TrainingLoadDay struct {
+	Date        time.Time
+	Load        float64
+	AcuteLoad   float64
+	ChronicLoad float64
+	ACWR        float64
+	Monotony    float64
+	Strain      float64
+	Flags       []string
+}
+
+type TrainingLoadReport struct {
+	Source            string
+	Timezone          string
+	ACWRThreshold     float64
+	MonotonyThreshold float64
+	StrainThreshold   float64
+	Days              []*TrainingLoadDay
+	Spikes            []*TrainingLoadDay
+}
+
+// DailyLoad is the training load accumulated on a local calendar day. Date is
+// the local date at midnight UTC.
+type DailyLoad struct {
+	Date time.Time
+	Load float64
+}
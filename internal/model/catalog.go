@@ -0,0 +1,77 @@
+package model
+
+import "time"
+
+// Tracking profiles tell which metrics a set of an exercise is logged with.
+// Assisted sets store the assistance as a negative weight.
+const (
+	TrackingWeightReps   = "weight_reps"
+	TrackingReps         = "reps"
+	TrackingTime         = "time"
+	TrackingWeightTime   = "weight_time"
+	TrackingDistanceTime = "distance_time"
+	TrackingAssisted     = "assisted"
+)
+
+var TrackingProfiles = []string{
+	TrackingWeightReps, TrackingReps, TrackingTime,
+	TrackingWeightTime, TrackingDistanceTime, TrackingAssisted,
+}
+
+const (
+	MechanicsCompound  = "compound"
+	MechanicsIsolation = "isolation"
+)
+
+// MovementPatterns lists the movement patterns an exercise can be classified
+// with.
+var MovementPatterns = []string{
+	"squat", "hinge", "lunge",
+	"horizontal_push", "vertical_push", "horizontal_pull", "vertical_pull",
+	"elbow_flexion", "elbow_extension", "plantar_flexion",
+	"core", "carry", "locomotion",
+}
+
+type Equipment struct {
+	ID   int64
+	Code string
+	Name string
+}
+
+// Muscle is a muscle of the catalog. MuscleGroup is the coarse group it
+// belongs to, the one exercises are grouped by in analytics.
+type Muscle struct {
+	ID          int64
+	Code        string
+	Name        string
+	MuscleGroup string
+}
+
+type ExerciseMuscle struct {
+	ExerciseID int64
+	Muscle     *Muscle
+	Primary    bool
+}
+
+const (
+	MediaKindImage = "image"
+	MediaKindVideo = "video"
+)
+
+// ExerciseMedia is an instruction image or video of an exercise. StorageKey
+// locates its file in the file storage.
+type ExerciseMedia struct {
+	ID          int64
+	ExerciseID  int64
+	Kind        string
+	StorageKey  string
+	ContentType string
+	Size        int64
+	UploadedBy  int64
+	CreatedAt   time.Time
+}
+
+type UploadMediaParams struct {
+	UserID     int64
+	ExerciseID int64
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"database/sql"
+	"slices"
+	"time"
+)
+
+// Permissions a coach is granted on the workouts of an athlete, each one
+// including the ones before it: comments need the history in view and
+// assigned workouts need commenting on.
+const (
+	PermissionView    = "view"
+	PermissionComment = "comment"
+	PermissionAssign  = "assign"
+)
+
+var Permissions = []string{PermissionView, PermissionComment, PermissionAssign}
+
+// OwnerOnly leaves a workout to its owner whatever their coaches are
+// granted; logging the session and its sets, tags and warm-ups are the
+// athlete's own.
+const OwnerOnly = ""
+
+const (
+	CoachingPending = "pending"
+	CoachingActive  = "active"
+)
+
+func IsPermission(permission string) bool {
+	return slices.Contains(Permissions, permission)
+}
+
+// Grants lists the permissions that include permission, none for OwnerOnly.
+func Grants(permission string) []string {
+	i := slices.Index(Permissions, permission)
+	if i < 0 {
+		return nil
+	}
+
+	return Permissions[i:]
+}
+
+// Coaching relates a coach to an athlete. The coach invites the athlete by
+// Email and the relationship is pending until the user with that email
+// accepts it; AthleteID is unknown until then, so the coach cannot tell
+// whether the email is registered. Coach and Athlete carry the ID, name and
+// email of either side, only the email of a pending athlete.
+type Coaching struct {
+	ID         int64
+	CoachID    int64
+	AthleteID  int64
+	Email      string
+	Permission string
+	Status     string
+	Coach      *User
+	Athlete    *User
+	CreatedAt  time.Time
+	AcceptedAt sql.NullTime
+}
+
+// Allows tells whether the relationship is accepted and grants permission.
+func (c *Coaching) Allows(permission string) bool {
+	return c.Status == CoachingActive && slices.Contains(Grants(permission), c.Permission)
+}
+
+// InviteAthleteParams invites the user registered with Email to be coached
+// by CoachID.
+type InviteAthleteParams struct {
+	CoachID    int64
+	Email      string
+	Permission string
+}
+
+type UpdateCoachingParams struct {
+	CoachID    int64
+	AthleteID  int64
+	Permission string
+}
+
+type WorkoutComment struct {
+	ID         int64
+	WorkoutID  int64
+	AuthorID   int64
+	AuthorName string
+	Body       string
+	CreatedAt  time.Time
+}
+
+// AthleteSummary is a row of the coach dashboard: the athlete's latest
+// workouts and how closely they follow their active programs.
+type AthleteSummary struct {
+	Coaching       *Coaching
+	RecentWorkouts []*Workout
+	Adherence      []*Adherence
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGrants(t *testing.T) {
+	tests := []struct {
+		permission string
+		want       []string
+	}{
+		{PermissionView, []string{PermissionView, PermissionComment, PermissionAssign}},
+		{PermissionComment, []string{PermissionComment, PermissionAssign}},
+		{PermissionAssign, []string{PermissionAssign}},
+		{OwnerOnly, nil},
+		{"admin", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.permission, func(t *testing.T) {
+			if got := Grants(tt.permission); !slices.Equal(got, tt.want) {
+				t.Errorf("Grants(%q) = %v, want %v", tt.permission, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoachingAllows(t *testing.T) {
+	tests := []struct {
+		name       string
+		coaching   Coaching
+		permission string
+		want       bool
+	}{
+		{"view allows view", Coaching{Status: CoachingActive, Permission: PermissionView}, PermissionView, true},
+		{"view denies comment", Coaching{Status: CoachingActive, Permission: PermissionView}, PermissionComment, false},
+		{"view denies assign", Coaching{Status: CoachingActive, Permission: PermissionView}, PermissionAssign, false},
+		{"comment allows view", Coaching{Status: CoachingActive, Permission: PermissionComment}, PermissionView, true},
+		{"comment allows comment", Coaching{Status: CoachingActive, Permission: PermissionComment}, PermissionComment, true},
+		{"comment denies assign", Coaching{Status: CoachingActive, Permission: PermissionComment}, PermissionAssign, false},
+		{"assign allows view", Coaching{Status: CoachingActive, Permission: PermissionAssign}, PermissionView, true},
+		{"assign allows assign", Coaching{Status: CoachingActive, Permission: PermissionAssign}, PermissionAssign, true},
+		{"pending allows nothing", Coaching{Status: CoachingPending, Permission: PermissionAssign}, PermissionView, false},
+		{"unknown status allows nothing", Coaching{Permission: PermissionAssign}, PermissionView, false},
+		{"owner only", Coaching{Status: CoachingActive, Permission: PermissionAssign}, OwnerOnly, false},
+		{"unknown permission", Coaching{Status: CoachingActive, Permission: PermissionAssign}, "admin", false},
+		{"unknown granted permission", Coaching{Status: CoachingActive, Permission: "admin"}, PermissionView, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.coaching.Allows(tt.permission); got != tt.want {
+				t.Errorf("Allows(%q) = %v, want %v", tt.permission, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPermission(t *testing.T) {
+	for _, p := range Permissions {
+		if !IsPermission(p) {
+			t.Errorf("IsPermission(%q) = false, want true", p)
+		}
+	}
+	for _, p := range []string{OwnerOnly, "admin", "View"} {
+		if IsPermission(p) {
+			t.Errorf("IsPermission(%q) = true, want false", p)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// IdempotencyRecord is the outcome of the first request sent with an
+// idempotency key. StatusCode is not valid while that request is still
+// being handled.
+type IdempotencyRecord struct {
+	UserID       int64
+	Key          string
+	RequestHash  string
+	StatusCode   sql.NullInt32
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,33 @@
+package model
+
+import "database/sql"
+
+const (
+	LocaleEnglish = "en"
+	LocaleRussian = "ru"
+	// DefaultLocale is the language the built-in catalog is written in.
+	DefaultLocale = LocaleRussian
+)
+
+var Locales = []string{LocaleEnglish, LocaleRussian}
+
+// ExerciseTranslation is the name and description of an exercise in a locale.
+type ExerciseTranslation struct {
+	ExerciseID  int64
+	Locale      string
+	Name        string
+	Description sql.NullString
+}
+
+// ResolveLocale picks the language set in the user's profile, then the one
+// the client accepts, then the catalog's own.
+func ResolveLocale(profile sql.NullString, accepted string) string {
+	switch {
+	case profile.Valid && profile.String != "":
+		return profile.String
+	case accepted != "":
+		return accepted
+	default:
+		return DefaultLocale
+	}
+}
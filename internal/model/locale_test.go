@@ -0,0 +1,28 @@
+package model
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestResolveLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  sql.NullString
+		accepted string
+		want     string
+	}{
+		{"profile wins", sql.NullString{String: LocaleEnglish, Valid: true}, LocaleRussian, LocaleEnglish},
+		{"accepted without profile", sql.NullString{}, LocaleEnglish, LocaleEnglish},
+		{"empty profile", sql.NullString{Valid: true}, LocaleEnglish, LocaleEnglish},
+		{"catalog default", sql.NullString{}, "", DefaultLocale},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveLocale(tt.profile, tt.accepted); got != tt.want {
+				t.Errorf("ResolveLocale(%+v, %q) = %q, want %q", tt.profile, tt.accepted, got, tt.want)
+			}
+		})
+	}
+}
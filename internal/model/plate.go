@@ -0,0 +1,61 @@
+package model
+
+import "database/sql"
+
+const (
+	UnitKg = "kg"
+	UnitLb = "lb"
+
+	KgPerLb = 0.45359237
+)
+
+// PlateCount is a number of plates of one weight. In an inventory it counts
+// single plates; in a loading it counts plates on each side of the bar.
+type PlateCount struct {
+	Weight float64
+	Count  int
+}
+
+type PlateInventory struct {
+	UserID    int64
+	Unit      string
+	BarWeight float64
+	Plates    []*PlateCount
+}
+
+type PlateLoadParams struct {
+	UserID    int64
+	Target    float64
+	Unit      string
+	BarWeight sql.NullFloat64
+}
+
+// PlateLoading is the closest loadable weight not above the target. All
+// weights are in Unit.
+type PlateLoading struct {
+	Unit      string
+	Target    float64
+	BarWeight float64
+	Achieved  float64
+	PerSide   []*PlateCount
+	Exact     bool
+}
+
+type WarmUpParams struct {
+	UserID        int64
+	WorkingWeight float64
+	Unit          string
+	BarWeight     sql.NullFloat64
+}
+
+type WarmUpSet struct {
+	Percent float64
+	Reps    int
+	Loading *PlateLoading
+}
+
+type InsertWarmUpParams struct {
+	WarmUpParams
+	WorkoutID  int64
+	ExerciseID int64
+}
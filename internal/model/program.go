@@ -0,0 +1,149 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	PrescriptionPercentTM = "percent_tm"
+	PrescriptionRPE       = "rpe"
+	PrescriptionFixed     = "fixed"
+)
+
+// Program is a multi-week training plan. Programs without an owner are shared
+// with every user.
+type Program struct {
+	ID          int64
+	UserID      sql.NullInt64
+	Name        string
+	Description string
+	Weeks       int
+	Generator   sql.NullString
+	Days        []*ProgramDay
+	CreatedAt   time.Time
+}
+
+// ProgramDay is a planned session. Day counts from 1 to 7 within the week,
+// starting at the weekday of the enrollment start date.
+type ProgramDay struct {
+	ID        int64
+	ProgramID int64
+	Week      int
+	Day       int
+	Name      string
+	Exercises []*ProgramExercise
+}
+
+// ProgramExercise prescribes sets x reps at a load given by Type: Value is a
+// percentage of the training max, a target RPE or a fixed weight in kg.
+type ProgramExercise struct {
+	ID           int64
+	ProgramDayID int64
+	ExerciseID   int64
+	Position     int
+	Sets         int
+	Reps         int
+	Type         string
+	Value        float64
+	AMRAP        bool
+	Notes        string
+	Exercise     Exercise
+}
+
+type TrainingMax struct {
+	ExerciseID  int64
+	TrainingMax float64
+}
+
+type Enrollment struct {
+	ID            int64
+	UserID        int64
+	ProgramID     int64
+	StartDate     time.Time
+	Active        bool
+	TrainingMaxes []*TrainingMax
+	Substitutions []*ExerciseSubstitution
+	Program       *Program
+	CreatedAt     time.Time
+}
+
+// ScheduledExercise is a prescription resolved for a specific enrollment.
+// Weight is empty when the load is autoregulated by RPE or the training max
+// is unknown. ReplacedExerciseID is the program's own exercise when the user
+// swapped it for ExerciseID; the load then comes from the training max of the
+// substitute.
+type ScheduledExercise struct {
+	ProgramExerciseID  int64
+	ReplacedExerciseID sql.NullInt64
+	ExerciseID         int64
+	Exercise           Exercise
+	Sets               int
+	Reps               int
+	Type               string
+	Value              float64
+	Weight             sql.NullFloat64
+	TargetRPE          sql.NullFloat64
+	AMRAP              bool
+	Notes              string
+}
+
+type ScheduledSession struct {
+	EnrollmentID int64
+	Date         time.Time
+	Week         int
+	Day          int
+	Name         string
+	WorkoutID    sql.NullInt64
+	Exercises    []*ScheduledExercise
+}
+
+type EnrollmentSession struct {
+	EnrollmentID int64
+	PlannedDate  time.Time
+	WorkoutID    int64
+}
+
+type Adherence struct {
+	EnrollmentID int64
+	Planned      int
+	Completed    int
+	Rate         float64
+	Sessions     []*ScheduledSession
+}
+
+// LiftMax is the estimated one-rep max of a main lift, taken from the user's
+// personal record for it.
+type LiftMax struct {
+	ExerciseID int64
+	Lift       string
+	OneRM      float64
+}
+
+// GeneratorInput is what a program generator builds from. Failures counts the
+// sessions in a row the lifter missed the prescribed reps on a lift; schemes
+// with failure handling use it to pick the starting stage or load.
+type GeneratorInput struct {
+	Weeks    int
+	Lifts    map[string]*LiftMax
+	Failures map[string]int
+}
+
+type GeneratedProgram struct {
+	Program       *Program
+	TrainingMaxes []*TrainingMax
+}
+
+type GeneratorInfo struct {
+	Name         string
+	Description  string
+	DefaultWeeks int
+}
+
+type GenerateProgramParams struct {
+	UserID    int64
+	Generator string
+	Weeks     int
+	StartDate time.Time
+	Failures  map[string]int
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+const (
+	ProgressionStart    = "start"
+	ProgressionIncrease = "increase"
+	ProgressionAddReps  = "add_reps"
+	ProgressionRepeat   = "repeat"
+	ProgressionDeload   = "deload"
+)
+
+// ExerciseSession is the heaviest entry of an exercise in one workout.
+type ExerciseSession struct {
+	WorkoutID  int64
+	Date       time.Time
+	Sets       int
+	Reps       int
+	Weight     float64
+	TargetReps int
+}
+
+// ProgressionSuggestion is the load to use next time an exercise is trained.
+// Weight is zero for bodyweight exercises and when there is no history yet
+// and negative, the assistance, for assisted ones; Reps is zero for
+// exercises tracked without reps.
+type ProgressionSuggestion struct {
+	ExerciseID        int64
+	Action            string
+	Sets              int
+	Reps              int
+	Weight            float64
+	Increment         float64
+	ConsecutiveMisses int
+	Last              *ExerciseSession
+}
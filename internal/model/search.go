@@ -0,0 +1,38 @@
+package model
+
+import "database/sql"
+
+const (
+	SearchKindWorkout  = "workout"
+	SearchKindEntry    = "entry"
+	SearchKindExercise = "exercise"
+)
+
+// SearchQuery is a free-text query in web search syntax: quoted phrases,
+// "or" and a leading minus are understood.
+// SearchQuery is a search of the user's. Locale is the language exercise
+// names are returned in.
+type SearchQuery struct {
+	UserID int64
+	Text   string
+	Locale string
+	Limit  uint64
+}
+
+// SearchResult is a matching workout, exercise entry of a workout or catalog
+// exercise. Snippet is the matched text, HTML-escaped, with the matches
+// wrapped in <mark></mark>.
+type SearchResult struct {
+	Kind      string
+	ID        int64
+	WorkoutID sql.NullInt64
+	Title     string
+	Snippet   string
+	Rank      float64
+	Date      sql.NullTime
+}
+
+type SearchResults struct {
+	Results []*SearchResult
+	Total   int64
+}
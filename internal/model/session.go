@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+const (
+	SessionEventStarted   = "session_started"
+	SessionEventSetLogged = "set_logged"
+	SessionEventRestTimer = "rest_timer"
+	SessionEventFinished  = "session_finished"
+)
+
+// DefaultRestSeconds is the rest timer length when the client does not ask
+// for one.
+const DefaultRestSeconds = 120
+
+type RestTimer struct {
+	StartedAt time.Time
+	Seconds   int
+}
+
+// SessionEvent is pushed to every client following a workout in progress.
+// Only the field matching Type is set.
+type SessionEvent struct {
+	Type      string
+	WorkoutID int64
+	At        time.Time
+	Workout   *Workout
+	Set       *WorkoutExercise
+	Rest      *RestTimer
+}
+
+type LogSetParams struct {
+	UserID      int64
+	Set         *WorkoutExercise
+	RestSeconds int
+}
+
+type FinishSessionParams struct {
+	UserID     int64
+	WorkoutID  int64
+	SessionRPE *float64
+}
@@ -0,0 +1,59 @@
+package model
+
+const (
+	SexMale   = "male"
+	SexFemale = "female"
+
+	LiftSquat    = "squat"
+	LiftBench    = "bench"
+	LiftDeadlift = "deadlift"
+	LiftOHP      = "ohp"
+)
+
+// StrengthStandard is the minimum one-rep max, as a multiple of bodyweight,
+// of a level. Custom levels are the ones a user set for themselves; they have
+// no sex.
+type StrengthStandard struct {
+	ExerciseID         int64
+	Sex                string
+	Level              string
+	Rank               int
+	BodyweightMultiple float64
+	Custom             bool
+}
+
+// ExerciseStandards is the ladder of levels an exercise's records are scored
+// against, ordered by rank.
+type ExerciseStandards struct {
+	ExerciseID int64
+	Custom     bool
+	Levels     []*StrengthStandard
+}
+
+// StrengthScore describes a personal record relative to the lifter's
+// bodyweight. Scores that cannot be computed, e.g. without a known sex or
+// bodyweight, are nil.
+type StrengthScore struct {
+	EstimatedOneRM     float64
+	BodyweightMultiple *float64
+	Wilks              *float64
+	DOTS               *float64
+	IPFGL              *float64
+	Level              string
+	NextLevel          string
+	NextLevelOneRM     *float64
+}
+
+type StrengthSummary struct {
+	Sex        string
+	Bodyweight *float64
+	Total      float64
+	Wilks      *float64
+	DOTS       *float64
+	IPFGL      *float64
+}
+
+type PersonalRecords struct {
+	Records []*UserRecord
+	Summary *StrengthSummary
+}
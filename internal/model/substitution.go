@@ -0,0 +1,49 @@
+package model
+
+// EquipmentBodyweight is the equipment of bodyweight exercises, available to
+// everyone whatever equipment they have.
+const EquipmentBodyweight = "bodyweight"
+
+// SubstitutesQuery looks up alternatives to an exercise among the ones the
+// user has the equipment for.
+type SubstitutesQuery struct {
+	UserID     int64
+	ExerciseID int64
+	Locale     string
+	Limit      uint64
+}
+
+// Substitute is an alternative to an exercise. SharedMuscles counts the
+// primary muscles both work and SamePattern tells whether they share the
+// movement pattern; Score ranks them from 0 to 1.
+type Substitute struct {
+	Exercise      *Exercise
+	SharedMuscles int
+	SamePattern   bool
+	Score         float64
+}
+
+// SwapExerciseParams replaces the exercise of a planned entry, a template
+// exercise or a program exercise of an enrollment, keeping its prescription.
+type SwapExerciseParams struct {
+	UserID     int64
+	ParentID   int64
+	EntryID    int64
+	ExerciseID int64
+}
+
+// ExerciseSubstitution replaces a program exercise with another one in the
+// sessions of an enrollment.
+type ExerciseSubstitution struct {
+	EnrollmentID      int64
+	ProgramExerciseID int64
+	ExerciseID        int64
+	Exercise          Exercise
+}
+
+// Substitutable tells whether the prescription of original can be carried
+// over to substitute: sets, reps and load only make sense within one type
+// and for exercises logged with the same metrics.
+func Substitutable(original, substitute *Exercise) bool {
+	return original.Type == substitute.Type && original.TrackingProfile == substitute.TrackingProfile
+}
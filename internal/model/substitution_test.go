@@ -0,0 +1,27 @@
+package model
+
+import "testing"
+
+func TestSubstitutable(t *testing.T) {
+	barbell := &Exercise{Type: "strength", TrackingProfile: TrackingWeightReps}
+
+	tests := []struct {
+		name       string
+		substitute *Exercise
+		want       bool
+	}{
+		{"same type and profile", &Exercise{Type: "strength", TrackingProfile: TrackingWeightReps}, true},
+		{"bodyweight reps", &Exercise{Type: "strength", TrackingProfile: TrackingReps}, false},
+		{"assisted", &Exercise{Type: "strength", TrackingProfile: TrackingAssisted}, false},
+		{"timed hold", &Exercise{Type: "strength", TrackingProfile: TrackingWeightTime}, false},
+		{"other type", &Exercise{Type: "cardio", TrackingProfile: TrackingWeightReps}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Substitutable(barbell, tt.substitute); got != tt.want {
+				t.Errorf("Substitutable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package model
+
+import "time"
+
+const (
+	SyncEntityWorkout = "workout"
+	SyncEntityEntry   = "workout_exercise"
+
+	SyncOpUpsert = "upsert"
+	SyncOpDelete = "delete"
+
+	// SyncStatusConflict means the server kept a version at least as recent
+	// as the change; it is returned with the result.
+	SyncStatusApplied  = "applied"
+	SyncStatusConflict = "conflict"
+	SyncStatusRejected = "rejected"
+)
+
+// SyncState is what the conflict resolution needs to know about a stored
+// workout or entry.
+type SyncState struct {
+	ID              int64
+	WorkoutID       int64
+	WorkoutUUID     string
+	ClientUpdatedAt time.Time
+}
+
+type SyncWorkout struct {
+	ClientUpdatedAt time.Time
+	Seq             int64
+	Workout         *Workout
+}
+
+// SyncEntry is an exercise entry of a workout, identified across devices by
+// its UUID and the UUID of its workout.
+type SyncEntry struct {
+	WorkoutUUID     string
+	ClientUpdatedAt time.Time
+	Seq             int64
+	Entry           *WorkoutExercise
+}
+
+type SyncTombstone struct {
+	Entity    string
+	UUID      string
+	DeletedAt time.Time
+	Seq       int64
+}
+
+// SyncChange is an edit made on a client, possibly while offline. Workout or
+// Entry is set for upserts depending on Entity.
+type SyncChange struct {
+	Entity          string
+	Op              string
+	UUID            string
+	ClientUpdatedAt time.Time
+	Workout         *SyncWorkout
+	Entry           *SyncEntry
+}
+
+type SyncResult struct {
+	Entity  string
+	UUID    string
+	Status  string
+	Error   string
+	Workout *SyncWorkout
+	Entry   *SyncEntry
+}
+
+type SyncRequest struct {
+	UserID  int64
+	Cursor  int64
+	Limit   uint64
+	Changes []*SyncChange
+}
+
+// SyncResponse holds the server changes after the request cursor. When
+// HasMore is set the client syncs again from Cursor to get the rest.
+type SyncResponse struct {
+	Results  []*SyncResult
+	Workouts []*SyncWorkout
+	Entries  []*SyncEntry
+	Deleted  []*SyncTombstone
+	Cursor   int64
+	HasMore  bool
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Tag struct {
+	ID        int64
+	UserID    int64
+	Name      string
+	Color     sql.NullString
+	CreatedAt time.Time
+}
+
+type UpdateTagParams struct {
+	ID     int64
+	UserID int64
+	Name   *string
+	Color  *string
+}
+
+// WorkoutTag is a tag attached to a workout.
+type WorkoutTag struct {
+	WorkoutID int64
+	Tag       *Tag
+}
+
+type SetWorkoutTagsParams struct {
+	UserID    int64
+	WorkoutID int64
+	TagIDs    []int64
+}
+
+// TagFilter narrows workouts down by their tags: a workout has to carry any
+// of Include, when set, and none of Exclude.
+type TagFilter struct {
+	Include []int64
+	Exclude []int64
+}
+
+// TagUsage is how much the user trained under a tag. Volume is weight times
+// reps over working sets.
+type TagUsage struct {
+	Tag       *Tag
+	Workouts  int
+	Sets      int
+	Volume    float64
+	FirstUsed sql.NullTime
+	LastUsed  sql.NullTime
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type WorkoutTemplate struct {
+	ID        int64
+	UserID    int64
+	Name      string
+	Notes     string
+	Exercises []*TemplateExercise
+	CreatedAt time.Time
+}
+
+// TemplateExercise is a planned exercise. Without a weight the load is picked
+// from the lifter's history when the template is instantiated.
+type TemplateExercise struct {
+	ID         int64
+	TemplateID int64
+	ExerciseID int64
+	Position   int
+	Sets       int
+	Reps       int
+	Weight     sql.NullFloat64
+	Notes      string
+	Exercise   Exercise
+}
+
+type InstantiateTemplateParams struct {
+	UserID     int64
+	TemplateID int64
+	Name       string
+	Date       time.Time
+}
+
+type PlannedExercise struct {
+	TemplateExercise *TemplateExercise
+	Suggestion       *ProgressionSuggestion
+}
+
+// TemplateWorkout is a workout created from a template with the load
+// suggested for each of its exercises.
+type TemplateWorkout struct {
+	WorkoutID int64
+	Exercises []*PlannedExercise
+}
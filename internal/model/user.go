@@ -19,16 +19,36 @@ type LoginUserParams struct {
 	Password string
 }
 
+// User is a registered user. IsAdmin lets the user curate the shared
+// exercise catalog; it is granted in the database only.
 type User struct {
 	ID   int64
 	Name string
 	//Age       int
 	Email     string
 	Password  string
+	Timezone  string
+	Sex       sql.NullString
+	Locale    sql.NullString
+	IsAdmin   bool
 	CreatedAt time.Time
 	UpdatedAt sql.NullTime
 }
 
+type UpdateProfileParams struct {
+	UserID   int64
+	Timezone *string
+	Sex      *string
+	Locale   *string
+}
+
+type BodyWeight struct {
+	ID         int64
+	UserID     int64
+	Weight     float64
+	MeasuredAt time.Time
+}
+
 type UserClaims struct {
 	jwt.StandardClaims
 	UserID int64
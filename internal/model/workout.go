@@ -5,14 +5,41 @@ import (
 	"time"
 )
 
+// Workout is a training session of UserID. AssignedBy is the coach who
+// planned it for the user.
 type Workout struct {
-	ID        int64
-	UserID    int64
-	Date      time.Time
-	Notes     string
-	Name      string
-	CreatedAt time.Time
-	UpdatedAt sql.NullTime
+	ID         int64
+	UUID       string
+	UserID     int64
+	Date       time.Time
+	Notes      string
+	Name       string
+	StartedAt  sql.NullTime
+	EndedAt    sql.NullTime
+	SessionRPE sql.NullFloat64
+	AssignedBy sql.NullInt64
+	CreatedAt  time.Time
+	UpdatedAt  sql.NullTime
+	Tags       []*Tag
+}
+
+// Duration returns the session length when both its start and end are known.
+func (w *Workout) Duration() (time.Duration, bool) {
+	if !w.StartedAt.Valid || !w.EndedAt.Valid {
+		return 0, false
+	}
+	return w.EndedAt.Time.Sub(w.StartedAt.Time), true
+}
+
+type UpdateWorkoutParams struct {
+	ID         int64
+	UserID     int64
+	Date       *time.Time
+	Name       *string
+	Notes      *string
+	StartedAt  *time.Time
+	EndedAt    *time.Time
+	SessionRPE *float64
 }
 
 type WorkoutSet struct {
@@ -21,15 +48,62 @@ type WorkoutSet struct {
 	ExerciseID int64
 }
 
+// WorkoutsFilter selects the user's workouts by their date. Pages go from the
+// newest workout back; After is the last workout of the previous page.
 type WorkoutsFilter struct {
-	StartDate time.Time
-	EndDate   time.Time
-	Offset    uint64
-	Limit     uint64
+	StartDate   time.Time
+	EndDate     time.Time
+	Limit       uint64
+	After       *WorkoutCursor
+	ExerciseID  int64
+	MuscleGroup string
+	Search      string
+	Tags        TagFilter
+}
+
+type WorkoutCursor struct {
+	Date time.Time
+	ID   int64
+}
+
+type WorkoutsPage struct {
+	Workouts []*Workout
+	Total    int64
+	Next     *WorkoutCursor
+}
+
+// ExercisesFilter pages the catalog by name; After is the last exercise of
+// the previous page.
+type ExercisesFilter struct {
+	Type            string
+	MuscleGroup     string
+	Equipment       string
+	PrimaryMuscle   string
+	SecondaryMuscle string
+	MovementPattern string
+	Mechanics       string
+	Unilateral      *bool
+	TrackingProfile string
+	// Locale is the language names are sorted and returned in.
+	Locale string
+	Limit  uint64
+	After  *ExerciseCursor
+}
+
+type ExerciseCursor struct {
+	Name string
+	ID   int64
+}
+
+type ExercisesPage struct {
+	Exercises []*Exercise
+	Total     int64
+	Next      *ExerciseCursor
 }
 
 type WorkoutExercise struct {
 	ID         int64
+	UUID       string
 	WorkoutID  int64
 	ExerciseID int64
 	Sets       int
@@ -37,7 +111,52 @@ type WorkoutExercise struct {
 	Weight     float64
 	Duration   int
 	Distance   float64
-	Exercise   Exercise
+	RPE        sql.NullFloat64
+	RIR        sql.NullInt32
+	TargetReps sql.NullInt32
+	IsWarmUp   bool
+	// Planned sets are the ones a coach planned and the athlete is yet to do;
+	// they count towards neither records nor analytics.
+	Planned bool
+	// Position orders exercises within the workout; a zero position on
+	// insert appends the exercise. Exercises sharing a GroupID are done
+	// back to back as a superset, giant set or circuit.
+	Position int
+	GroupID  sql.NullInt32
+	// GroupKind sets the kind of the group when the exercise is added.
+	GroupKind string
+	// CompletedAt and RestSeconds are recorded for sets logged live; rest is
+	// the time since the previous set of the session.
+	CompletedAt sql.NullTime
+	RestSeconds sql.NullInt32
+	// AvgHeartRate, MaxHeartRate and ElevationGain (meters) are only recorded
+	// for cardio.
+	AvgHeartRate  sql.NullInt32
+	MaxHeartRate  sql.NullInt32
+	ElevationGain sql.NullFloat64
+	Splits        []*Split
+	Exercise      Exercise
+	Cardio        *CardioMetrics
+}
+
+// Split is a lap or a fixed-distance split of a cardio exercise.
+type Split struct {
+	ID                int64
+	WorkoutExerciseID int64
+	Number            int
+	Distance          float64
+	Duration          int
+	AvgHeartRate      sql.NullInt32
+	ElevationGain     sql.NullFloat64
+	PaceSecondsPerKm  *float64
+}
+
+// CardioMetrics are derived from the logged distance and duration. Calories
+// are only estimated when the lifter's bodyweight is known.
+type CardioMetrics struct {
+	PaceSecondsPerKm *float64
+	SpeedKmh         *float64
+	Calories         *float64
 }
 
 type Exercise struct {
@@ -46,11 +165,56 @@ type Exercise struct {
 	Type        string
 	MuscleGroup string
 	Description string
+	Lift        sql.NullString
+	MET         sql.NullFloat64
+	// LoadIncrement is the weight added when progressing, in kg.
+	LoadIncrement    float64
+	TrackingProfile  string
+	Equipment        *Equipment
+	MovementPattern  sql.NullString
+	Mechanics        sql.NullString
+	Unilateral       bool
+	PrimaryMuscles   []*Muscle
+	SecondaryMuscles []*Muscle
 }
 
 type WorkoutExercises struct {
 	Workout   *Workout
 	Exercises []*WorkoutExercise
+	Blocks    []*ExerciseBlock
+}
+
+const (
+	ExerciseGroupSingle   = "single"
+	ExerciseGroupSuperset = "superset"
+	ExerciseGroupGiantSet = "giant_set"
+	ExerciseGroupCircuit  = "circuit"
+)
+
+type ExerciseGroup struct {
+	WorkoutID int64
+	GroupID   int32
+	Kind      string
+}
+
+// ExerciseBlock is an exercise done on its own or a group of exercises done
+// together, in workout order.
+type ExerciseBlock struct {
+	GroupID   sql.NullInt32
+	Kind      string
+	Exercises []*WorkoutExercise
+}
+
+type ExerciseOrder struct {
+	WorkoutExerciseID int64
+	GroupID           sql.NullInt32
+}
+
+type ReorderExercisesParams struct {
+	UserID    int64
+	WorkoutID int64
+	Order     []*ExerciseOrder
+	Groups    []*ExerciseGroup
 }
 
 type UserRecord struct {
@@ -59,7 +223,10 @@ type UserRecord struct {
 	ExerciseID int64
 	Weight     float64
 	Reps       int
+	Duration   int
+	Distance   float64
 	Date       time.Time
 	Notes      string
 	Exercise   Exercise
+	Strength   *StrengthScore
 }
@@ -0,0 +1,180 @@
+// Package plates works out how to load a barbell from a limited set of plates.
+package plates
+
+import (
+	"math"
+	"sort"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+// precision is the number of steps per unit weights are compared in, enough
+// for 1.25 kg and 2.5 lb change plates.
+const precision = 100
+
+// DefaultInventory is a typical commercial gym: five pairs of every plate.
+func DefaultInventory(unit string) *model.PlateInventory {
+	inv := &model.PlateInventory{Unit: unit}
+
+	var weights []float64
+	if unit == model.UnitLb {
+		inv.BarWeight = 45
+		weights = []float64{45, 35, 25, 10, 5, 2.5}
+	} else {
+		inv.Unit = model.UnitKg
+		inv.BarWeight = 20
+		weights = []float64{25, 20, 15, 10, 5, 2.5, 1.25}
+	}
+	for _, w := range weights {
+		inv.Plates = append(inv.Plates, &model.PlateCount{Weight: w, Count: 10})
+	}
+
+	return inv
+}
+
+// Convert converts weight between kg and lb.
+func Convert(weight float64, from, to string) float64 {
+	switch {
+	case from == to:
+		return weight
+	case from == model.UnitLb:
+		return weight * model.KgPerLb
+	default:
+		return weight / model.KgPerLb
+	}
+}
+
+func steps(weight float64) int {
+	return int(math.Round(weight * precision))
+}
+
+// Load finds the heaviest weight not above target that the inventory can put
+// on the bar symmetrically, using as few plates as possible.
+func Load(inv *model.PlateInventory, target, bar float64) *model.PlateLoading {
+	res := &model.PlateLoading{
+		Unit:      inv.Unit,
+		Target:    target,
+		BarWeight: bar,
+		Achieved:  bar,
+		PerSide:   []*model.PlateCount{},
+	}
+	side := steps((target - bar) / 2)
+	if side <= 0 {
+		res.Exact = side == 0
+		return res
+	}
+
+	plates := make([]*model.PlateCount, 0, len(inv.Plates))
+	for _, p := range inv.Plates {
+		if p.Weight > 0 && p.Count >= 2 {
+			plates = append(plates, p)
+		}
+	}
+	sort.Slice(plates, func(i, j int) bool { return plates[i].Weight > plates[j].Weight })
+
+	// bounded change making: fewest[a] is the fewest plates making a per side,
+	// used[a] the plate index added last
+	const unreachable = math.MaxInt32
+	fewest := make([]int, side+1)
+	used := make([][]int, side+1)
+	for a := 1; a <= side; a++ {
+		fewest[a] = unreachable
+	}
+	for i, p := range plates {
+		w, pairs := steps(p.Weight), p.Count/2
+		for k := 0; k < pairs; k++ {
+			for a := side; a >= w; a-- {
+				if fewest[a-w] == unreachable || fewest[a-w]+1 >= fewest[a] {
+					continue
+				}
+				fewest[a] = fewest[a-w] + 1
+				used[a] = append(append([]int(nil), used[a-w]...), i)
+			}
+		}
+	}
+
+	best := side
+	for best > 0 && fewest[best] == unreachable {
+		best--
+	}
+
+	// lifters load the heaviest plates first, so prefer that whenever it
+	// reaches the same weight
+	counts := greedy(plates, best)
+	if counts == nil {
+		counts = make(map[int]int)
+		for _, i := range used[best] {
+			counts[i]++
+		}
+	}
+	for i, p := range plates {
+		if counts[i] > 0 {
+			res.PerSide = append(res.PerSide, &model.PlateCount{Weight: p.Weight, Count: counts[i]})
+		}
+	}
+
+	res.Achieved = bar + 2*float64(best)/precision
+	res.Exact = best == side
+
+	return res
+}
+
+// greedy loads the heaviest plates first and returns the plates used per side,
+// or nil when that does not add up to side.
+func greedy(plates []*model.PlateCount, side int) map[int]int {
+	counts := make(map[int]int)
+	for i, p := range plates {
+		w := steps(p.Weight)
+		for n := 0; n < p.Count/2 && side >= w; n++ {
+			side -= w
+			counts[i]++
+		}
+	}
+	if side != 0 {
+		return nil
+	}
+	return counts
+}
+
+type warmUpStep struct {
+	percent float64
+	reps    int
+}
+
+// warmUpRamp climbs from the empty bar to the working weight in shrinking
+// sets so the lifter is not tired before the first work set.
+var warmUpRamp = []warmUpStep{
+	{0, 10},
+	{40, 5},
+	{60, 3},
+	{80, 2},
+	{90, 1},
+}
+
+// WarmUp builds the ramp of warm-up sets for a working weight. Sets that
+// would not be heavier than the one before, or not lighter than the working
+// weight, are left out; the 90% single only pays off for heavy work sets.
+func WarmUp(inv *model.PlateInventory, working, bar float64) []*model.WarmUpSet {
+	var (
+		sets []*model.WarmUpSet
+		prev float64
+	)
+	heavy := Convert(working, inv.Unit, model.UnitKg) >= 100
+
+	for _, step := range warmUpRamp {
+		if step.percent == 90 && !heavy {
+			continue
+		}
+
+		target := math.Max(working*step.percent/100, bar)
+		loading := Load(inv, target, bar)
+		if loading.Achieved >= working || (len(sets) > 0 && loading.Achieved <= prev) {
+			continue
+		}
+
+		sets = append(sets, &model.WarmUpSet{Percent: step.percent, Reps: step.reps, Loading: loading})
+		prev = loading.Achieved
+	}
+
+	return sets
+}
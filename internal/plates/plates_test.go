@@ -0,0 +1,228 @@
+package plates
+
+import (
+	"math"
+	"testing"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+type plateCounts map[float64]int
+
+func inventory(unit string, counts plateCounts) *model.PlateInventory {
+	inv := &model.PlateInventory{Unit: unit}
+	for w, n := range counts {
+		inv.Plates = append(inv.Plates, &model.PlateCount{Weight: w, Count: n})
+	}
+	return inv
+}
+
+func perSide(l *model.PlateLoading) plateCounts {
+	res := make(plateCounts, len(l.PerSide))
+	for _, p := range l.PerSide {
+		res[p.Weight] = p.Count
+	}
+	return res
+}
+
+func equalCounts(a, b plateCounts) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for w, n := range a {
+		if b[w] != n {
+			return false
+		}
+	}
+	return true
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		weight   float64
+		from, to string
+		want     float64
+	}{
+		{100, model.UnitKg, model.UnitKg, 100},
+		{45, model.UnitLb, model.UnitLb, 45},
+		{1, model.UnitLb, model.UnitKg, model.KgPerLb},
+		{model.KgPerLb, model.UnitKg, model.UnitLb, 1},
+	}
+
+	for _, tt := range tests {
+		if got := Convert(tt.weight, tt.from, tt.to); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Convert(%v, %s, %s) = %v, want %v", tt.weight, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name     string
+		inv      *model.PlateInventory
+		target   float64
+		bar      float64
+		achieved float64
+		exact    bool
+		perSide  plateCounts
+	}{
+		{
+			name:     "empty bar",
+			inv:      DefaultInventory(model.UnitKg),
+			target:   20,
+			bar:      20,
+			achieved: 20,
+			exact:    true,
+			perSide:  plateCounts{},
+		},
+		{
+			name:     "below the bar",
+			inv:      DefaultInventory(model.UnitKg),
+			target:   15,
+			bar:      20,
+			achieved: 20,
+			perSide:  plateCounts{},
+		},
+		{
+			name:     "heaviest plates first",
+			inv:      DefaultInventory(model.UnitKg),
+			target:   100,
+			bar:      20,
+			achieved: 100,
+			exact:    true,
+			perSide:  plateCounts{25: 1, 15: 1},
+		},
+		{
+			name:     "change plates",
+			inv:      DefaultInventory(model.UnitKg),
+			target:   62.5,
+			bar:      20,
+			achieved: 62.5,
+			exact:    true,
+			perSide:  plateCounts{20: 1, 1.25: 1},
+		},
+		{
+			name:     "unreachable target rounds down",
+			inv:      DefaultInventory(model.UnitKg),
+			target:   101,
+			bar:      20,
+			achieved: 100,
+			perSide:  plateCounts{25: 1, 15: 1},
+		},
+		{
+			name:     "fewest plates when heaviest first fails",
+			inv:      inventory(model.UnitKg, plateCounts{20: 2, 15: 4}),
+			target:   80,
+			bar:      20,
+			achieved: 80,
+			exact:    true,
+			perSide:  plateCounts{15: 2},
+		},
+		{
+			name:     "single plates cannot be loaded",
+			inv:      inventory(model.UnitKg, plateCounts{25: 1, 10: 2}),
+			target:   70,
+			bar:      20,
+			achieved: 40,
+			perSide:  plateCounts{10: 1},
+		},
+		{
+			name:     "limited pairs",
+			inv:      inventory(model.UnitKg, plateCounts{20: 4}),
+			target:   140,
+			bar:      20,
+			achieved: 100,
+			perSide:  plateCounts{20: 2},
+		},
+		{
+			name:     "pounds",
+			inv:      DefaultInventory(model.UnitLb),
+			target:   225,
+			bar:      45,
+			achieved: 225,
+			exact:    true,
+			perSide:  plateCounts{45: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Load(tt.inv, tt.target, tt.bar)
+
+			if got.Unit != tt.inv.Unit || got.Target != tt.target || got.BarWeight != tt.bar {
+				t.Errorf("loading echoes %s %v/%v, want %s %v/%v", got.Unit, got.Target, got.BarWeight, tt.inv.Unit, tt.target, tt.bar)
+			}
+			if got.Achieved != tt.achieved || got.Exact != tt.exact {
+				t.Errorf("achieved = %v (exact %v), want %v (exact %v)", got.Achieved, got.Exact, tt.achieved, tt.exact)
+			}
+			if pc := perSide(got); !equalCounts(pc, tt.perSide) {
+				t.Errorf("per side = %v, want %v", pc, tt.perSide)
+			}
+		})
+	}
+}
+
+func TestWarmUp(t *testing.T) {
+	type set struct {
+		percent  float64
+		reps     int
+		achieved float64
+	}
+
+	tests := []struct {
+		name    string
+		inv     *model.PlateInventory
+		working float64
+		bar     float64
+		want    []set
+	}{
+		{
+			name:    "heavy work set gets a single",
+			inv:     DefaultInventory(model.UnitKg),
+			working: 100,
+			bar:     20,
+			want:    []set{{0, 10, 20}, {40, 5, 40}, {60, 3, 60}, {80, 2, 80}, {90, 1, 90}},
+		},
+		{
+			name:    "light work set",
+			inv:     DefaultInventory(model.UnitKg),
+			working: 60,
+			bar:     20,
+			want:    []set{{0, 10, 20}, {40, 5, 22.5}, {60, 3, 35}, {80, 2, 47.5}},
+		},
+		{
+			name:    "sets no heavier than the last are skipped",
+			inv:     DefaultInventory(model.UnitKg),
+			working: 30,
+			bar:     20,
+			want:    []set{{0, 10, 20}, {80, 2, 22.5}},
+		},
+		{
+			name:    "empty bar work set",
+			inv:     DefaultInventory(model.UnitKg),
+			working: 20,
+			bar:     20,
+		},
+		{
+			name:    "heavy in pounds",
+			inv:     DefaultInventory(model.UnitLb),
+			working: 225,
+			bar:     45,
+			want:    []set{{0, 10, 45}, {40, 5, 90}, {60, 3, 135}, {80, 2, 180}, {90, 1, 200}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WarmUp(tt.inv, tt.working, tt.bar)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d sets, want %d", len(got), len(tt.want))
+			}
+			for i, s := range got {
+				if g := (set{s.Percent, s.Reps, s.Loading.Achieved}); g != tt.want[i] {
+					t.Errorf("set %d = %+v, want %+v", i, g, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/client/db"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+)
+
+var _ repository.AnalyticsRepository = (*repo)(nil)
+
+// Reps count toward volume for the tracking profiles logged with reps, and
+// tonnage only for weight and reps: a timed hold has no reps and assisted
+// sets carry a negative weight.
+const (
+	repsVolume    = "CASE WHEN e.tracking_profile IN ('weight_reps', 'reps', 'assisted') THEN we.sets * we.reps ELSE 0 END"
+	tonnageVolume = "CASE WHEN e.tracking_profile = 'weight_reps' THEN we.sets * we.reps * we.weight ELSE 0 END"
+)
+
+// performedWorkout leaves out the workouts a coach assigned that the athlete
+// has neither started nor logged a set in: they are only a plan yet.
+const performedWorkout = "(w.assigned_by IS NULL OR w.started_at IS NOT NULL OR " +
+	"EXISTS (SELECT 1 FROM workout_exercises pe WHERE pe.workout_id = w.id AND NOT pe.is_planned))"
+
+type repo struct {
+	db db.Client
+	qb squirrel.StatementBuilderType
+}
+
+func NewRepository(db db.Client) *repo {
+	return &repo{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *repo) GetWeeklyMuscleVolume(ctx context.Context, userID int64, filter *model.MuscleVolumeFilter) ([]*model.MuscleGroupVolume, error) {
+	// every logged exercise is credited in full to its primary muscle group and,
+	// when requested, partially to each of its secondary muscle groups
+	muscles := "SELECT id AS exercise_id, muscle_group, 1.0::numeric AS factor FROM exercises WHERE type = 'strength' AND muscle_group IS NOT NULL"
+	var muscleArgs []interface{}
+	if filter.SecondaryWeight > 0 {
+		muscles += " UNION ALL SELECT DISTINCT em.exercise_id, m.muscle_group, ?::numeric FROM exercise_muscles em" +
+			" JOIN muscles m ON m.id = em.muscle_id JOIN exercises e ON e.id = em.exercise_id" +
+			" WHERE NOT em.is_primary AND m.muscle_group IS DISTINCT FROM e.muscle_group"
+		muscleArgs = append(muscleArgs, filter.SecondaryWeight)
+	}
+
+	builder := r.qb.
+		Select(
+			"date_trunc('week', w.date) AS week",
+			"m.muscle_group",
+			"COALESCE(SUM(we.sets * m.factor), 0)::float8",
+			"COALESCE(SUM(("+repsVolume+") * m.factor), 0)::float8",
+			"COALESCE(SUM(("+tonnageVolume+") * m.factor), 0)::float8",
+		).
+		From("workout_exercises we").
+		Join("workouts w ON w.id = we.workout_id").
+		Join("exercises e ON e.id = we.exercise_id").
+		Join("("+muscles+") m ON m.exercise_id = we.exercise_id", muscleArgs...).
+		Where(squirrel.Eq{"w.user_id": userID, "we.is_warmup": false, "we.is_planned": false}).
+		Where(squirrel.GtOrEq{"w.date": filter.StartDate}).
+		Where(squirrel.Lt{"w.date": filter.EndDate}).
+		GroupBy("week", "m.muscle_group").
+		OrderBy("week", "m.muscle_group")
+
+	query, args, err := repository.ApplyTagFilter(builder, "w.id", filter.Tags).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get muscle volume: %w", err)
+	}
+	defer rows.Close()
+
+	var volumes []*model.MuscleGroupVolume
+	for rows.Next() {
+		var volume model.MuscleGroupVolume
+		err = rows.Scan(
+			&volume.WeekStart,
+			&volume.MuscleGroup,
+			&volume.Sets,
+			&volume.Reps,
+			&volume.Tonnage,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan muscle volume: %w", err)
+		}
+		volumes = append(volumes, &volume)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate muscle volume: %w", err)
+	}
+
+	return volumes, nil
+}
+
+func (r *repo) ListMuscleGroups(ctx context.Context) ([]string, error) {
+	query, args, err := r.qb.
+		Select("DISTINCT muscle_group").
+		From("exercises").
+		Where(squirrel.Eq{"type": "strength"}).
+		Where(squirrel.NotEq{"muscle_group": nil}).
+		OrderBy("muscle_group").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list muscle groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []string
+	for rows.Next() {
+		var group string
+		if err = rows.Scan(&group); err != nil {
+			return nil, fmt.Errorf("failed to scan muscle group: %w", err)
+		}
+		groups = append(groups, group)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate muscle groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// GetCalendarDays groups the user's workouts by local calendar day, leaving
+// out the sets and the assigned workouts that are only planned. The volume
+// counts working sets only, like the muscle group volume, so warm-ups add to
+// neither it nor the volume training load. Zero start or end leaves that side
+// of the range open.
+func (r *repo) GetCalendarDays(ctx context.Context, userID int64, timezone string, start, end time.Time, tags model.TagFilter) ([]*model.CalendarDay, error) {
+	volume := squirrel.
+		Select("we.workout_id", "SUM("+tonnageVolume+") AS volume").
+		From("workout_exercises we").
+		Join("exercises e ON e.id = we.exercise_id").
+		Where(squirrel.Eq{"we.is_warmup": false, "we.is_planned": false}).
+		GroupBy("we.workout_id")
+	volumeSql, volumeArgs, err := volume.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build volume subquery: %w", err)
+	}
+
+	builder := r.qb.
+		Select().
+		Column(squirrel.Expr("((w.date AT TIME ZONE 'UTC') AT TIME ZONE ?)::date AS day", timezone)).
+		Columns("COUNT(*)", "COALESCE(SUM(v.volume), 0)::float8").
+		From("workouts w").
+		LeftJoin("("+volumeSql+") v ON v.workout_id = w.id", volumeArgs...).
+		Where(squirrel.Eq{"w.user_id": userID}).
+		Where(performedWorkout).
+		GroupBy("day").
+		OrderBy("day")
+
+	if !start.IsZero() {
+		builder = builder.Where(squirrel.GtOrEq{"w.date": start.UTC()})
+	}
+
+	if !end.IsZero() {
+		builder = builder.Where(squirrel.Lt{"w.date": end.UTC()})
+	}
+
+	query, args, err := repository.ApplyTagFilter(builder, "w.id", tags).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar: %w", err)
+	}
+	defer rows.Close()
+
+	var days []*model.CalendarDay
+	for rows.Next() {
+		var day model.CalendarDay
+		if err = rows.Scan(&day.Date, &day.Workouts, &day.Volume); err != nil {
+			return nil, fmt.Errorf("failed to scan calendar day: %w", err)
+		}
+		days = append(days, &day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate calendar: %w", err)
+	}
+
+	return days, nil
+}
+
+// GetAverageSessionDuration averages the recorded start to end time of
+// sessions. Sessions without both timestamps are estimated as the time between
+// the first and the last exercise logged in them.
+func (r *repo) GetAverageSessionDuration(ctx context.Context, userID int64, tags model.TagFilter) (time.Duration, error) {
+	spans := squirrel.
+		Select("COALESCE(w.ended_at - w.started_at, MAX(we.created_at) - MIN(we.created_at)) AS span").
+		From("workouts w").
+		LeftJoin("workout_exercises we ON we.workout_id = w.id AND NOT we.is_planned").
+		Where(squirrel.Eq{"w.user_id": userID}).
+		Where(performedWorkout).
+		GroupBy("w.id").
+		Having("(w.started_at IS NOT NULL AND w.ended_at IS NOT NULL) OR COUNT(we.id) > 1")
+
+	query, args, err := r.qb.
+		Select("COALESCE(EXTRACT(EPOCH FROM AVG(s.span)), 0)::float8").
+		FromSelect(repository.ApplyTagFilter(spans, "w.id", tags), "s").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var seconds float64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&seconds)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get average session duration: %w", err)
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// GetDailySessionLoad sums Foster's session load, session RPE times duration
+// in minutes, per local calendar day. Sessions without an RPE or without both
+// start and end times carry no load.
+func (r *repo) GetDailySessionLoad(ctx context.Context, userID int64, timezone string, start, end time.Time, tags model.TagFilter) ([]*model.DailyLoad, error) {
+	builder := r.qb.
+		Select().
+		Column(squirrel.Expr("((date AT TIME ZONE 'UTC') AT TIME ZONE ?)::date AS day", timezone)).
+		Columns("SUM(session_rpe * EXTRACT(EPOCH FROM ended_at - started_at) / 60)::float8").
+		From("workouts").
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.NotEq{"session_rpe": nil, "started_at": nil, "ended_at": nil}).
+		Where(squirrel.GtOrEq{"date": start.UTC()}).
+		Where(squirrel.Lt{"date": end.UTC()}).
+		GroupBy("day").
+		OrderBy("day")
+
+	query, args, err := repository.ApplyTagFilter(builder, "workouts.id", tags).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session load: %w", err)
+	}
+	defer rows.Close()
+
+	var loads []*model.DailyLoad
+	for rows.Next() {
+		var load model.DailyLoad
+		if err = rows.Scan(&load.Date, &load.Load); err != nil {
+			return nil, fmt.Errorf("failed to scan session load: %w", err)
+		}
+		loads = append(loads, &load)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate session load: %w", err)
+	}
+
+	return loads, nil
+}
+
+func (r *repo) GetTopExercises(ctx context.Context, userID int64, limit uint64, tags model.TagFilter) ([]*model.ExerciseUsage, error) {
+	builder := r.qb.
+		Select("e.id", "e.name", "COUNT(DISTINCT we.workout_id) AS workouts", "COALESCE(SUM(we.sets), 0) AS sets").
+		From("workout_exercises we").
+		Join("workouts w ON w.id = we.workout_id").
+		Join("exercises e ON e.id = we.exercise_id").
+		Where(squirrel.Eq{"w.user_id": userID, "we.is_planned": false}).
+		GroupBy("e.id", "e.name").
+		OrderBy("workouts DESC", "sets DESC", "e.name").
+		Limit(limit)
+
+	query, args, err := repository.ApplyTagFilter(builder, "w.id", tags).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top exercises: %w", err)
+	}
+	defer rows.Close()
+
+	var exercises []*model.ExerciseUsage
+	for rows.Next() {
+		var exercise model.ExerciseUsage
+		if err = rows.Scan(&exercise.ExerciseID, &exercise.Name, &exercise.Workouts, &exercise.Sets); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise usage: %w", err)
+		}
+		exercises = append(exercises, &exercise)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exercise usage: %w", err)
+	}
+
+	return exercises, nil
+}
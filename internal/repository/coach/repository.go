@@ -0,0 +1,271 @@
+package coach
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+var _ repository.CoachRepository = (*repo)(nil)
+
+type repo struct {
+	db db.Client
+	qb squirrel.StatementBuilderType
+}
+
+func NewRepository(db db.Client) *repo {
+	return &repo{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// invitedEmail is the email of the user in ?, lowercased as invitations
+// store it.
+const invitedEmail = "(SELECT lower(email) FROM users WHERE id = ?)"
+
+// addressedTo keeps the relationships of the athlete, accepted or pending
+// for the athlete's email.
+func addressedTo(athleteID int64) squirrel.Sqlizer {
+	return squirrel.Or{
+		squirrel.Eq{"ca.athlete_id": athleteID},
+		squirrel.And{
+			squirrel.Eq{"ca.status": model.CoachingPending},
+			squirrel.Expr("ca.email = "+invitedEmail, athleteID),
+		},
+	}
+}
+
+// CreateCoaching stores a pending invitation to coaching.Email.
+func (r *repo) CreateCoaching(ctx context.Context, coaching *model.Coaching) (int64, error) {
+	query, args, err := r.qb.
+		Insert("coach_athletes").
+		Columns("coach_id", "email", "permission").
+		Values(coaching.CoachID, coaching.Email, coaching.Permission).
+		Suffix("RETURNING id, status, created_at").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id, &coaching.Status, &coaching.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, apperrors.ErrCoachingExists
+		}
+		return 0, fmt.Errorf("failed to insert coaching: %w", err)
+	}
+
+	return id, nil
+}
+
+// selectCoachings joins the coach and the athlete of each relationship; a
+// pending one has no athlete yet.
+func (r *repo) selectCoachings() squirrel.SelectBuilder {
+	return r.qb.
+		Select("ca.id", "ca.coach_id", "ca.athlete_id", "ca.email", "ca.permission", "ca.status", "ca.created_at", "ca.accepted_at",
+			"c.name", "c.email", "a.name", "a.email").
+		From("coach_athletes ca").
+		Join("users c ON c.id = ca.coach_id").
+		LeftJoin("users a ON a.id = ca.athlete_id")
+}
+
+func (r *repo) GetCoaching(ctx context.Context, coachID, athleteID int64) (*model.Coaching, error) {
+	query, args, err := r.selectCoachings().
+		Where(squirrel.Eq{"ca.coach_id": coachID, "ca.athlete_id": athleteID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	coaching, err := scanCoaching(r.db.DB().QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrCoachingNotFound
+		}
+		return nil, fmt.Errorf("failed to get coaching: %w", err)
+	}
+
+	return coaching, nil
+}
+
+func (r *repo) ListAthletes(ctx context.Context, coachID int64) ([]*model.Coaching, error) {
+	return r.listCoachings(ctx, squirrel.Eq{"ca.coach_id": coachID}, "coalesce(lower(a.name), ca.email)")
+}
+
+// ListCoaches lists the coaches of the athlete and the invitations to the
+// athlete's email.
+func (r *repo) ListCoaches(ctx context.Context, athleteID int64) ([]*model.Coaching, error) {
+	return r.listCoachings(ctx, addressedTo(athleteID), "lower(c.name)")
+}
+
+func (r *repo) listCoachings(ctx context.Context, where squirrel.Sqlizer, orderBy string) ([]*model.Coaching, error) {
+	query, args, err := r.selectCoachings().
+		Where(where).
+		OrderBy(orderBy, "ca.id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list coachings: %w", err)
+	}
+	defer rows.Close()
+
+	var coachings []*model.Coaching
+	for rows.Next() {
+		coaching, err := scanCoaching(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan coaching: %w", err)
+		}
+		coachings = append(coachings, coaching)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate coachings: %w", err)
+	}
+
+	return coachings, nil
+}
+
+// AcceptCoaching activates the coach's invitation to the athlete's email.
+func (r *repo) AcceptCoaching(ctx context.Context, coachID, athleteID int64) error {
+	query, args, err := r.qb.
+		Update("coach_athletes").
+		Set("athlete_id", athleteID).
+		Set("status", model.CoachingActive).
+		Set("accepted_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"coach_id": coachID, "status": model.CoachingPending}).
+		Where("email = "+invitedEmail, athleteID).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return apperrors.ErrCoachingExists
+		}
+		return fmt.Errorf("failed to accept coaching: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrCoachingNotFound
+	}
+
+	return nil
+}
+
+func (r *repo) UpdatePermission(ctx context.Context, params *model.UpdateCoachingParams) error {
+	query, args, err := r.qb.
+		Update("coach_athletes").
+		Set("permission", params.Permission).
+		Where(squirrel.Eq{"coach_id": params.CoachID, "athlete_id": params.AthleteID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update coaching permission: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrCoachingNotFound
+	}
+
+	return nil
+}
+
+// DeclineCoaching ends the coaching of the athlete or declines the coach's
+// invitation to the athlete's email.
+func (r *repo) DeclineCoaching(ctx context.Context, coachID, athleteID int64) error {
+	query, args, err := r.qb.
+		Delete("coach_athletes ca").
+		Where(squirrel.Eq{"ca.coach_id": coachID}).
+		Where(addressedTo(athleteID)).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to decline coaching: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrCoachingNotFound
+	}
+
+	return nil
+}
+
+// DeleteInvitation withdraws the coach's pending invitation.
+func (r *repo) DeleteInvitation(ctx context.Context, coachID, coachingID int64) error {
+	query, args, err := r.qb.
+		Delete("coach_athletes").
+		Where(squirrel.Eq{"id": coachingID, "coach_id": coachID, "status": model.CoachingPending}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete invitation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrCoachingNotFound
+	}
+
+	return nil
+}
+
+// DeleteCoaching stops coaching the athlete.
+func (r *repo) DeleteCoaching(ctx context.Context, coachID, athleteID int64) error {
+	query, args, err := r.qb.
+		Delete("coach_athletes").
+		Where(squirrel.Eq{"coach_id": coachID, "athlete_id": athleteID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete coaching: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrCoachingNotFound
+	}
+
+	return nil
+}
+
+func scanCoaching(row pgx.Row) (*model.Coaching, error) {
+	var (
+		c                         = model.Coaching{Coach: &model.User{}, Athlete: &model.User{}}
+		athleteID                 sql.NullInt64
+		athleteName, athleteEmail sql.NullString
+	)
+	err := row.Scan(&c.ID, &c.CoachID, &athleteID, &c.Email, &c.Permission, &c.Status, &c.CreatedAt, &c.AcceptedAt,
+		&c.Coach.Name, &c.Coach.Email, &athleteName, &athleteEmail)
+	if err != nil {
+		return nil, err
+	}
+	c.AthleteID = athleteID.Int64
+	c.Coach.ID = c.CoachID
+	c.Athlete.ID = c.AthleteID
+	c.Athlete.Name = athleteName.String
+	c.Athlete.Email = c.Email
+	if athleteEmail.Valid {
+		c.Athlete.Email = athleteEmail.String
+	}
+
+	return &c, nil
+}
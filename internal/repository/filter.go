@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+// ApplyTagFilter narrows a query over workouts down by their tags; workoutID
+// is the column holding the workout id in the query.
+func ApplyTagFilter(builder squirrel.SelectBuilder, workoutID string, filter model.TagFilter) squirrel.SelectBuilder {
+	if len(filter.Include) > 0 {
+		builder = builder.Where("EXISTS (SELECT 1 FROM workout_tags wt WHERE wt.workout_id = "+workoutID+" AND wt.tag_id = ANY(?))", filter.Include)
+	}
+	if len(filter.Exclude) > 0 {
+		builder = builder.Where("NOT EXISTS (SELECT 1 FROM workout_tags wt WHERE wt.workout_id = "+workoutID+" AND wt.tag_id = ANY(?))", filter.Exclude)
+	}
+	return builder
+}
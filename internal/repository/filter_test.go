@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func TestApplyTagFilter(t *testing.T) {
+	const (
+		base    = "SELECT w.id FROM workouts w WHERE w.user_id = ?"
+		include = "EXISTS (SELECT 1 FROM workout_tags wt WHERE wt.workout_id = w.id AND wt.tag_id = ANY(?))"
+		exclude = "NOT EXISTS (SELECT 1 FROM workout_tags wt WHERE wt.workout_id = w.id AND wt.tag_id = ANY(?))"
+	)
+
+	tests := []struct {
+		name   string
+		filter model.TagFilter
+		sql    string
+		args   []interface{}
+	}{
+		{"no filter", model.TagFilter{}, base, []interface{}{int64(1)}},
+		{"include", model.TagFilter{Include: []int64{2, 3}}, base + " AND " + include, []interface{}{int64(1), []int64{2, 3}}},
+		{"exclude", model.TagFilter{Exclude: []int64{4}}, base + " AND " + exclude, []interface{}{int64(1), []int64{4}}},
+		{
+			name:   "include and exclude",
+			filter: model.TagFilter{Include: []int64{2}, Exclude: []int64{4}},
+			sql:    base + " AND " + include + " AND " + exclude,
+			args:   []interface{}{int64(1), []int64{2}, []int64{4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := squirrel.Select("w.id").From("workouts w").Where(squirrel.Eq{"w.user_id": int64(1)})
+			sql, args, err := ApplyTagFilter(builder, "w.id", tt.filter).ToSql()
+			if err != nil {
+				t.Fatalf("ToSql() error = %v", err)
+			}
+			if sql != tt.sql || !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("query = %s %v, want %s %v", sql, args, tt.sql, tt.args)
+			}
+		})
+	}
+}
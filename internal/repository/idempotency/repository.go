@@ -0,0 +1,141 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+var _ repository.IdempotencyRepository = (*repo)(nil)
+
+type repo struct {
+	db db.Client
+	qb squirrel.StatementBuilderType
+}
+
+func NewRepository(db db.Client) *repo {
+	return &repo{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Reserve claims the key for a new request. It reports false when the key
+// is already held by a request that has not expired; an expired one is taken
+// over.
+func (r *repo) Reserve(ctx context.Context, rec *model.IdempotencyRecord) (bool, error) {
+	query, args, err := r.qb.
+		Insert("idempotency_keys").
+		Columns("user_id", "key", "request_hash", "expires_at").
+		Values(rec.UserID, rec.Key, rec.RequestHash, rec.ExpiresAt).
+		Suffix(`ON CONFLICT (user_id, key) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			status_code = NULL,
+			content_type = NULL,
+			response_body = NULL,
+			created_at = now(),
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= now()
+		RETURNING user_id`).ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var userID int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *repo) Get(ctx context.Context, userID int64, key string) (*model.IdempotencyRecord, error) {
+	query, args, err := r.qb.
+		Select("user_id", "key", "request_hash", "status_code", "COALESCE(content_type, '')", "response_body", "created_at", "expires_at").
+		From("idempotency_keys").
+		Where(squirrel.Eq{"user_id": userID, "key": key}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var rec model.IdempotencyRecord
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(
+		&rec.UserID,
+		&rec.Key,
+		&rec.RequestHash,
+		&rec.StatusCode,
+		&rec.ContentType,
+		&rec.ResponseBody,
+		&rec.CreatedAt,
+		&rec.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrIdempotencyMissing
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return &rec, nil
+}
+
+func (r *repo) Complete(ctx context.Context, rec *model.IdempotencyRecord) error {
+	query, args, err := r.qb.
+		Update("idempotency_keys").
+		Set("status_code", rec.StatusCode).
+		Set("content_type", rec.ContentType).
+		Set("response_body", rec.ResponseBody).
+		Set("expires_at", rec.ExpiresAt).
+		Where(squirrel.Eq{"user_id": rec.UserID, "key": rec.Key}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) Delete(ctx context.Context, userID int64, key string) error {
+	query, args, err := r.qb.
+		Delete("idempotency_keys").
+		Where(squirrel.Eq{"user_id": userID, "key": key}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) DeleteExpired(ctx context.Context) (int64, error) {
+	query, args, err := r.qb.
+		Delete("idempotency_keys").
+		Where(squirrel.Expr("expires_at <= now()")).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
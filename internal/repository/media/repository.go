@@ -0,0 +1,132 @@
+package media
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+var _ repository.MediaRepository = (*repo)(nil)
+
+type repo struct {
+	db db.Client
+	qb squirrel.StatementBuilderType
+}
+
+func NewRepository(db db.Client) *repo {
+	return &repo{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *repo) AddMedia(ctx context.Context, m *model.ExerciseMedia) (int64, error) {
+	query, args, err := r.qb.
+		Insert("exercise_media").
+		Columns("exercise_id", "kind", "storage_key", "content_type", "size", "uploaded_by").
+		Values(m.ExerciseID, m.Kind, m.StorageKey, m.ContentType, m.Size, m.UploadedBy).
+		Suffix("RETURNING id, created_at").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id, &m.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert media: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repo) GetMedia(ctx context.Context, mediaID int64) (*model.ExerciseMedia, error) {
+	query, args, err := r.qb.
+		Select("id", "exercise_id", "kind", "storage_key", "content_type", "size", "uploaded_by", "created_at").
+		From("exercise_media").
+		Where(squirrel.Eq{"id": mediaID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	m, err := scanMedia(r.db.DB().QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrMediaNotFound
+		}
+		return nil, fmt.Errorf("failed to get media: %w", err)
+	}
+
+	return m, nil
+}
+
+func (r *repo) ListMedia(ctx context.Context, exerciseID int64) ([]*model.ExerciseMedia, error) {
+	query, args, err := r.qb.
+		Select("id", "exercise_id", "kind", "storage_key", "content_type", "size", "uploaded_by", "created_at").
+		From("exercise_media").
+		Where(squirrel.Eq{"exercise_id": exerciseID}).
+		OrderBy("id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list media: %w", err)
+	}
+	defer rows.Close()
+
+	var media []*model.ExerciseMedia
+	for rows.Next() {
+		m, err := scanMedia(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan media: %w", err)
+		}
+		media = append(media, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate media: %w", err)
+	}
+
+	return media, nil
+}
+
+func (r *repo) DeleteMedia(ctx context.Context, mediaID int64) error {
+	query, args, err := r.qb.
+		Delete("exercise_media").
+		Where(squirrel.Eq{"id": mediaID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete media: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrMediaNotFound
+	}
+
+	return nil
+}
+
+func scanMedia(row pgx.Row) (*model.ExerciseMedia, error) {
+	var (
+		m          model.ExerciseMedia
+		uploadedBy sql.NullInt64
+	)
+	err := row.Scan(&m.ID, &m.ExerciseID, &m.Kind, &m.StorageKey, &m.ContentType, &m.Size, &uploadedBy, &m.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	m.UploadedBy = uploadedBy.Int64
+
+	return &m, nil
+}
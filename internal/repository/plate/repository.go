@@ -0,0 +1,122 @@
+package plate
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+var _ repository.PlateRepository = (*repo)(nil)
+
+type repo struct {
+	db db.Client
+	qb squirrel.StatementBuilderType
+}
+
+func NewRepository(db db.Client) *repo {
+	return &repo{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *repo) GetInventory(ctx context.Context, userID int64) (*model.PlateInventory, error) {
+	query, args, err := r.qb.
+		Select("user_id", "unit", "bar_weight").
+		From("plate_inventories").
+		Where(squirrel.Eq{"user_id": userID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var inv model.PlateInventory
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&inv.UserID, &inv.Unit, &inv.BarWeight)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrPlatesNotFound
+		}
+		return nil, fmt.Errorf("failed to get plate inventory: %w", err)
+	}
+
+	query, args, err = r.qb.
+		Select("weight", "count").
+		From("plate_inventory_items").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("weight DESC").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plates: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p model.PlateCount
+		if err = rows.Scan(&p.Weight, &p.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan plate: %w", err)
+		}
+		inv.Plates = append(inv.Plates, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate plates: %w", err)
+	}
+
+	return &inv, nil
+}
+
+// SaveInventory replaces the user's inventory.
+func (r *repo) SaveInventory(ctx context.Context, inv *model.PlateInventory) error {
+	query, args, err := r.qb.
+		Insert("plate_inventories").
+		Columns("user_id", "unit", "bar_weight").
+		Values(inv.UserID, inv.Unit, inv.BarWeight).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET unit = EXCLUDED.unit, bar_weight = EXCLUDED.bar_weight").ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save plate inventory: %w", err)
+	}
+
+	query, args, err = r.qb.
+		Delete("plate_inventory_items").
+		Where(squirrel.Eq{"user_id": inv.UserID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to clear plates: %w", err)
+	}
+
+	if len(inv.Plates) == 0 {
+		return nil
+	}
+
+	builder := r.qb.Insert("plate_inventory_items").Columns("user_id", "weight", "count")
+	for _, p := range inv.Plates {
+		builder = builder.Values(inv.UserID, p.Weight, p.Count)
+	}
+
+	query, args, err = builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert plates: %w", err)
+	}
+
+	return nil
+}
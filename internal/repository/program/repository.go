@@ -0,0 +1,536 @@
+package program
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+var _ repository.ProgramRepository = (*repo)(nil)
+
+type repo struct {
+	db db.Client
+	qb squirrel.StatementBuilderType
+}
+
+func NewRepository(db db.Client) *repo {
+	return &repo{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *repo) CreateProgram(ctx context.Context, program *model.Program) (int64, error) {
+	query, args, err := r.qb.
+		Insert("programs").
+		Columns("user_id", "name", "description", "weeks", "generator").
+		Values(program.UserID, program.Name, program.Description, program.Weeks, program.Generator).
+		Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert program: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repo) AddProgramDay(ctx context.Context, day *model.ProgramDay) (int64, error) {
+	query, args, err := r.qb.
+		Insert("program_days").
+		Columns("program_id", "week", "day", "name").
+		Values(day.ProgramID, day.Week, day.Day, day.Name).
+		Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert program day: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repo) AddProgramExercises(ctx context.Context, exercises []*model.ProgramExercise) error {
+	if len(exercises) == 0 {
+		return nil
+	}
+
+	builder := r.qb.Insert("program_exercises").
+		Columns("program_day_id", "exercise_id", "position", "sets", "reps", "prescription_type", "prescription_value", "amrap", "notes")
+	for _, e := range exercises {
+		builder = builder.Values(e.ProgramDayID, e.ExerciseID, e.Position, e.Sets, e.Reps, e.Type, e.Value, e.AMRAP, e.Notes)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	_, err = r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to insert program exercises: %w", err)
+	}
+
+	return nil
+}
+
+// visibleTo limits programs to the user's own, the shared ones and the ones
+// the user is enrolled in, such as the programs a coach assigned.
+func visibleTo(userID int64) squirrel.Sqlizer {
+	return squirrel.Or{
+		squirrel.Eq{"user_id": userID},
+		squirrel.Eq{"user_id": nil},
+		squirrel.Expr("id IN (SELECT program_id FROM program_enrollments WHERE user_id = ?)", userID),
+	}
+}
+
+func (r *repo) GetProgram(ctx context.Context, programID, userID int64) (*model.Program, error) {
+	query, args, err := r.qb.
+		Select("id", "user_id", "name", "description", "weeks", "generator", "created_at").
+		From("programs").
+		Where(squirrel.Eq{"id": programID}).
+		Where(visibleTo(userID)).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var program model.Program
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(
+		&program.ID,
+		&program.UserID,
+		&program.Name,
+		&program.Description,
+		&program.Weeks,
+		&program.Generator,
+		&program.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrProgramNotFound
+		}
+		return nil, fmt.Errorf("failed to get program: %w", err)
+	}
+
+	return &program, nil
+}
+
+func (r *repo) ListPrograms(ctx context.Context, userID int64) ([]*model.Program, error) {
+	query, args, err := r.qb.
+		Select("id", "user_id", "name", "description", "weeks", "generator", "created_at").
+		From("programs").
+		Where(visibleTo(userID)).
+		OrderBy("created_at DESC", "id DESC").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list programs: %w", err)
+	}
+	defer rows.Close()
+
+	var programs []*model.Program
+	for rows.Next() {
+		var program model.Program
+		err = rows.Scan(&program.ID, &program.UserID, &program.Name, &program.Description, &program.Weeks, &program.Generator, &program.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan program: %w", err)
+		}
+		programs = append(programs, &program)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate programs: %w", err)
+	}
+
+	return programs, nil
+}
+
+func (r *repo) GetProgramDays(ctx context.Context, programID int64) ([]*model.ProgramDay, error) {
+	query, args, err := r.qb.
+		Select("id", "program_id", "week", "day", "name").
+		From("program_days").
+		Where(squirrel.Eq{"program_id": programID}).
+		OrderBy("week", "day").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list program days: %w", err)
+	}
+	defer rows.Close()
+
+	var days []*model.ProgramDay
+	for rows.Next() {
+		var day model.ProgramDay
+		if err = rows.Scan(&day.ID, &day.ProgramID, &day.Week, &day.Day, &day.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan program day: %w", err)
+		}
+		days = append(days, &day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate program days: %w", err)
+	}
+
+	return days, nil
+}
+
+func (r *repo) GetProgramExercises(ctx context.Context, programID int64) ([]*model.ProgramExercise, error) {
+	query, args, err := r.qb.
+		Select("pe.id", "pe.program_day_id", "pe.exercise_id", "pe.position", "pe.sets", "pe.reps", "pe.prescription_type", "pe.prescription_value", "pe.amrap", "pe.notes",
+			"e.name", "e.type", "e.muscle_group", "e.description", "e.tracking_profile").
+		From("program_exercises pe").
+		Join("program_days pd ON pd.id = pe.program_day_id").
+		Join("exercises e ON e.id = pe.exercise_id").
+		Where(squirrel.Eq{"pd.program_id": programID}).
+		OrderBy("pe.program_day_id", "pe.position", "pe.id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list program exercises: %w", err)
+	}
+	defer rows.Close()
+
+	var exercises []*model.ProgramExercise
+	for rows.Next() {
+		var e model.ProgramExercise
+		err = rows.Scan(
+			&e.ID,
+			&e.ProgramDayID,
+			&e.ExerciseID,
+			&e.Position,
+			&e.Sets,
+			&e.Reps,
+			&e.Type,
+			&e.Value,
+			&e.AMRAP,
+			&e.Notes,
+			&e.Exercise.Name,
+			&e.Exercise.Type,
+			&e.Exercise.MuscleGroup,
+			&e.Exercise.Description,
+			&e.Exercise.TrackingProfile,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan program exercise: %w", err)
+		}
+		e.Exercise.ID = e.ExerciseID
+		exercises = append(exercises, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate program exercises: %w", err)
+	}
+
+	return exercises, nil
+}
+
+func (r *repo) CreateEnrollment(ctx context.Context, enrollment *model.Enrollment) (int64, error) {
+	query, args, err := r.qb.
+		Insert("program_enrollments").
+		Columns("user_id", "program_id", "start_date").
+		Values(enrollment.UserID, enrollment.ProgramID, enrollment.StartDate).
+		Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert enrollment: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repo) GetEnrollment(ctx context.Context, enrollmentID, userID int64) (*model.Enrollment, error) {
+	query, args, err := r.qb.
+		Select("id", "user_id", "program_id", "start_date", "active", "created_at").
+		From("program_enrollments").
+		Where(squirrel.Eq{"id": enrollmentID, "user_id": userID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var e model.Enrollment
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&e.ID, &e.UserID, &e.ProgramID, &e.StartDate, &e.Active, &e.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrEnrollmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get enrollment: %w", err)
+	}
+
+	return &e, nil
+}
+
+func (r *repo) ListEnrollments(ctx context.Context, userID int64) ([]*model.Enrollment, error) {
+	query, args, err := r.qb.
+		Select("id", "user_id", "program_id", "start_date", "active", "created_at").
+		From("program_enrollments").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("start_date DESC", "id DESC").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list enrollments: %w", err)
+	}
+	defer rows.Close()
+
+	var enrollments []*model.Enrollment
+	for rows.Next() {
+		var e model.Enrollment
+		if err = rows.Scan(&e.ID, &e.UserID, &e.ProgramID, &e.StartDate, &e.Active, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan enrollment: %w", err)
+		}
+		enrollments = append(enrollments, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate enrollments: %w", err)
+	}
+
+	return enrollments, nil
+}
+
+func (r *repo) SetTrainingMaxes(ctx context.Context, enrollmentID int64, maxes []*model.TrainingMax) error {
+	if len(maxes) == 0 {
+		return nil
+	}
+
+	builder := r.qb.Insert("enrollment_training_maxes").
+		Columns("enrollment_id", "exercise_id", "training_max").
+		Suffix("ON CONFLICT (enrollment_id, exercise_id) DO UPDATE SET training_max = EXCLUDED.training_max")
+	for _, m := range maxes {
+		builder = builder.Values(enrollmentID, m.ExerciseID, m.TrainingMax)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	_, err = r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to set training maxes: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) GetTrainingMaxes(ctx context.Context, enrollmentID int64) ([]*model.TrainingMax, error) {
+	query, args, err := r.qb.
+		Select("exercise_id", "training_max").
+		From("enrollment_training_maxes").
+		Where(squirrel.Eq{"enrollment_id": enrollmentID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list training maxes: %w", err)
+	}
+	defer rows.Close()
+
+	var maxes []*model.TrainingMax
+	for rows.Next() {
+		var m model.TrainingMax
+		if err = rows.Scan(&m.ExerciseID, &m.TrainingMax); err != nil {
+			return nil, fmt.Errorf("failed to scan training max: %w", err)
+		}
+		maxes = append(maxes, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate training maxes: %w", err)
+	}
+
+	return maxes, nil
+}
+
+func (r *repo) LinkSession(ctx context.Context, session *model.EnrollmentSession) error {
+	query, args, err := r.qb.
+		Insert("enrollment_sessions").
+		Columns("enrollment_id", "planned_date", "workout_id").
+		Values(session.EnrollmentID, session.PlannedDate, session.WorkoutID).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	_, err = r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return apperrors.ErrSessionLinked
+		}
+		return fmt.Errorf("failed to link session: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) ListSessions(ctx context.Context, enrollmentID int64) ([]*model.EnrollmentSession, error) {
+	query, args, err := r.qb.
+		Select("enrollment_id", "planned_date", "workout_id").
+		From("enrollment_sessions").
+		Where(squirrel.Eq{"enrollment_id": enrollmentID}).
+		OrderBy("planned_date").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*model.EnrollmentSession
+	for rows.Next() {
+		var s model.EnrollmentSession
+		if err = rows.Scan(&s.EnrollmentID, &s.PlannedDate, &s.WorkoutID); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// GetLiftRecords returns the user's personal records on exercises that stand
+// for one of the main lifts.
+func (r *repo) GetLiftRecords(ctx context.Context, userID int64) ([]*model.UserRecord, error) {
+	query, args, err := r.qb.
+		Select("pr.exercise_id", "pr.weight", "pr.reps", "e.name", "e.lift").
+		From("personal_records pr").
+		Join("exercises e ON e.id = pr.exercise_id").
+		Where(squirrel.Eq{"pr.user_id": userID}).
+		Where(squirrel.NotEq{"e.lift": nil}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list lift records: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*model.UserRecord
+	for rows.Next() {
+		var rec model.UserRecord
+		if err = rows.Scan(&rec.ExerciseID, &rec.Weight, &rec.Reps, &rec.Exercise.Name, &rec.Exercise.Lift); err != nil {
+			return nil, fmt.Errorf("failed to scan lift record: %w", err)
+		}
+		rec.UserID = userID
+		rec.Exercise.ID = rec.ExerciseID
+		records = append(records, &rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate lift records: %w", err)
+	}
+
+	return records, nil
+}
+
+func (r *repo) GetSubstitutions(ctx context.Context, enrollmentID int64) ([]*model.ExerciseSubstitution, error) {
+	query, args, err := r.qb.
+		Select("es.enrollment_id", "es.program_exercise_id", "es.exercise_id", "e.name", "e.type", "e.muscle_group", "e.description", "e.tracking_profile").
+		From("enrollment_substitutions es").
+		Join("exercises e ON e.id = es.exercise_id").
+		Where(squirrel.Eq{"es.enrollment_id": enrollmentID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list substitutions: %w", err)
+	}
+	defer rows.Close()
+
+	var substitutions []*model.ExerciseSubstitution
+	for rows.Next() {
+		var s model.ExerciseSubstitution
+		err = rows.Scan(
+			&s.EnrollmentID,
+			&s.ProgramExerciseID,
+			&s.ExerciseID,
+			&s.Exercise.Name,
+			&s.Exercise.Type,
+			&s.Exercise.MuscleGroup,
+			&s.Exercise.Description,
+			&s.Exercise.TrackingProfile,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan substitution: %w", err)
+		}
+		s.Exercise.ID = s.ExerciseID
+		substitutions = append(substitutions, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate substitutions: %w", err)
+	}
+
+	return substitutions, nil
+}
+
+func (r *repo) SetSubstitution(ctx context.Context, s *model.ExerciseSubstitution) error {
+	query, args, err := r.qb.
+		Insert("enrollment_substitutions").
+		Columns("enrollment_id", "program_exercise_id", "exercise_id").
+		Values(s.EnrollmentID, s.ProgramExerciseID, s.ExerciseID).
+		Suffix("ON CONFLICT (enrollment_id, program_exercise_id) DO UPDATE SET exercise_id = EXCLUDED.exercise_id").ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	_, err = r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to set substitution: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) DeleteSubstitution(ctx context.Context, enrollmentID, programExerciseID int64) error {
+	query, args, err := r.qb.
+		Delete("enrollment_substitutions").
+		Where(squirrel.Eq{"enrollment_id": enrollmentID, "program_exercise_id": programExerciseID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	_, err = r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete substitution: %w", err)
+	}
+
+	return nil
+}
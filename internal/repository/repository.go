@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"time"
+
 	"github.com/biryanim/workoutbook/internal/model"
 )
 
@@ -9,19 +12,162 @@ type UserRepository interface {
 	Create(ctx context.Context, user *model.CreateUserParams) (int64, error)
 	GetByID(ctx context.Context, id int64) (*model.User, error)
 	GetByEmail(ctx context.Context, email string) (*model.User, error)
+	UpdateProfile(ctx context.Context, params *model.UpdateProfileParams) error
+	AddBodyWeight(ctx context.Context, bw *model.BodyWeight) (int64, error)
+	GetLatestBodyWeight(ctx context.Context, userID int64) (*model.BodyWeight, error)
+	ListBodyWeights(ctx context.Context, userID int64) ([]*model.BodyWeight, error)
+	ListEquipment(ctx context.Context, userID int64) ([]*model.Equipment, error)
+	SetEquipment(ctx context.Context, userID int64, codes []string) error
 }
 
 type WorkoutRepository interface {
 	CreateWorkout(ctx context.Context, workout *model.Workout) (int64, error)
-	GetWorkoutByID(ctx context.Context, workoutID, userId int64) (*model.Workout, error)
+	GetWorkoutByID(ctx context.Context, workoutID, userId int64, permission string) (*model.Workout, error)
+	UpdateWorkout(ctx context.Context, params *model.UpdateWorkoutParams) error
 	ListWorkouts(ctx context.Context, userId int64, filter *model.WorkoutsFilter) ([]*model.Workout, error)
+	CountWorkouts(ctx context.Context, userId int64, filter *model.WorkoutsFilter) (int64, error)
 	AddWorkoutExercise(ctx context.Context, we *model.WorkoutExercise) (int64, error)
 	GetExercisesByWorkoutID(ctx context.Context, workoutID int64) ([]*model.WorkoutExercise, error)
-	IsUserHaveWorkout(ctx context.Context, userId, workoutId int64) (bool, error)
-	GetExercises(ctx context.Context, typ string) ([]*model.Exercise, error)
+	GetLastSetTime(ctx context.Context, workoutID int64) (sql.NullTime, error)
+	GetPlannedEntry(ctx context.Context, workoutID, exerciseID int64) (*model.WorkoutExercise, error)
+	UpdatePlannedSets(ctx context.Context, workoutExerciseID int64, sets int) error
+	PerformPlannedEntry(ctx context.Context, we *model.WorkoutExercise) error
+	UpdateExerciseOrder(ctx context.Context, workoutID int64, order []*model.ExerciseOrder) error
+	UpsertExerciseGroups(ctx context.Context, groups []*model.ExerciseGroup) error
+	ListExerciseGroups(ctx context.Context, workoutID int64) ([]*model.ExerciseGroup, error)
+	AddSplits(ctx context.Context, splits []*model.Split) error
+	GetSplitsByWorkoutID(ctx context.Context, workoutID int64) ([]*model.Split, error)
+	GetMappedExerciseID(ctx context.Context, source, externalName string) (int64, error)
+	ListExerciseMappings(ctx context.Context, source string) ([]*model.ExerciseMapping, error)
+	SetExerciseMapping(ctx context.Context, mapping *model.ExerciseMapping) error
+	DeleteExerciseMapping(ctx context.Context, source, externalName string) error
+	AddTrack(ctx context.Context, track *model.WorkoutTrack) (int64, error)
+	AddSamples(ctx context.Context, workoutExerciseID int64, points []*model.TrackPoint) error
+	IsUserHaveWorkout(ctx context.Context, userId, workoutId int64, permission string) (bool, error)
+	AddWorkoutComment(ctx context.Context, comment *model.WorkoutComment) (int64, error)
+	ListWorkoutComments(ctx context.Context, workoutID int64) ([]*model.WorkoutComment, error)
+	GetExercises(ctx context.Context, filter *model.ExercisesFilter) ([]*model.Exercise, error)
+	CountExercises(ctx context.Context, filter *model.ExercisesFilter) (int64, error)
+	GetExerciseByID(ctx context.Context, exerciseID int64) (*model.Exercise, error)
+	ListEquipment(ctx context.Context) ([]*model.Equipment, error)
+	ListMuscles(ctx context.Context) ([]*model.Muscle, error)
+	ListExerciseMuscles(ctx context.Context, exerciseIDs []int64) ([]*model.ExerciseMuscle, error)
+	ListExerciseTranslations(ctx context.Context, locale string, exerciseIDs []int64) ([]*model.ExerciseTranslation, error)
+	AddExerciseAlias(ctx context.Context, alias *model.ExerciseAlias) (int64, error)
+	GetExerciseAlias(ctx context.Context, aliasID int64) (*model.ExerciseAlias, error)
+	ListExerciseAliases(ctx context.Context, userID, exerciseID int64) ([]*model.ExerciseAlias, error)
+	DeleteExerciseAlias(ctx context.Context, aliasID int64) error
+	ResolveExercises(ctx context.Context, q *model.ResolveQuery) ([]*model.ExerciseCandidate, error)
+	ListSubstitutes(ctx context.Context, q *model.SubstitutesQuery) ([]*model.Substitute, error)
+	GetExerciseHistory(ctx context.Context, userID, exerciseID int64, limit uint64) ([]*model.ExerciseSession, error)
 
 	GetPersonalRecord(ctx context.Context, userID, exerciseID int64) (*model.UserRecord, error)
 	AddRecord(ctx context.Context, user *model.UserRecord) (int64, error)
 	UpdatePersonalRecord(ctx context.Context, user *model.UserRecord) error
 	ListRecords(ctx context.Context, userId int64) ([]*model.UserRecord, error)
+	ListStrengthStandards(ctx context.Context, userID int64, sex string) ([]*model.StrengthStandard, error)
+	SaveStrengthStandards(ctx context.Context, userID, exerciseID int64, standards []*model.StrengthStandard) error
+	DeleteStrengthStandards(ctx context.Context, userID, exerciseID int64) error
+
+	GetSyncWorkout(ctx context.Context, userID int64, uuid string) (*model.SyncWorkout, error)
+	GetSyncEntry(ctx context.Context, userID int64, uuid string) (*model.SyncEntry, error)
+	IsSyncDeleted(ctx context.Context, userID int64, entity, uuid string) (bool, error)
+	CreateSyncWorkout(ctx context.Context, sw *model.SyncWorkout) (int64, error)
+	UpdateSyncWorkout(ctx context.Context, sw *model.SyncWorkout) error
+	CreateSyncEntry(ctx context.Context, se *model.SyncEntry) (int64, error)
+	UpdateSyncEntry(ctx context.Context, se *model.SyncEntry) error
+	DeleteWorkout(ctx context.Context, userID, workoutID int64) error
+	DeleteWorkoutExercise(ctx context.Context, workoutID, workoutExerciseID int64) error
+	LockSyncChanges(ctx context.Context, userID int64) error
+	ListWorkoutChanges(ctx context.Context, userID, cursor int64, limit uint64) ([]*model.SyncWorkout, error)
+	ListEntryChanges(ctx context.Context, userID, cursor int64, limit uint64) ([]*model.SyncEntry, error)
+	ListTombstones(ctx context.Context, userID, cursor int64, limit uint64) ([]*model.SyncTombstone, error)
+}
+
+type AnalyticsRepository interface {
+	GetWeeklyMuscleVolume(ctx context.Context, userID int64, filter *model.MuscleVolumeFilter) ([]*model.MuscleGroupVolume, error)
+	ListMuscleGroups(ctx context.Context) ([]string, error)
+	GetCalendarDays(ctx context.Context, userID int64, timezone string, start, end time.Time, tags model.TagFilter) ([]*model.CalendarDay, error)
+	GetDailySessionLoad(ctx context.Context, userID int64, timezone string, start, end time.Time, tags model.TagFilter) ([]*model.DailyLoad, error)
+	GetAverageSessionDuration(ctx context.Context, userID int64, tags model.TagFilter) (time.Duration, error)
+	GetTopExercises(ctx context.Context, userID int64, limit uint64, tags model.TagFilter) ([]*model.ExerciseUsage, error)
+}
+
+type ProgramRepository interface {
+	CreateProgram(ctx context.Context, program *model.Program) (int64, error)
+	AddProgramDay(ctx context.Context, day *model.ProgramDay) (int64, error)
+	AddProgramExercises(ctx context.Context, exercises []*model.ProgramExercise) error
+	GetProgram(ctx context.Context, programID, userID int64) (*model.Program, error)
+	ListPrograms(ctx context.Context, userID int64) ([]*model.Program, error)
+	GetProgramDays(ctx context.Context, programID int64) ([]*model.ProgramDay, error)
+	GetProgramExercises(ctx context.Context, programID int64) ([]*model.ProgramExercise, error)
+	GetLiftRecords(ctx context.Context, userID int64) ([]*model.UserRecord, error)
+
+	CreateEnrollment(ctx context.Context, enrollment *model.Enrollment) (int64, error)
+	GetEnrollment(ctx context.Context, enrollmentID, userID int64) (*model.Enrollment, error)
+	ListEnrollments(ctx context.Context, userID int64) ([]*model.Enrollment, error)
+	SetTrainingMaxes(ctx context.Context, enrollmentID int64, maxes []*model.TrainingMax) error
+	GetTrainingMaxes(ctx context.Context, enrollmentID int64) ([]*model.TrainingMax, error)
+	LinkSession(ctx context.Context, session *model.EnrollmentSession) error
+	ListSessions(ctx context.Context, enrollmentID int64) ([]*model.EnrollmentSession, error)
+	GetSubstitutions(ctx context.Context, enrollmentID int64) ([]*model.ExerciseSubstitution, error)
+	SetSubstitution(ctx context.Context, s *model.ExerciseSubstitution) error
+	DeleteSubstitution(ctx context.Context, enrollmentID, programExerciseID int64) error
+}
+
+type TemplateRepository interface {
+	CreateTemplate(ctx context.Context, template *model.WorkoutTemplate) (int64, error)
+	AddTemplateExercises(ctx context.Context, exercises []*model.TemplateExercise) error
+	GetTemplate(ctx context.Context, templateID, userID int64) (*model.WorkoutTemplate, error)
+	ListTemplates(ctx context.Context, userID int64) ([]*model.WorkoutTemplate, error)
+	GetTemplateExercises(ctx context.Context, templateID int64) ([]*model.TemplateExercise, error)
+	ReplaceTemplateExercise(ctx context.Context, templateExerciseID, exerciseID int64) error
+}
+
+type PlateRepository interface {
+	GetInventory(ctx context.Context, userID int64) (*model.PlateInventory, error)
+	SaveInventory(ctx context.Context, inv *model.PlateInventory) error
+}
+
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, rec *model.IdempotencyRecord) (bool, error)
+	Get(ctx context.Context, userID int64, key string) (*model.IdempotencyRecord, error)
+	Complete(ctx context.Context, rec *model.IdempotencyRecord) error
+	Delete(ctx context.Context, userID int64, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type SearchRepository interface {
+	Search(ctx context.Context, q *model.SearchQuery) (*model.SearchResults, error)
+}
+
+type TagRepository interface {
+	CreateTag(ctx context.Context, tag *model.Tag) (int64, error)
+	GetTag(ctx context.Context, tagID, userID int64) (*model.Tag, error)
+	ListTags(ctx context.Context, userID int64) ([]*model.Tag, error)
+	UpdateTag(ctx context.Context, params *model.UpdateTagParams) error
+	DeleteTag(ctx context.Context, tagID, userID int64) error
+	CountUserTags(ctx context.Context, userID int64, tagIDs []int64) (int, error)
+	SetWorkoutTags(ctx context.Context, workoutID int64, tagIDs []int64) error
+	ListWorkoutTags(ctx context.Context, workoutIDs []int64) ([]*model.WorkoutTag, error)
+	GetTagUsage(ctx context.Context, userID int64) ([]*model.TagUsage, error)
+}
+
+type MediaRepository interface {
+	AddMedia(ctx context.Context, m *model.ExerciseMedia) (int64, error)
+	GetMedia(ctx context.Context, mediaID int64) (*model.ExerciseMedia, error)
+	ListMedia(ctx context.Context, exerciseID int64) ([]*model.ExerciseMedia, error)
+	DeleteMedia(ctx context.Context, mediaID int64) error
+}
+
+type CoachRepository interface {
+	CreateCoaching(ctx context.Context, coaching *model.Coaching) (int64, error)
+	GetCoaching(ctx context.Context, coachID, athleteID int64) (*model.Coaching, error)
+	ListAthletes(ctx context.Context, coachID int64) ([]*model.Coaching, error)
+	ListCoaches(ctx context.Context, athleteID int64) ([]*model.Coaching, error)
+	AcceptCoaching(ctx context.Context, coachID, athleteID int64) error
+	UpdatePermission(ctx context.Context, params *model.UpdateCoachingParams) error
+	DeclineCoaching(ctx context.Context, coachID, athleteID int64) error
+	DeleteInvitation(ctx context.Context, coachID, coachingID int64) error
+	DeleteCoaching(ctx context.Context, coachID, athleteID int64) error
 }
@@ -0,0 +1,128 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/client/db"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+)
+
+var _ repository.SearchRepository = (*repo)(nil)
+
+// exerciseMatches ranks each catalog exercise by the best match among its
+// built-in text, its translations and the aliases visible to q.user_id.
+const exerciseMatches = "SELECT x.exercise_id, max(x.rank) AS rank FROM (" +
+	"SELECT id AS exercise_id, ts_rank(search_vector, q.query) AS rank FROM exercises WHERE search_vector @@ q.query " +
+	"UNION ALL " +
+	"SELECT exercise_id, ts_rank(search_vector, q.query) FROM exercise_translations WHERE search_vector @@ q.query " +
+	"UNION ALL " +
+	"SELECT exercise_id, ts_rank(search_vector, q.query) FROM exercise_aliases " +
+	"WHERE (created_by IS NULL OR created_by = q.user_id) AND search_vector @@ q.query" +
+	") x GROUP BY x.exercise_id"
+
+// headlineOptions wraps the matches in <mark> and keeps snippets short.
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=20, MinWords=5, MaxFragments=2, FragmentDelimiter=\" … \""
+
+// htmlEscapes are the replacements of html.EscapeString in the order they
+// have to be applied: the ampersand first so that entities are not escaped
+// twice.
+var htmlEscapes = [][2]string{{"&", "&amp;"}, {"<", "&lt;"}, {">", "&gt;"}, {`"`, "&#34;"}, {"'", "&#39;"}}
+
+// escapeHTML wraps the SQL text expression in replace calls escaping it like
+// html.EscapeString so that only the <mark> tags of the headline are markup
+// in a snippet.
+func escapeHTML(text string) string {
+	for _, r := range htmlEscapes {
+		text = fmt.Sprintf("replace(%s, '%s', '%s')", text, strings.ReplaceAll(r[0], "'", "''"), r[1])
+	}
+
+	return text
+}
+
+type repo struct {
+	db db.Client
+	qb squirrel.StatementBuilderType
+}
+
+func NewRepository(db db.Client) *repo {
+	return &repo{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+// Search matches the query against the user's workouts and exercise entries
+// and against the shared exercise catalog in any of its translations and of
+// the aliases visible to the user, best matches first; exercise names are returned in q.Locale. The
+// query is parsed in both the Russian and the English configuration, the same
+// ones the search vectors are built with.
+func (r *repo) Search(ctx context.Context, q *model.SearchQuery) (*model.SearchResults, error) {
+	headline := func(text string) string {
+		return fmt.Sprintf("ts_headline('russian', %s, q.query, '%s')", escapeHTML(text), headlineOptions)
+	}
+
+	matches := "SELECT '" + model.SearchKindWorkout + "' AS kind, w.id, w.id AS workout_id, w.name AS title, " +
+		headline("w.name || coalesce(' — ' || w.notes, '')") + " AS snippet, " +
+		"ts_rank(w.search_vector, q.query) AS rank, w.date " +
+		"FROM workouts w WHERE w.user_id = ? AND w.search_vector @@ q.query " +
+		"UNION ALL " +
+		"SELECT '" + model.SearchKindEntry + "', we.id, we.workout_id, coalesce(t.name, e.name), " +
+		headline("coalesce(we.notes, '')") + ", " +
+		"ts_rank(we.search_vector, q.query), w.date " +
+		"FROM workout_exercises we JOIN workouts w ON w.id = we.workout_id JOIN exercises e ON e.id = we.exercise_id " +
+		"LEFT JOIN exercise_translations t ON t.exercise_id = e.id AND t.locale = ? " +
+		"WHERE w.user_id = ? AND we.search_vector @@ q.query " +
+		"UNION ALL " +
+		"SELECT '" + model.SearchKindExercise + "', e.id, NULL, coalesce(t.name, e.name), " +
+		headline("coalesce(t.name, e.name) || coalesce(' — ' || coalesce(t.description, e.description), '')") + ", " +
+		"m.rank, NULL " +
+		"FROM (" + exerciseMatches + ") m JOIN exercises e ON e.id = m.exercise_id " +
+		"LEFT JOIN exercise_translations t ON t.exercise_id = e.id AND t.locale = ?"
+
+	query, args, err := r.qb.
+		Select("r.kind", "r.id", "r.workout_id", "r.title", "r.snippet", "r.rank::float8", "r.date", "count(*) OVER ()").
+		Prefix("WITH q AS (SELECT websearch_to_tsquery('russian', ?) || websearch_to_tsquery('english', ?) AS query, ?::int AS user_id)",
+			q.Text, q.Text, q.UserID).
+		From("q").
+		Join("LATERAL ("+matches+") r ON true", q.UserID, q.Locale, q.UserID, q.Locale).
+		OrderBy("r.rank DESC", "r.date DESC NULLS LAST", "r.kind", "r.id").
+		Limit(q.Limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	results := &model.SearchResults{}
+	for rows.Next() {
+		var res model.SearchResult
+		err = rows.Scan(
+			&res.Kind,
+			&res.ID,
+			&res.WorkoutID,
+			&res.Title,
+			&res.Snippet,
+			&res.Rank,
+			&res.Date,
+			&results.Total,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		results.Results = append(results.Results, &res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate search results: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,37 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"testing"
+)
+
+func TestHTMLEscapes(t *testing.T) {
+	tests := []string{
+		"Bench press",
+		"<script>alert(1)</script>",
+		`Pull-ups "wide" & chin-ups`,
+		"Farmer's walk",
+		"&lt;already escaped&gt; &amp;",
+		"<mark>fake</mark>",
+	}
+
+	for _, text := range tests {
+		t.Run(text, func(t *testing.T) {
+			got := text
+			for _, r := range htmlEscapes {
+				got = strings.ReplaceAll(got, r[0], r[1])
+			}
+			if want := html.EscapeString(text); got != want {
+				t.Errorf("escaped = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestEscapeHTML(t *testing.T) {
+	want := `replace(replace(replace(replace(replace(w.name, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`
+	if got := escapeHTML("w.name"); got != want {
+		t.Errorf("escapeHTML() = %s, want %s", got, want)
+	}
+}
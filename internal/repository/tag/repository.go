@@ -0,0 +1,308 @@
+package tag
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+var _ repository.TagRepository = (*repo)(nil)
+
+type repo struct {
+	db db.Client
+	qb squirrel.StatementBuilderType
+}
+
+func NewRepository(db db.Client) *repo {
+	return &repo{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *repo) CreateTag(ctx context.Context, tag *model.Tag) (int64, error) {
+	query, args, err := r.qb.
+		Insert("tags").
+		Columns("user_id", "name", "color").
+		Values(tag.UserID, tag.Name, tag.Color).
+		Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, apperrors.ErrTagAlreadyExists
+		}
+		return 0, fmt.Errorf("failed to insert tag: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repo) GetTag(ctx context.Context, tagID, userID int64) (*model.Tag, error) {
+	query, args, err := r.qb.
+		Select("id", "user_id", "name", "color", "created_at").
+		From("tags").
+		Where(squirrel.Eq{"id": tagID, "user_id": userID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var t model.Tag
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&t.ID, &t.UserID, &t.Name, &t.Color, &t.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	return &t, nil
+}
+
+func (r *repo) ListTags(ctx context.Context, userID int64) ([]*model.Tag, error) {
+	query, args, err := r.qb.
+		Select("id", "user_id", "name", "color", "created_at").
+		From("tags").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("lower(name)", "id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*model.Tag
+	for rows.Next() {
+		var t model.Tag
+		if err = rows.Scan(&t.ID, &t.UserID, &t.Name, &t.Color, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// UpdateTag changes the fields set in params; an empty color clears it.
+func (r *repo) UpdateTag(ctx context.Context, params *model.UpdateTagParams) error {
+	builder := r.qb.
+		Update("tags").
+		Where(squirrel.Eq{"id": params.ID, "user_id": params.UserID})
+	if params.Name != nil {
+		builder = builder.Set("name", *params.Name)
+	}
+	if params.Color != nil {
+		builder = builder.Set("color", squirrel.Expr("NULLIF(?, '')", *params.Color))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return apperrors.ErrTagAlreadyExists
+		}
+		return fmt.Errorf("failed to update tag: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrTagNotFound
+	}
+
+	return nil
+}
+
+func (r *repo) DeleteTag(ctx context.Context, tagID, userID int64) error {
+	query, args, err := r.qb.
+		Delete("tags").
+		Where(squirrel.Eq{"id": tagID, "user_id": userID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrTagNotFound
+	}
+
+	return nil
+}
+
+// CountUserTags counts how many of the tags belong to the user.
+func (r *repo) CountUserTags(ctx context.Context, userID int64, tagIDs []int64) (int, error) {
+	query, args, err := r.qb.
+		Select("count(*)").
+		From("tags").
+		Where(squirrel.Eq{"user_id": userID, "id": tagIDs}).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var count int
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count tags: %w", err)
+	}
+
+	return count, nil
+}
+
+// SetWorkoutTags replaces the tags of the workout.
+func (r *repo) SetWorkoutTags(ctx context.Context, workoutID int64, tagIDs []int64) error {
+	query, args, err := r.qb.
+		Delete("workout_tags").
+		Where(squirrel.Eq{"workout_id": workoutID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete workout tags: %w", err)
+	}
+
+	if len(tagIDs) == 0 {
+		return nil
+	}
+
+	builder := r.qb.Insert("workout_tags").Columns("workout_id", "tag_id")
+	for _, id := range tagIDs {
+		builder = builder.Values(workoutID, id)
+	}
+
+	query, args, err = builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to insert workout tags: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) ListWorkoutTags(ctx context.Context, workoutIDs []int64) ([]*model.WorkoutTag, error) {
+	if len(workoutIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := r.qb.
+		Select("wt.workout_id", "t.id", "t.user_id", "t.name", "t.color", "t.created_at").
+		From("workout_tags wt").
+		Join("tags t ON t.id = wt.tag_id").
+		Where(squirrel.Eq{"wt.workout_id": workoutIDs}).
+		OrderBy("wt.workout_id", "lower(t.name)", "t.id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workout tags: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []*model.WorkoutTag
+	for rows.Next() {
+		var (
+			wt model.WorkoutTag
+			t  model.Tag
+		)
+		if err = rows.Scan(&wt.WorkoutID, &t.ID, &t.UserID, &t.Name, &t.Color, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan workout tag: %w", err)
+		}
+		wt.Tag = &t
+		tags = append(tags, &wt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate workout tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// GetTagUsage sums the training done under each of the user's tags, unused
+// tags included, most used first.
+func (r *repo) GetTagUsage(ctx context.Context, userID int64) ([]*model.TagUsage, error) {
+	query, args, err := r.qb.
+		Select(
+			"t.id", "t.user_id", "t.name", "t.color", "t.created_at",
+			"COUNT(DISTINCT w.id)",
+			"COALESCE(SUM(we.sets) FILTER (WHERE NOT we.is_warmup AND NOT we.is_planned), 0)",
+			"COALESCE(SUM(we.sets * we.reps * we.weight) FILTER (WHERE NOT we.is_warmup AND NOT we.is_planned), 0)::float8",
+			"MIN(w.date)",
+			"MAX(w.date)",
+		).
+		From("tags t").
+		LeftJoin("workout_tags wt ON wt.tag_id = t.id").
+		LeftJoin("workouts w ON w.id = wt.workout_id").
+		LeftJoin("workout_exercises we ON we.workout_id = w.id").
+		Where(squirrel.Eq{"t.user_id": userID}).
+		GroupBy("t.id").
+		OrderBy("COUNT(DISTINCT w.id) DESC", "lower(t.name)", "t.id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag usage: %w", err)
+	}
+	defer rows.Close()
+
+	var usage []*model.TagUsage
+	for rows.Next() {
+		var (
+			u model.TagUsage
+			t model.Tag
+		)
+		err = rows.Scan(
+			&t.ID,
+			&t.UserID,
+			&t.Name,
+			&t.Color,
+			&t.CreatedAt,
+			&u.Workouts,
+			&u.Sets,
+			&u.Volume,
+			&u.FirstUsed,
+			&u.LastUsed,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tag usage: %w", err)
+		}
+		u.Tag = &t
+		usage = append(usage, &u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tag usage: %w", err)
+	}
+
+	return usage, nil
+}
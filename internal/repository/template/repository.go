@@ -0,0 +1,192 @@
+package template
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+)
+
+var _ repository.TemplateRepository = (*repo)(nil)
+
+type repo struct {
+	db db.Client
+	qb squirrel.StatementBuilderType
+}
+
+func NewRepository(db db.Client) *repo {
+	return &repo{
+		db: db,
+		qb: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+	}
+}
+
+func (r *repo) CreateTemplate(ctx context.Context, template *model.WorkoutTemplate) (int64, error) {
+	query, args, err := r.qb.
+		Insert("workout_templates").
+		Columns("user_id", "name", "notes").
+		Values(template.UserID, template.Name, template.Notes).
+		Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert template: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repo) AddTemplateExercises(ctx context.Context, exercises []*model.TemplateExercise) error {
+	if len(exercises) == 0 {
+		return nil
+	}
+
+	builder := r.qb.Insert("workout_template_exercises").
+		Columns("template_id", "exercise_id", "position", "sets", "reps", "weight", "notes")
+	for _, e := range exercises {
+		builder = builder.Values(e.TemplateID, e.ExerciseID, e.Position, e.Sets, e.Reps, e.Weight, e.Notes)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	_, err = r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to insert template exercises: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) GetTemplate(ctx context.Context, templateID, userID int64) (*model.WorkoutTemplate, error) {
+	query, args, err := r.qb.
+		Select("id", "user_id", "name", "notes", "created_at").
+		From("workout_templates").
+		Where(squirrel.Eq{"id": templateID, "user_id": userID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var t model.WorkoutTemplate
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&t.ID, &t.UserID, &t.Name, &t.Notes, &t.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+
+	return &t, nil
+}
+
+func (r *repo) ListTemplates(ctx context.Context, userID int64) ([]*model.WorkoutTemplate, error) {
+	query, args, err := r.qb.
+		Select("id", "user_id", "name", "notes", "created_at").
+		From("workout_templates").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("name", "id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*model.WorkoutTemplate
+	for rows.Next() {
+		var t model.WorkoutTemplate
+		if err = rows.Scan(&t.ID, &t.UserID, &t.Name, &t.Notes, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan template: %w", err)
+		}
+		templates = append(templates, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+func (r *repo) GetTemplateExercises(ctx context.Context, templateID int64) ([]*model.TemplateExercise, error) {
+	query, args, err := r.qb.
+		Select("te.id", "te.template_id", "te.exercise_id", "te.position", "te.sets", "te.reps", "te.weight", "te.notes",
+			"e.name", "e.type", "e.muscle_group", "e.description", "e.load_increment", "e.tracking_profile").
+		From("workout_template_exercises te").
+		Join("exercises e ON e.id = te.exercise_id").
+		Where(squirrel.Eq{"te.template_id": templateID}).
+		OrderBy("te.position", "te.id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list template exercises: %w", err)
+	}
+	defer rows.Close()
+
+	var exercises []*model.TemplateExercise
+	for rows.Next() {
+		var e model.TemplateExercise
+		err = rows.Scan(
+			&e.ID,
+			&e.TemplateID,
+			&e.ExerciseID,
+			&e.Position,
+			&e.Sets,
+			&e.Reps,
+			&e.Weight,
+			&e.Notes,
+			&e.Exercise.Name,
+			&e.Exercise.Type,
+			&e.Exercise.MuscleGroup,
+			&e.Exercise.Description,
+			&e.Exercise.LoadIncrement,
+			&e.Exercise.TrackingProfile,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan template exercise: %w", err)
+		}
+		e.Exercise.ID = e.ExerciseID
+		exercises = append(exercises, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate template exercises: %w", err)
+	}
+
+	return exercises, nil
+}
+
+// ReplaceTemplateExercise swaps the exercise of a template entry, keeping its
+// sets, reps, weight and notes.
+func (r *repo) ReplaceTemplateExercise(ctx context.Context, templateExerciseID, exerciseID int64) error {
+	query, args, err := r.qb.
+		Update("workout_template_exercises").
+		Set("exercise_id", exerciseID).
+		Where(squirrel.Eq{"id": templateExerciseID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	_, err = r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to replace template exercise: %w", err)
+	}
+
+	return nil
+}
@@ -52,7 +52,7 @@ func (r *repo) Create(ctx context.Context, user *model.CreateUserParams) (int64,
 
 func (r *repo) GetByEmail(ctx context.Context, email string) (*model.User, error) {
 	query, args, err := r.qb.
-		Select("id", "name", "email", "password", "created_at", "updated_at").
+		Select("id", "name", "email", "password", "timezone", "sex", "locale", "is_admin", "created_at", "updated_at").
 		From("users").
 		Where(squirrel.Eq{"email": email}).
 		ToSql()
@@ -67,6 +67,10 @@ func (r *repo) GetByEmail(ctx context.Context, email string) (*model.User, error
 		&user.Name,
 		&user.Email,
 		&user.Password,
+		&user.Timezone,
+		&user.Sex,
+		&user.Locale,
+		&user.IsAdmin,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -82,7 +86,7 @@ func (r *repo) GetByEmail(ctx context.Context, email string) (*model.User, error
 
 func (r *repo) GetByID(ctx context.Context, id int64) (*model.User, error) {
 	query, args, err := r.qb.
-		Select("id", "name", "email", "password", "created_at", "updated_at").
+		Select("id", "name", "email", "password", "timezone", "sex", "locale", "is_admin", "created_at", "updated_at").
 		From("users").
 		Where(squirrel.Eq{"id": id}).
 		ToSql()
@@ -96,6 +100,10 @@ func (r *repo) GetByID(ctx context.Context, id int64) (*model.User, error) {
 		&user.Name,
 		&user.Email,
 		&user.Password,
+		&user.Timezone,
+		&user.Sex,
+		&user.Locale,
+		&user.IsAdmin,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -108,3 +116,179 @@ func (r *repo) GetByID(ctx context.Context, id int64) (*model.User, error) {
 
 	return &user, nil
 }
+
+func (r *repo) UpdateProfile(ctx context.Context, params *model.UpdateProfileParams) error {
+	builder := r.qb.
+		Update("users").
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": params.UserID})
+
+	if params.Timezone != nil {
+		builder = builder.Set("timezone", *params.Timezone)
+	}
+	if params.Sex != nil {
+		builder = builder.Set("sex", *params.Sex)
+	}
+	if params.Locale != nil {
+		builder = builder.Set("locale", *params.Locale)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update profile: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (r *repo) AddBodyWeight(ctx context.Context, bw *model.BodyWeight) (int64, error) {
+	query, args, err := r.qb.
+		Insert("body_weights").
+		Columns("user_id", "weight", "measured_at").
+		Values(bw.UserID, bw.Weight, bw.MeasuredAt).
+		Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert body weight: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repo) GetLatestBodyWeight(ctx context.Context, userID int64) (*model.BodyWeight, error) {
+	query, args, err := r.qb.
+		Select("id", "user_id", "weight", "measured_at").
+		From("body_weights").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("measured_at DESC", "id DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var bw model.BodyWeight
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&bw.ID, &bw.UserID, &bw.Weight, &bw.MeasuredAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrBodyWeightNotFound
+		}
+		return nil, fmt.Errorf("failed to get body weight: %w", err)
+	}
+
+	return &bw, nil
+}
+
+func (r *repo) ListBodyWeights(ctx context.Context, userID int64) ([]*model.BodyWeight, error) {
+	query, args, err := r.qb.
+		Select("id", "user_id", "weight", "measured_at").
+		From("body_weights").
+		Where(squirrel.Eq{"user_id": userID}).
+		OrderBy("measured_at DESC", "id DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list body weights: %w", err)
+	}
+	defer rows.Close()
+
+	var weights []*model.BodyWeight
+	for rows.Next() {
+		var bw model.BodyWeight
+		if err = rows.Scan(&bw.ID, &bw.UserID, &bw.Weight, &bw.MeasuredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan body weight: %w", err)
+		}
+		weights = append(weights, &bw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate body weights: %w", err)
+	}
+
+	return weights, nil
+}
+
+func (r *repo) ListEquipment(ctx context.Context, userID int64) ([]*model.Equipment, error) {
+	query, args, err := r.qb.
+		Select("eq.id", "eq.code", "eq.name").
+		From("user_equipment ue").
+		Join("equipment eq ON eq.id = ue.equipment_id").
+		Where(squirrel.Eq{"ue.user_id": userID}).
+		OrderBy("eq.id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user equipment: %w", err)
+	}
+	defer rows.Close()
+
+	var equipment []*model.Equipment
+	for rows.Next() {
+		var eq model.Equipment
+		if err = rows.Scan(&eq.ID, &eq.Code, &eq.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan user equipment: %w", err)
+		}
+		equipment = append(equipment, &eq)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate user equipment: %w", err)
+	}
+
+	return equipment, nil
+}
+
+// SetEquipment replaces the user's equipment with the one of the codes.
+// Unknown codes are an error.
+func (r *repo) SetEquipment(ctx context.Context, userID int64, codes []string) error {
+	query, args, err := r.qb.
+		Delete("user_equipment").
+		Where(squirrel.Eq{"user_id": userID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to clear user equipment: %w", err)
+	}
+
+	if len(codes) == 0 {
+		return nil
+	}
+
+	query, args, err = r.qb.
+		Insert("user_equipment").
+		Columns("user_id", "equipment_id").
+		Select(r.qb.Select().Column("?::int", userID).Column("id").From("equipment").Where(squirrel.Eq{"code": codes})).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to insert user equipment: %w", err)
+	}
+	if tag.RowsAffected() != int64(len(codes)) {
+		return apperrors.ErrInvalidEquipment
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+package workout
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+// nameMatches lists every name an exercise is known by, its translations and
+// the aliases visible to q.user_id, that is trigram similar to the normalized
+// text q.text or contains a word similar to it. The built-in names need no
+// arm of their own as each exercise has its Russian translation.
+const nameMatches = "SELECT a.exercise_id, a.alias AS matched, similarity(a.normalized, q.text) AS score " +
+	"FROM q, exercise_aliases a WHERE " + aliasVisible + " AND (a.normalized % q.text OR q.text <% a.normalized) " +
+	"UNION ALL " +
+	"SELECT t.exercise_id, t.name, similarity(lower(t.name), q.text) " +
+	"FROM q, exercise_translations t WHERE lower(t.name) % q.text OR q.text <% lower(t.name)"
+
+// aliasVisible keeps the built-in aliases and the ones q.user_id added.
+const aliasVisible = "(a.created_by IS NULL OR a.created_by = q.user_id)"
+
+// bestMatches keeps the best matching name of each exercise.
+const bestMatches = "SELECT DISTINCT ON (exercise_id) exercise_id, matched, score FROM (" + nameMatches + ") m " +
+	"ORDER BY exercise_id, score DESC"
+
+func (r *repo) AddExerciseAlias(ctx context.Context, alias *model.ExerciseAlias) (int64, error) {
+	query, args, err := r.qb.
+		Insert("exercise_aliases").
+		Columns("exercise_id", "alias", "normalized", "created_by").
+		Values(alias.ExerciseID, alias.Alias, alias.Normalized, alias.CreatedBy).
+		Suffix("RETURNING id, created_at").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id, &alias.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, apperrors.ErrAliasAlreadyExists
+		}
+		return 0, fmt.Errorf("failed to insert exercise alias: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repo) GetExerciseAlias(ctx context.Context, aliasID int64) (*model.ExerciseAlias, error) {
+	query, args, err := r.qb.
+		Select("id", "exercise_id", "alias", "normalized", "created_by", "created_at").
+		From("exercise_aliases").
+		Where(squirrel.Eq{"id": aliasID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	alias, err := scanExerciseAlias(r.db.DB().QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrAliasNotFound
+		}
+		return nil, fmt.Errorf("failed to get exercise alias: %w", err)
+	}
+
+	return alias, nil
+}
+
+// ListExerciseAliases lists the built-in aliases of the exercise and the ones
+// the user added.
+func (r *repo) ListExerciseAliases(ctx context.Context, userID, exerciseID int64) ([]*model.ExerciseAlias, error) {
+	query, args, err := r.qb.
+		Select("id", "exercise_id", "alias", "normalized", "created_by", "created_at").
+		From("exercise_aliases").
+		Where(squirrel.Eq{"exercise_id": exerciseID}).
+		Where(squirrel.Or{squirrel.Eq{"created_by": nil}, squirrel.Eq{"created_by": userID}}).
+		OrderBy("id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exercise aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []*model.ExerciseAlias
+	for rows.Next() {
+		alias, err := scanExerciseAlias(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exercise alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exercise aliases: %w", err)
+	}
+
+	return aliases, nil
+}
+
+func (r *repo) DeleteExerciseAlias(ctx context.Context, aliasID int64) error {
+	query, args, err := r.qb.
+		Delete("exercise_aliases").
+		Where(squirrel.Eq{"id": aliasID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete exercise alias: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrAliasNotFound
+	}
+
+	return nil
+}
+
+// ResolveExercises ranks the exercises whose names or aliases are similar to
+// q.Text, best first, with the names translated to q.Locale. Only the
+// built-in aliases and the ones q.UserID added are matched.
+func (r *repo) ResolveExercises(ctx context.Context, q *model.ResolveQuery) ([]*model.ExerciseCandidate, error) {
+	query, args, err := r.selectExercises(q.Locale).
+		Prefix("WITH q AS (SELECT ?::text AS text, ?::int AS user_id)", q.Text, q.UserID).
+		Columns("c.matched", "c.score::float8").
+		Join("("+bestMatches+") c ON c.exercise_id = e.id").
+		OrderBy("c.score DESC", localizedName, "e.id").
+		Limit(q.Limit).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve exercises: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []*model.ExerciseCandidate
+	for rows.Next() {
+		var c model.ExerciseCandidate
+		c.Exercise, err = scanExercise(rows, &c.Matched, &c.Score)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exercise candidate: %w", err)
+		}
+		candidates = append(candidates, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exercise candidates: %w", err)
+	}
+
+	return candidates, nil
+}
+
+func scanExerciseAlias(row pgx.Row) (*model.ExerciseAlias, error) {
+	var alias model.ExerciseAlias
+	err := row.Scan(&alias.ID, &alias.ExerciseID, &alias.Alias, &alias.Normalized, &alias.CreatedBy, &alias.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &alias, nil
+}
@@ -0,0 +1,147 @@
+package workout
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func (r *repo) ListEquipment(ctx context.Context) ([]*model.Equipment, error) {
+	query, args, err := r.qb.
+		Select("id", "code", "name").
+		From("equipment").
+		OrderBy("id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list equipment: %w", err)
+	}
+	defer rows.Close()
+
+	var equipment []*model.Equipment
+	for rows.Next() {
+		var eq model.Equipment
+		if err = rows.Scan(&eq.ID, &eq.Code, &eq.Name); err != nil {
+			return nil, fmt.Errorf("failed to scan equipment: %w", err)
+		}
+		equipment = append(equipment, &eq)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate equipment: %w", err)
+	}
+
+	return equipment, nil
+}
+
+func (r *repo) ListMuscles(ctx context.Context) ([]*model.Muscle, error) {
+	query, args, err := r.qb.
+		Select("id", "code", "name", "muscle_group").
+		From("muscles").
+		OrderBy("id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list muscles: %w", err)
+	}
+	defer rows.Close()
+
+	var muscles []*model.Muscle
+	for rows.Next() {
+		var m model.Muscle
+		if err = rows.Scan(&m.ID, &m.Code, &m.Name, &m.MuscleGroup); err != nil {
+			return nil, fmt.Errorf("failed to scan muscle: %w", err)
+		}
+		muscles = append(muscles, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate muscles: %w", err)
+	}
+
+	return muscles, nil
+}
+
+// ListExerciseMuscles returns the primary and secondary muscles of the
+// exercises, primary ones first.
+func (r *repo) ListExerciseMuscles(ctx context.Context, exerciseIDs []int64) ([]*model.ExerciseMuscle, error) {
+	if len(exerciseIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := r.qb.
+		Select("em.exercise_id", "em.is_primary", "m.id", "m.code", "m.name", "m.muscle_group").
+		From("exercise_muscles em").
+		Join("muscles m ON m.id = em.muscle_id").
+		Where(squirrel.Eq{"em.exercise_id": exerciseIDs}).
+		OrderBy("em.exercise_id", "em.is_primary DESC", "m.id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exercise muscles: %w", err)
+	}
+	defer rows.Close()
+
+	var muscles []*model.ExerciseMuscle
+	for rows.Next() {
+		var (
+			em model.ExerciseMuscle
+			m  model.Muscle
+		)
+		if err = rows.Scan(&em.ExerciseID, &em.Primary, &m.ID, &m.Code, &m.Name, &m.MuscleGroup); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise muscle: %w", err)
+		}
+		em.Muscle = &m
+		muscles = append(muscles, &em)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exercise muscles: %w", err)
+	}
+
+	return muscles, nil
+}
+
+// ListExerciseTranslations returns the translations of the exercises to the
+// locale; exercises without one are left out.
+func (r *repo) ListExerciseTranslations(ctx context.Context, locale string, exerciseIDs []int64) ([]*model.ExerciseTranslation, error) {
+	if len(exerciseIDs) == 0 {
+		return nil, nil
+	}
+
+	query, args, err := r.qb.
+		Select("exercise_id", "locale", "name", "description").
+		From("exercise_translations").
+		Where(squirrel.Eq{"locale": locale, "exercise_id": exerciseIDs}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exercise translations: %w", err)
+	}
+	defer rows.Close()
+
+	var translations []*model.ExerciseTranslation
+	for rows.Next() {
+		var t model.ExerciseTranslation
+		if err = rows.Scan(&t.ExerciseID, &t.Locale, &t.Name, &t.Description); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise translation: %w", err)
+		}
+		translations = append(translations, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exercise translations: %w", err)
+	}
+
+	return translations, nil
+}
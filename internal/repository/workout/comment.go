@@ -0,0 +1,62 @@
+package workout
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func (r *repo) AddWorkoutComment(ctx context.Context, comment *model.WorkoutComment) (int64, error) {
+	query, args, err := r.qb.
+		Insert("workout_comments").
+		Columns("workout_id", "author_id", "body").
+		Values(comment.WorkoutID, comment.AuthorID, comment.Body).
+		Suffix("RETURNING id, created_at").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id, &comment.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert workout comment: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListWorkoutComments returns the comments on the workout, oldest first, with
+// the names of their authors.
+func (r *repo) ListWorkoutComments(ctx context.Context, workoutID int64) ([]*model.WorkoutComment, error) {
+	query, args, err := r.qb.
+		Select("wc.id", "wc.workout_id", "wc.author_id", "u.name", "wc.body", "wc.created_at").
+		From("workout_comments wc").
+		Join("users u ON u.id = wc.author_id").
+		Where(squirrel.Eq{"wc.workout_id": workoutID}).
+		OrderBy("wc.created_at", "wc.id").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workout comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*model.WorkoutComment
+	for rows.Next() {
+		var c model.WorkoutComment
+		if err = rows.Scan(&c.ID, &c.WorkoutID, &c.AuthorID, &c.AuthorName, &c.Body, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan workout comment: %w", err)
+		}
+		comments = append(comments, &c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate workout comments: %w", err)
+	}
+
+	return comments, nil
+}
@@ -2,7 +2,9 @@ package workout
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"strings"
 
 	apperrors "github.com/biryanim/workoutbook/internal/errors"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/biryanim/workoutbook/internal/model"
 	"github.com/biryanim/workoutbook/internal/repository"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pkg/errors"
 )
 
@@ -31,8 +34,8 @@ func NewRepository(db db.Client) *repo {
 func (r *repo) CreateWorkout(ctx context.Context, workout *model.Workout) (int64, error) {
 	query, args, err := r.qb.
 		Insert("workouts").
-		Columns("user_id", "date", "name", "notes").
-		Values(workout.UserID, workout.Date, workout.Name, workout.Notes).
+		Columns("user_id", "date", "name", "notes", "started_at", "ended_at", "session_rpe", "assigned_by").
+		Values(workout.UserID, workout.Date, workout.Name, workout.Notes, workout.StartedAt, workout.EndedAt, workout.SessionRPE, workout.AssignedBy).
 		Suffix("RETURNING id").ToSql()
 	if err != nil {
 		return 0, fmt.Errorf("failed to build insert query: %w", err)
@@ -47,11 +50,38 @@ func (r *repo) CreateWorkout(ctx context.Context, workout *model.Workout) (int64
 	return id, nil
 }
 
-func (r *repo) GetWorkoutByID(ctx context.Context, workoutID, userId int64) (*model.Workout, error) {
+// accessibleBy keeps the workouts of the user and, unless permission is
+// model.OwnerOnly, the ones of the athletes whose accepted coach the user is
+// with a permission that includes it. Coaches only change the workouts they
+// assigned, and only until the athlete starts them.
+func (r *repo) accessibleBy(userID int64, permission string) squirrel.Sqlizer {
+	grants := model.Grants(permission)
+	if len(grants) == 0 {
+		return squirrel.Eq{"user_id": userID}
+	}
+
+	// nested with ? placeholders, numbered along with the outer query
+	athletes := squirrel.
+		Select("athlete_id").
+		From("coach_athletes").
+		Where(squirrel.Eq{"coach_id": userID, "status": model.CoachingActive, "permission": grants})
+
+	coached := squirrel.And{squirrel.Expr("user_id IN (?)", athletes)}
+	if permission == model.PermissionAssign {
+		coached = append(coached, squirrel.Eq{"assigned_by": userID, "started_at": nil})
+	}
+
+	return squirrel.Or{squirrel.Eq{"user_id": userID}, coached}
+}
+
+// GetWorkoutByID returns the workout if the user owns it or coaches its owner
+// with the permission.
+func (r *repo) GetWorkoutByID(ctx context.Context, workoutID, userId int64, permission string) (*model.Workout, error) {
 	query, args, err := r.qb.
-		Select("id", "user_id", "date", "notes", "name", "created_at", "updated_at").
+		Select("id", "uuid", "user_id", "date", "notes", "name", "started_at", "ended_at", "session_rpe", "assigned_by", "created_at", "updated_at").
 		From("workouts").
-		Where(squirrel.Eq{"id": workoutID, "user_id": userId}).ToSql()
+		Where(squirrel.Eq{"id": workoutID}).
+		Where(r.accessibleBy(userId, permission)).ToSql()
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to build select query: %w", err)
@@ -60,38 +90,127 @@ func (r *repo) GetWorkoutByID(ctx context.Context, workoutID, userId int64) (*mo
 	var workout model.Workout
 	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(
 		&workout.ID,
+		&workout.UUID,
 		&workout.UserID,
 		&workout.Date,
 		&workout.Notes,
 		&workout.Name,
+		&workout.StartedAt,
+		&workout.EndedAt,
+		&workout.SessionRPE,
+		&workout.AssignedBy,
 		&workout.CreatedAt,
 		&workout.UpdatedAt,
 	)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, apperrors.ErrTaskNotFound
+			return nil, apperrors.ErrWorkoutNotFound
 		}
+		return nil, fmt.Errorf("failed to get workout: %w", err)
 	}
 	return &workout, nil
 }
 
-func (r *repo) ListWorkouts(ctx context.Context, userId int64, filter *model.WorkoutsFilter) ([]*model.Workout, error) {
-	builder := r.qb.Select("id", "user_id", "date", "notes", "name", "created_at", "updated_at").
-		From("workouts").
-		Where(squirrel.Eq{"user_id": userId}).
-		OrderBy(
-			"date DESC",
-		).
-		Limit(filter.Limit).
-		Offset(filter.Offset)
+// GetLastSetTime returns when the latest live-logged set of the workout was
+// completed; it is not valid before the first one.
+func (r *repo) GetLastSetTime(ctx context.Context, workoutID int64) (sql.NullTime, error) {
+	query, args, err := r.qb.
+		Select("MAX(completed_at)").
+		From("workout_exercises").
+		Where(squirrel.Eq{"workout_id": workoutID}).ToSql()
+	if err != nil {
+		return sql.NullTime{}, fmt.Errorf("failed to build select query: %w", err)
+	}
 
-	if !filter.StartDate.IsZero() {
-		builder = builder.Where(squirrel.GtOrEq{"created_at": filter.StartDate})
+	var last sql.NullTime
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&last)
+	if err != nil {
+		return sql.NullTime{}, fmt.Errorf("failed to get last set time: %w", err)
+	}
+
+	return last, nil
+}
+
+func (r *repo) UpdateWorkout(ctx context.Context, params *model.UpdateWorkoutParams) error {
+	builder := r.qb.
+		Update("workouts").
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": params.ID, "user_id": params.UserID})
+
+	if params.Date != nil {
+		builder = builder.Set("date", *params.Date)
+	}
+	if params.Name != nil {
+		builder = builder.Set("name", *params.Name)
+	}
+	if params.Notes != nil {
+		builder = builder.Set("notes", *params.Notes)
+	}
+	if params.StartedAt != nil {
+		builder = builder.Set("started_at", *params.StartedAt)
+	}
+	if params.EndedAt != nil {
+		builder = builder.Set("ended_at", *params.EndedAt)
+	}
+	if params.SessionRPE != nil {
+		builder = builder.Set("session_rpe", *params.SessionRPE)
 	}
 
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update workout: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrWorkoutNotFound
+	}
+
+	return nil
+}
+
+// applyWorkoutsFilter narrows a query over "workouts w" down to the workouts
+// matching the filter; the page cursor is left to the caller.
+func applyWorkoutsFilter(builder squirrel.SelectBuilder, userId int64, filter *model.WorkoutsFilter) squirrel.SelectBuilder {
+	builder = builder.Where(squirrel.Eq{"w.user_id": userId})
+
+	if !filter.StartDate.IsZero() {
+		builder = builder.Where(squirrel.GtOrEq{"w.date": filter.StartDate})
+	}
 	if !filter.EndDate.IsZero() {
-		builder = builder.Where(squirrel.LtOrEq{"created_at": filter.EndDate})
+		builder = builder.Where(squirrel.LtOrEq{"w.date": filter.EndDate})
+	}
+	if filter.ExerciseID != 0 {
+		builder = builder.Where("EXISTS (SELECT 1 FROM workout_exercises we WHERE we.workout_id = w.id AND we.exercise_id = ?)", filter.ExerciseID)
+	}
+	if filter.MuscleGroup != "" {
+		builder = builder.Where("EXISTS (SELECT 1 FROM workout_exercises we JOIN exercises e ON e.id = we.exercise_id WHERE we.workout_id = w.id AND e.muscle_group = ?)", filter.MuscleGroup)
+	}
+	if filter.Search != "" {
+		builder = builder.Where(squirrel.ILike{"w.name": "%" + likeEscaper.Replace(filter.Search) + "%"})
+	}
+
+	return repository.ApplyTagFilter(builder, "w.id", filter.Tags)
+}
+
+// likeEscaper makes user input match literally in LIKE patterns.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ListWorkouts returns the filtered workouts from the newest back, starting
+// after filter.After.
+func (r *repo) ListWorkouts(ctx context.Context, userId int64, filter *model.WorkoutsFilter) ([]*model.Workout, error) {
+	builder := r.qb.Select("w.id", "w.uuid", "w.user_id", "w.date", "w.notes", "w.name", "w.started_at", "w.ended_at", "w.session_rpe", "w.assigned_by", "w.created_at", "w.updated_at").
+		From("workouts w").
+		OrderBy("w.date DESC", "w.id DESC").
+		Limit(filter.Limit)
+	builder = applyWorkoutsFilter(builder, userId, filter)
+
+	if filter.After != nil {
+		builder = builder.Where("(w.date, w.id) < (?, ?)", filter.After.Date, filter.After.ID)
 	}
 
 	query, args, err := builder.ToSql()
@@ -110,10 +229,15 @@ func (r *repo) ListWorkouts(ctx context.Context, userId int64, filter *model.Wor
 		var workout model.Workout
 		err = rows.Scan(
 			&workout.ID,
+			&workout.UUID,
 			&workout.UserID,
 			&workout.Date,
 			&workout.Notes,
 			&workout.Name,
+			&workout.StartedAt,
+			&workout.EndedAt,
+			&workout.SessionRPE,
+			&workout.AssignedBy,
 			&workout.CreatedAt,
 			&workout.UpdatedAt,
 		)
@@ -130,10 +254,45 @@ func (r *repo) ListWorkouts(ctx context.Context, userId int64, filter *model.Wor
 	return workouts, nil
 }
 
+func (r *repo) CountWorkouts(ctx context.Context, userId int64, filter *model.WorkoutsFilter) (int64, error) {
+	builder := applyWorkoutsFilter(r.qb.Select("count(*)").From("workouts w"), userId, filter)
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var count int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count workouts: %w", err)
+	}
+
+	return count, nil
+}
+
+// AddWorkoutExercise appends the exercise to the workout, or inserts it at
+// we.Position moving the exercises from there on one place down.
 func (r *repo) AddWorkoutExercise(ctx context.Context, we *model.WorkoutExercise) (int64, error) {
+	var position interface{} = squirrel.Expr("(SELECT COALESCE(MAX(position), 0) + 1 FROM workout_exercises WHERE workout_id = ?)", we.WorkoutID)
+	if we.Position > 0 {
+		query, args, err := r.qb.Update("workout_exercises").
+			Set("position", squirrel.Expr("position + 1")).
+			Where(squirrel.Eq{"workout_id": we.WorkoutID}).
+			Where(squirrel.GtOrEq{"position": we.Position}).ToSql()
+		if err != nil {
+			return 0, fmt.Errorf("failed to build update query: %w", err)
+		}
+
+		if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+			return 0, fmt.Errorf("failed to shift workout exercises: %w", err)
+		}
+		position = we.Position
+	}
+
 	query, args, err := r.qb.Insert("workout_exercises").
-		Columns("workout_id", "exercise_id", "sets", "reps", "weight", "duration", "distance").
-		Values(we.WorkoutID, we.ExerciseID, we.Sets, we.Reps, we.Weight, we.Duration, we.Distance).
+		Columns("workout_id", "exercise_id", "sets", "reps", "weight", "duration", "distance", "rpe", "rir", "target_reps", "is_warmup", "is_planned", "avg_heart_rate", "max_heart_rate", "elevation_gain", "position", "group_id", "completed_at", "rest_seconds").
+		Values(we.WorkoutID, we.ExerciseID, we.Sets, we.Reps, we.Weight, we.Duration, we.Distance, we.RPE, we.RIR, we.TargetReps, we.IsWarmUp, we.Planned, we.AvgHeartRate, we.MaxHeartRate, we.ElevationGain, position, we.GroupID, we.CompletedAt, we.RestSeconds).
 		Suffix("RETURNING id").ToSql()
 
 	if err != nil {
@@ -151,10 +310,11 @@ func (r *repo) AddWorkoutExercise(ctx context.Context, we *model.WorkoutExercise
 
 func (r *repo) GetExercisesByWorkoutID(ctx context.Context, workoutID int64) ([]*model.WorkoutExercise, error) {
 	query, args, err := r.qb.
-		Select("we.id", "we.workout_id", "we.exercise_id", "we.sets", "we.reps", "we.weight", "we.duration", "we.distance", "e.name", "e.type", "e.muscle_group", "e.description").
+		Select("we.id", "we.uuid", "we.workout_id", "we.exercise_id", "we.sets", "we.reps", "we.weight", "we.duration", "we.distance", "we.rpe", "we.rir", "we.target_reps", "we.is_warmup", "we.is_planned", "we.avg_heart_rate", "we.max_heart_rate", "we.elevation_gain", "we.position", "we.group_id", "we.completed_at", "we.rest_seconds", "e.name", "e.type", "e.muscle_group", "e.description", "e.met", "e.tracking_profile").
 		From("workout_exercises we").
 		Join("exercises e ON we.exercise_id = e.id").
-		Where(squirrel.Eq{"we.workout_id": workoutID}).ToSql()
+		Where(squirrel.Eq{"we.workout_id": workoutID}).
+		OrderBy("we.position", "we.id").ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build select query: %w", err)
 	}
@@ -170,6 +330,7 @@ func (r *repo) GetExercisesByWorkoutID(ctx context.Context, workoutID int64) ([]
 		var exercise model.WorkoutExercise
 		err = rows.Scan(
 			&exercise.ID,
+			&exercise.UUID,
 			&exercise.WorkoutID,
 			&exercise.ExerciseID,
 			&exercise.Sets,
@@ -177,11 +338,29 @@ func (r *repo) GetExercisesByWorkoutID(ctx context.Context, workoutID int64) ([]
 			&exercise.Weight,
 			&exercise.Duration,
 			&exercise.Distance,
+			&exercise.RPE,
+			&exercise.RIR,
+			&exercise.TargetReps,
+			&exercise.IsWarmUp,
+			&exercise.Planned,
+			&exercise.AvgHeartRate,
+			&exercise.MaxHeartRate,
+			&exercise.ElevationGain,
+			&exercise.Position,
+			&exercise.GroupID,
+			&exercise.CompletedAt,
+			&exercise.RestSeconds,
 			&exercise.Exercise.Name,
 			&exercise.Exercise.Type,
 			&exercise.Exercise.MuscleGroup,
 			&exercise.Exercise.Description,
+			&exercise.Exercise.MET,
+			&exercise.Exercise.TrackingProfile,
 		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan workout exercise: %w", err)
+		}
+		exercise.Exercise.ID = exercise.ExerciseID
 
 		exercises = append(exercises, &exercise)
 	}
@@ -192,11 +371,311 @@ func (r *repo) GetExercisesByWorkoutID(ctx context.Context, workoutID int64) ([]
 	return exercises, nil
 }
 
-func (r *repo) IsUserHaveWorkout(ctx context.Context, userId, workoutId int64) (bool, error) {
+// GetPlannedEntry returns the first entry of the exercise in the workout that
+// is still planned.
+func (r *repo) GetPlannedEntry(ctx context.Context, workoutID, exerciseID int64) (*model.WorkoutExercise, error) {
+	query, args, err := r.qb.
+		Select("id", "workout_id", "exercise_id", "sets", "reps", "weight", "position", "group_id").
+		From("workout_exercises").
+		Where(squirrel.Eq{"workout_id": workoutID, "exercise_id": exerciseID, "is_planned": true}).
+		OrderBy("position", "id").
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	we := model.WorkoutExercise{Planned: true}
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(
+		&we.ID,
+		&we.WorkoutID,
+		&we.ExerciseID,
+		&we.Sets,
+		&we.Reps,
+		&we.Weight,
+		&we.Position,
+		&we.GroupID,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to get planned entry: %w", err)
+	}
+
+	return &we, nil
+}
+
+// UpdatePlannedSets sets how many sets of a planned entry are left to do.
+func (r *repo) UpdatePlannedSets(ctx context.Context, workoutExerciseID int64, sets int) error {
+	query, args, err := r.qb.
+		Update("workout_exercises").
+		Set("sets", sets).
+		Where(squirrel.Eq{"id": workoutExerciseID, "is_planned": true}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update planned sets: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrEntryNotFound
+	}
+
+	return nil
+}
+
+// PerformPlannedEntry records the planned entry we.ID as done with the
+// metrics of we, keeping its place in the workout.
+func (r *repo) PerformPlannedEntry(ctx context.Context, we *model.WorkoutExercise) error {
+	query, args, err := r.qb.
+		Update("workout_exercises").
+		Set("sets", we.Sets).
+		Set("reps", we.Reps).
+		Set("weight", we.Weight).
+		Set("duration", we.Duration).
+		Set("distance", we.Distance).
+		Set("rpe", we.RPE).
+		Set("rir", we.RIR).
+		Set("target_reps", we.TargetReps).
+		Set("is_warmup", we.IsWarmUp).
+		Set("is_planned", false).
+		Set("avg_heart_rate", we.AvgHeartRate).
+		Set("max_heart_rate", we.MaxHeartRate).
+		Set("elevation_gain", we.ElevationGain).
+		Set("group_id", we.GroupID).
+		Set("completed_at", we.CompletedAt).
+		Set("rest_seconds", we.RestSeconds).
+		Where(squirrel.Eq{"id": we.ID, "workout_id": we.WorkoutID, "is_planned": true}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to perform planned entry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrEntryNotFound
+	}
+
+	return nil
+}
+
+func (r *repo) AddSplits(ctx context.Context, splits []*model.Split) error {
+	if len(splits) == 0 {
+		return nil
+	}
+
+	builder := r.qb.Insert("workout_exercise_splits").
+		Columns("workout_exercise_id", "split_number", "distance", "duration", "avg_heart_rate", "elevation_gain")
+	for _, sp := range splits {
+		builder = builder.Values(sp.WorkoutExerciseID, sp.Number, sp.Distance, sp.Duration, sp.AvgHeartRate, sp.ElevationGain)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	_, err = r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to insert splits: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) GetSplitsByWorkoutID(ctx context.Context, workoutID int64) ([]*model.Split, error) {
+	query, args, err := r.qb.
+		Select("s.id", "s.workout_exercise_id", "s.split_number", "s.distance", "s.duration", "s.avg_heart_rate", "s.elevation_gain").
+		From("workout_exercise_splits s").
+		Join("workout_exercises we ON we.id = s.workout_exercise_id").
+		Where(squirrel.Eq{"we.workout_id": workoutID}).
+		OrderBy("s.workout_exercise_id", "s.split_number").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list splits: %w", err)
+	}
+	defer rows.Close()
+
+	var splits []*model.Split
+	for rows.Next() {
+		var sp model.Split
+		err = rows.Scan(&sp.ID, &sp.WorkoutExerciseID, &sp.Number, &sp.Distance, &sp.Duration, &sp.AvgHeartRate, &sp.ElevationGain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan split: %w", err)
+		}
+		splits = append(splits, &sp)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate splits: %w", err)
+	}
+
+	return splits, nil
+}
+
+func (r *repo) GetMappedExerciseID(ctx context.Context, source, externalName string) (int64, error) {
+	query, args, err := r.qb.
+		Select("exercise_id").
+		From("exercise_import_mappings").
+		Where(squirrel.Eq{"source": source}).
+		Where("lower(external_name) = lower(?)", externalName).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, apperrors.ErrExerciseNotMapped
+		}
+		return 0, fmt.Errorf("failed to get exercise mapping: %w", err)
+	}
+
+	return id, nil
+}
+
+// ListExerciseMappings lists the mappings of source, of every source when it
+// is empty.
+func (r *repo) ListExerciseMappings(ctx context.Context, source string) ([]*model.ExerciseMapping, error) {
+	builder := r.qb.
+		Select("source", "external_name", "exercise_id").
+		From("exercise_import_mappings").
+		OrderBy("source", "external_name")
+	if source != "" {
+		builder = builder.Where(squirrel.Eq{"source": source})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exercise mappings: %w", err)
+	}
+	defer rows.Close()
+
+	var mappings []*model.ExerciseMapping
+	for rows.Next() {
+		var m model.ExerciseMapping
+		if err = rows.Scan(&m.Source, &m.ExternalName, &m.ExerciseID); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise mapping: %w", err)
+		}
+		mappings = append(mappings, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exercise mappings: %w", err)
+	}
+
+	return mappings, nil
+}
+
+// SetExerciseMapping maps the external name onto the exercise, replacing the
+// exercise it was mapped onto before.
+func (r *repo) SetExerciseMapping(ctx context.Context, mapping *model.ExerciseMapping) error {
+	query, args, err := r.qb.
+		Insert("exercise_import_mappings").
+		Columns("source", "external_name", "exercise_id").
+		Values(mapping.Source, mapping.ExternalName, mapping.ExerciseID).
+		Suffix("ON CONFLICT (source, external_name) DO UPDATE SET exercise_id = EXCLUDED.exercise_id").ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to set exercise mapping: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) DeleteExerciseMapping(ctx context.Context, source, externalName string) error {
+	query, args, err := r.qb.
+		Delete("exercise_import_mappings").
+		Where(squirrel.Eq{"source": source}).
+		Where("lower(external_name) = lower(?)", externalName).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete exercise mapping: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrMappingNotFound
+	}
+
+	return nil
+}
+
+func (r *repo) AddTrack(ctx context.Context, track *model.WorkoutTrack) (int64, error) {
+	query, args, err := r.qb.
+		Insert("workout_tracks").
+		Columns("workout_id", "workout_exercise_id", "format", "raw").
+		Values(track.WorkoutID, track.WorkoutExerciseID, track.Format, track.Raw).
+		Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert track: %w", err)
+	}
+
+	return id, nil
+}
+
+// samplesBatchSize keeps a single insert well below the postgres limit of
+// 65535 bind parameters.
+const samplesBatchSize = 1000
+
+func (r *repo) AddSamples(ctx context.Context, workoutExerciseID int64, points []*model.TrackPoint) error {
+	for start := 0; start < len(points); start += samplesBatchSize {
+		end := min(start+samplesBatchSize, len(points))
+
+		builder := r.qb.Insert("workout_exercise_samples").
+			Columns("workout_exercise_id", "recorded_at", "latitude", "longitude", "elevation", "heart_rate", "distance")
+		for _, p := range points[start:end] {
+			var lat, lon sql.NullFloat64
+			if p.HasPosition {
+				lat = sql.NullFloat64{Float64: p.Latitude, Valid: true}
+				lon = sql.NullFloat64{Float64: p.Longitude, Valid: true}
+			}
+			builder = builder.Values(workoutExerciseID, p.Time, lat, lon, p.Elevation, p.HeartRate, p.Distance)
+		}
+
+		query, args, err := builder.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert query: %w", err)
+		}
+
+		_, err = r.db.DB().ExecContext(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("failed to insert samples: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *repo) IsUserHaveWorkout(ctx context.Context, userId, workoutId int64, permission string) (bool, error) {
 	query, args, err := r.qb.
 		Select("count(*)").
 		From("workouts").
-		Where(squirrel.Eq{"id": workoutId, "user_id": userId}).ToSql()
+		Where(squirrel.Eq{"id": workoutId}).
+		Where(r.accessibleBy(userId, permission)).ToSql()
 	if err != nil {
 		return false, fmt.Errorf("failed to build select query: %w", err)
 	}
@@ -210,11 +689,112 @@ func (r *repo) IsUserHaveWorkout(ctx context.Context, userId, workoutId int64) (
 	return count > 0, nil
 }
 
-func (r *repo) GetExercises(ctx context.Context, exerciseType string) ([]*model.Exercise, error) {
-	builder := r.qb.Select("id", "name", "type", "muscle_group", "description").
-		From("exercises")
-	if exerciseType != "" {
-		builder = builder.Where("type = ?", exerciseType)
+// localizedName and localizedDescription fall back to the catalog's own text
+// when the exercise has no translation to the requested locale.
+const (
+	localizedName        = "COALESCE(t.name, e.name)"
+	localizedDescription = "COALESCE(t.description, e.description)"
+)
+
+// exerciseColumns are the catalog columns of selectExercises, scanned by
+// scanExercise.
+var exerciseColumns = []string{
+	"e.id", localizedName, "e.type", "e.muscle_group", localizedDescription, "e.lift", "e.met", "e.load_increment", "e.tracking_profile",
+	"eq.id", "eq.code", "eq.name", "e.movement_pattern", "e.mechanics", "e.unilateral",
+}
+
+// selectExercises selects the catalog with the names and descriptions
+// translated to locale; an empty locale keeps the built-in ones.
+func (r *repo) selectExercises(locale string) squirrel.SelectBuilder {
+	return r.qb.Select(exerciseColumns...).
+		From("exercises e").
+		LeftJoin("equipment eq ON eq.id = e.equipment_id").
+		LeftJoin("exercise_translations t ON t.exercise_id = e.id AND t.locale = ?", locale)
+}
+
+// scanExercise scans exerciseColumns and then any extra columns into extra.
+func scanExercise(row pgx.Row, extra ...any) (*model.Exercise, error) {
+	var (
+		exercise      model.Exercise
+		equipmentID   sql.NullInt64
+		equipmentCode sql.NullString
+		equipmentName sql.NullString
+	)
+	dest := []any{
+		&exercise.ID,
+		&exercise.Name,
+		&exercise.Type,
+		&exercise.MuscleGroup,
+		&exercise.Description,
+		&exercise.Lift,
+		&exercise.MET,
+		&exercise.LoadIncrement,
+		&exercise.TrackingProfile,
+		&equipmentID,
+		&equipmentCode,
+		&equipmentName,
+		&exercise.MovementPattern,
+		&exercise.Mechanics,
+		&exercise.Unilateral,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	if equipmentID.Valid {
+		exercise.Equipment = &model.Equipment{
+			ID:   equipmentID.Int64,
+			Code: equipmentCode.String,
+			Name: equipmentName.String,
+		}
+	}
+
+	return &exercise, nil
+}
+
+func applyExercisesFilter(builder squirrel.SelectBuilder, filter *model.ExercisesFilter) squirrel.SelectBuilder {
+	if filter.Type != "" {
+		builder = builder.Where(squirrel.Eq{"e.type": filter.Type})
+	}
+	if filter.MuscleGroup != "" {
+		builder = builder.Where(squirrel.Eq{"e.muscle_group": filter.MuscleGroup})
+	}
+	if filter.Equipment != "" {
+		builder = builder.Where("e.equipment_id = (SELECT id FROM equipment WHERE code = ?)", filter.Equipment)
+	}
+	if filter.PrimaryMuscle != "" {
+		builder = builder.Where("EXISTS (SELECT 1 FROM exercise_muscles em JOIN muscles m ON m.id = em.muscle_id WHERE em.exercise_id = e.id AND em.is_primary AND m.code = ?)", filter.PrimaryMuscle)
+	}
+	if filter.SecondaryMuscle != "" {
+		builder = builder.Where("EXISTS (SELECT 1 FROM exercise_muscles em JOIN muscles m ON m.id = em.muscle_id WHERE em.exercise_id = e.id AND NOT em.is_primary AND m.code = ?)", filter.SecondaryMuscle)
+	}
+	if filter.MovementPattern != "" {
+		builder = builder.Where(squirrel.Eq{"e.movement_pattern": filter.MovementPattern})
+	}
+	if filter.Mechanics != "" {
+		builder = builder.Where(squirrel.Eq{"e.mechanics": filter.Mechanics})
+	}
+	if filter.Unilateral != nil {
+		builder = builder.Where(squirrel.Eq{"e.unilateral": *filter.Unilateral})
+	}
+	if filter.TrackingProfile != "" {
+		builder = builder.Where(squirrel.Eq{"e.tracking_profile": filter.TrackingProfile})
+	}
+	return builder
+}
+
+// GetExercises returns the catalog ordered by its name in filter.Locale,
+// starting after filter.After.
+func (r *repo) GetExercises(ctx context.Context, filter *model.ExercisesFilter) ([]*model.Exercise, error) {
+	builder := r.selectExercises(filter.Locale).
+		OrderBy(localizedName, "e.id")
+	builder = applyExercisesFilter(builder, filter)
+	if filter.Limit > 0 {
+		builder = builder.Limit(filter.Limit)
+	}
+	if filter.After != nil {
+		builder = builder.Where("("+localizedName+", e.id) > (?, ?)", filter.After.Name, filter.After.ID)
 	}
 	query, args, err := builder.ToSql()
 	if err != nil {
@@ -223,41 +803,49 @@ func (r *repo) GetExercises(ctx context.Context, exerciseType string) ([]*model.
 
 	rows, err := r.db.DB().QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list workouts: %w", err)
+		return nil, fmt.Errorf("failed to list exercises: %w", err)
 	}
 	defer rows.Close()
 
 	var exercises []*model.Exercise
 
 	for rows.Next() {
-		var exercise model.Exercise
-		err = rows.Scan(
-			&exercise.ID,
-			&exercise.Name,
-			&exercise.Type,
-			&exercise.MuscleGroup,
-			&exercise.Description,
-		)
+		exercise, err := scanExercise(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan workout: %w", err)
+			return nil, fmt.Errorf("failed to scan exercise: %w", err)
 		}
 
-		exercises = append(exercises, &exercise)
+		exercises = append(exercises, exercise)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("failed to iterate workouts: %w", err)
+		return nil, fmt.Errorf("failed to iterate exercises: %w", err)
 	}
 
 	return exercises, nil
 }
 
+func (r *repo) CountExercises(ctx context.Context, filter *model.ExercisesFilter) (int64, error) {
+	query, args, err := applyExercisesFilter(r.qb.Select("count(*)").From("exercises e"), filter).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var count int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count exercises: %w", err)
+	}
+
+	return count, nil
+}
+
 func (r *repo) AddRecord(ctx context.Context, user *model.UserRecord) (int64, error) {
 	query, args, err := r.qb.
 		Insert("personal_records").
-		Columns("user_id", "exercise_id", "weight", "reps", "date").
-		Values(user.UserID, user.ExerciseID, user.Weight, user.Reps, user.Date).
-		ToSql()
+		Columns("user_id", "exercise_id", "weight", "reps", "duration", "distance", "date").
+		Values(user.UserID, user.ExerciseID, user.Weight, user.Reps, user.Duration, user.Distance, user.Date).
+		Suffix("RETURNING id").ToSql()
 	if err != nil {
 		return 0, fmt.Errorf("failed to build insert query: %w", err)
 	}
@@ -273,7 +861,7 @@ func (r *repo) AddRecord(ctx context.Context, user *model.UserRecord) (int64, er
 
 func (r *repo) GetPersonalRecord(ctx context.Context, userID, exerciseID int64) (*model.UserRecord, error) {
 	query, args, err := r.qb.
-		Select("weight", "reps").
+		Select("weight", "reps", "duration", "distance").
 		From("personal_records").
 		Where(squirrel.Eq{"user_id": userID, "exercise_id": exerciseID}).ToSql()
 	if err != nil {
@@ -282,9 +870,12 @@ func (r *repo) GetPersonalRecord(ctx context.Context, userID, exerciseID int64)
 
 	var record model.UserRecord
 
-	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&record.Weight, &record.Reps)
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&record.Weight, &record.Reps, &record.Duration, &record.Distance)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list workouts: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to get record: %w", err)
 	}
 
 	return &record, nil
@@ -295,6 +886,8 @@ func (r *repo) UpdatePersonalRecord(ctx context.Context, user *model.UserRecord)
 		Update("personal_records").
 		Set("weight", user.Weight).
 		Set("reps", user.Reps).
+		Set("duration", user.Duration).
+		Set("distance", user.Distance).
 		Set("date", user.Date).
 		Where(squirrel.Eq{"user_id": user.UserID, "exercise_id": user.ExerciseID}).ToSql()
 	if err != nil {
@@ -310,10 +903,10 @@ func (r *repo) UpdatePersonalRecord(ctx context.Context, user *model.UserRecord)
 
 func (r *repo) ListRecords(ctx context.Context, userId int64) ([]*model.UserRecord, error) {
 	query, args, err := r.qb.
-		Select("pr.id", "pr.exercise_id", "pr.weight", "pr.reps", "pr.date", "e.name", "e.type", "e.muscle_group", "e.description").
+		Select("pr.id", "pr.exercise_id", "pr.weight", "pr.reps", "pr.duration", "pr.distance", "pr.date", "e.name", "e.type", "e.muscle_group", "e.description", "e.lift", "e.tracking_profile").
 		From("personal_records pr").
 		Join("exercises e ON pr.exercise_id = e.id").
-		Where(squirrel.Eq{"user_id": userId}).OrderBy("pr.date DESC").ToSql()
+		Where(squirrel.Eq{"pr.user_id": userId}).OrderBy("pr.date DESC").ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build select query: %w", err)
 	}
@@ -327,10 +920,11 @@ func (r *repo) ListRecords(ctx context.Context, userId int64) ([]*model.UserReco
 	var records []*model.UserRecord
 	for rows.Next() {
 		var record model.UserRecord
-		err = rows.Scan(&record.ID, &record.ExerciseID, &record.Weight, &record.Reps, &record.Date, &record.Exercise.Name, &record.Exercise.Type, &record.Exercise.MuscleGroup, &record.Exercise.Description)
+		err = rows.Scan(&record.ID, &record.ExerciseID, &record.Weight, &record.Reps, &record.Duration, &record.Distance, &record.Date, &record.Exercise.Name, &record.Exercise.Type, &record.Exercise.MuscleGroup, &record.Exercise.Description, &record.Exercise.Lift, &record.Exercise.TrackingProfile)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan records: %w", err)
 		}
+		record.Exercise.ID = record.ExerciseID
 		records = append(records, &record)
 	}
 	if err := rows.Err(); err != nil {
@@ -338,3 +932,218 @@ func (r *repo) ListRecords(ctx context.Context, userId int64) ([]*model.UserReco
 	}
 	return records, nil
 }
+
+// ListStrengthStandards returns the levels records are scored against,
+// ordered by exercise and rank: the user's own levels for the exercises they
+// set them for and the built-in ones for sex for the rest.
+func (r *repo) ListStrengthStandards(ctx context.Context, userID int64, sex string) ([]*model.StrengthStandard, error) {
+	custom, customArgs, err := squirrel.
+		Select("exercise_id", "'' AS sex", "level", "rank", "bodyweight_multiple", "TRUE AS custom").
+		From("user_strength_standards").
+		Where(squirrel.Eq{"user_id": userID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build custom standards subquery: %w", err)
+	}
+
+	query, args, err := r.qb.
+		Select("s.exercise_id", "s.sex", "s.level", "s.rank", "s.bodyweight_multiple", "FALSE AS custom").
+		From("strength_standards s").
+		Where(squirrel.Eq{"s.sex": sex}).
+		Where("NOT EXISTS (SELECT 1 FROM user_strength_standards u WHERE u.user_id = ? AND u.exercise_id = s.exercise_id)", userID).
+		Suffix("UNION ALL "+custom+" ORDER BY exercise_id, rank", customArgs...).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list strength standards: %w", err)
+	}
+	defer rows.Close()
+
+	var standards []*model.StrengthStandard
+	for rows.Next() {
+		var standard model.StrengthStandard
+		err = rows.Scan(&standard.ExerciseID, &standard.Sex, &standard.Level, &standard.Rank, &standard.BodyweightMultiple, &standard.Custom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan strength standard: %w", err)
+		}
+		standards = append(standards, &standard)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list strength standards: %w", err)
+	}
+	return standards, nil
+}
+
+// SaveStrengthStandards replaces the user's own levels for the exercise.
+func (r *repo) SaveStrengthStandards(ctx context.Context, userID, exerciseID int64, standards []*model.StrengthStandard) error {
+	err := r.DeleteStrengthStandards(ctx, userID, exerciseID)
+	if err != nil {
+		return err
+	}
+
+	builder := r.qb.
+		Insert("user_strength_standards").
+		Columns("user_id", "exercise_id", "level", "rank", "bodyweight_multiple")
+	for _, standard := range standards {
+		builder = builder.Values(userID, exerciseID, standard.Level, standard.Rank, standard.BodyweightMultiple)
+	}
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return errors.Wrap(apperrors.ErrInvalidStandards, "unknown exercise")
+		}
+		return fmt.Errorf("failed to save strength standards: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) DeleteStrengthStandards(ctx context.Context, userID, exerciseID int64) error {
+	query, args, err := r.qb.
+		Delete("user_strength_standards").
+		Where(squirrel.Eq{"user_id": userID, "exercise_id": exerciseID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete strength standards: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) GetExerciseByID(ctx context.Context, exerciseID int64) (*model.Exercise, error) {
+	query, args, err := r.selectExercises("").
+		Where(squirrel.Eq{"e.id": exerciseID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	exercise, err := scanExercise(r.db.DB().QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrExerciseNotFound
+		}
+		return nil, fmt.Errorf("failed to get exercise: %w", err)
+	}
+
+	return exercise, nil
+}
+
+// GetExerciseHistory returns the heaviest entry of the exercise in each of the
+// user's last limit workouts, newest first. Warm-ups and the sets planned but
+// not done yet are left out.
+func (r *repo) GetExerciseHistory(ctx context.Context, userID, exerciseID int64, limit uint64) ([]*model.ExerciseSession, error) {
+	query, args, err := r.qb.
+		Select("DISTINCT ON (w.date, w.id) w.id", "w.date", "we.sets", "we.reps", "we.weight", "COALESCE(we.target_reps, 0)").
+		From("workout_exercises we").
+		Join("workouts w ON w.id = we.workout_id").
+		Where(squirrel.Eq{"w.user_id": userID, "we.exercise_id": exerciseID, "we.is_warmup": false, "we.is_planned": false}).
+		OrderBy("w.date DESC", "w.id DESC", "we.weight DESC", "we.reps DESC").
+		Limit(limit).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exercise history: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*model.ExerciseSession
+	for rows.Next() {
+		var s model.ExerciseSession
+		if err = rows.Scan(&s.WorkoutID, &s.Date, &s.Sets, &s.Reps, &s.Weight, &s.TargetReps); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise session: %w", err)
+		}
+		sessions = append(sessions, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exercise history: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *repo) UpsertExerciseGroups(ctx context.Context, groups []*model.ExerciseGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	builder := r.qb.Insert("workout_exercise_groups").
+		Columns("workout_id", "group_id", "kind").
+		Suffix("ON CONFLICT (workout_id, group_id) DO UPDATE SET kind = EXCLUDED.kind")
+	for _, g := range groups {
+		builder = builder.Values(g.WorkoutID, g.GroupID, g.Kind)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save exercise groups: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) ListExerciseGroups(ctx context.Context, workoutID int64) ([]*model.ExerciseGroup, error) {
+	query, args, err := r.qb.
+		Select("workout_id", "group_id", "kind").
+		From("workout_exercise_groups").
+		Where(squirrel.Eq{"workout_id": workoutID}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exercise groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*model.ExerciseGroup
+	for rows.Next() {
+		var g model.ExerciseGroup
+		if err = rows.Scan(&g.WorkoutID, &g.GroupID, &g.Kind); err != nil {
+			return nil, fmt.Errorf("failed to scan exercise group: %w", err)
+		}
+		groups = append(groups, &g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate exercise groups: %w", err)
+	}
+
+	return groups, nil
+}
+
+// UpdateExerciseOrder numbers the workout's exercises from 1 in the given
+// order and regroups them.
+func (r *repo) UpdateExerciseOrder(ctx context.Context, workoutID int64, order []*model.ExerciseOrder) error {
+	for i, o := range order {
+		query, args, err := r.qb.Update("workout_exercises").
+			Set("position", i+1).
+			Set("group_id", o.GroupID).
+			Where(squirrel.Eq{"id": o.WorkoutExerciseID, "workout_id": workoutID}).ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build update query: %w", err)
+		}
+
+		if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to reorder workout exercises: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package workout
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+// substituteSource is the exercise to substitute with the number of its
+// primary muscles.
+const substituteSource = "WITH src AS (SELECT e.id, e.type, e.tracking_profile, e.movement_pattern, " +
+	"(SELECT count(*) FROM exercise_muscles em WHERE em.exercise_id = e.id AND em.is_primary) AS muscles " +
+	"FROM exercises e WHERE e.id = ?)"
+
+// sharedMuscles counts the primary muscles of e and the ones of them the
+// source exercise works as primary too.
+const sharedMuscles = "SELECT count(*) FILTER (WHERE em.muscle_id IN (" +
+	"SELECT muscle_id FROM exercise_muscles WHERE exercise_id = src.id AND is_primary)) AS shared, count(*) AS total " +
+	"FROM exercise_muscles em WHERE em.exercise_id = e.id AND em.is_primary"
+
+const samePattern = "coalesce(e.movement_pattern = src.movement_pattern, false)"
+
+// substituteScore weighs the overlap of the primary muscles, as the share of
+// the muscles either exercise works, above the shared movement pattern.
+const substituteScore = "(0.6 * pm.shared / (pm.total + src.muscles - pm.shared) + 0.4 * (" + samePattern + ")::int)::float8"
+
+// availableEquipment keeps the exercises the user has the equipment for.
+// Users who have not listed their equipment are assumed to have any.
+const availableEquipment = "(e.equipment_id IS NULL OR eq.code = ? " +
+	"OR NOT EXISTS (SELECT 1 FROM user_equipment WHERE user_id = ?) " +
+	"OR EXISTS (SELECT 1 FROM user_equipment ue WHERE ue.user_id = ? AND ue.equipment_id = e.equipment_id))"
+
+// ListSubstitutes ranks the exercises of the same type and tracking profile
+// that share a primary muscle with q.ExerciseID, best first, with the names
+// translated to q.Locale.
+func (r *repo) ListSubstitutes(ctx context.Context, q *model.SubstitutesQuery) ([]*model.Substitute, error) {
+	query, args, err := r.selectExercises(q.Locale).
+		Prefix(substituteSource, q.ExerciseID).
+		Columns("pm.shared", samePattern, substituteScore+" AS score").
+		Join("src ON src.id <> e.id AND src.type = e.type AND src.tracking_profile = e.tracking_profile").
+		JoinClause("CROSS JOIN LATERAL ("+sharedMuscles+") pm").
+		Where("pm.shared > 0").
+		Where(availableEquipment, model.EquipmentBodyweight, q.UserID, q.UserID).
+		OrderBy("score DESC", localizedName, "e.id").
+		Limit(q.Limit).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list substitutes: %w", err)
+	}
+	defer rows.Close()
+
+	var substitutes []*model.Substitute
+	for rows.Next() {
+		var sub model.Substitute
+		sub.Exercise, err = scanExercise(rows, &sub.SharedMuscles, &sub.SamePattern, &sub.Score)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan substitute: %w", err)
+		}
+		substitutes = append(substitutes, &sub)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate substitutes: %w", err)
+	}
+
+	return substitutes, nil
+}
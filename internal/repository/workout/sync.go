@@ -0,0 +1,379 @@
+package workout
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+)
+
+var (
+	syncWorkoutColumns = []string{"w.id", "w.uuid", "w.user_id", "w.date", "w.notes", "w.name", "w.started_at", "w.ended_at", "w.session_rpe", "w.client_updated_at", "w.sync_seq"}
+	syncEntryColumns   = []string{"we.id", "we.uuid", "we.workout_id", "w.uuid", "we.exercise_id", "we.sets", "we.reps", "we.weight", "we.duration", "we.distance", "we.rpe", "we.rir", "we.target_reps", "we.is_warmup", "we.avg_heart_rate", "we.max_heart_rate", "we.elevation_gain", "we.position", "we.group_id", "we.completed_at", "we.rest_seconds", "we.client_updated_at", "we.sync_seq"}
+)
+
+func scanSyncWorkout(row pgx.Row) (*model.SyncWorkout, error) {
+	sw := &model.SyncWorkout{Workout: &model.Workout{}}
+	w := sw.Workout
+	err := row.Scan(&w.ID, &w.UUID, &w.UserID, &w.Date, &w.Notes, &w.Name, &w.StartedAt, &w.EndedAt, &w.SessionRPE, &sw.ClientUpdatedAt, &sw.Seq)
+	if err != nil {
+		return nil, err
+	}
+	return sw, nil
+}
+
+func scanSyncEntry(row pgx.Row) (*model.SyncEntry, error) {
+	se := &model.SyncEntry{Entry: &model.WorkoutExercise{}}
+	e := se.Entry
+	err := row.Scan(
+		&e.ID, &e.UUID, &e.WorkoutID, &se.WorkoutUUID, &e.ExerciseID,
+		&e.Sets, &e.Reps, &e.Weight, &e.Duration, &e.Distance,
+		&e.RPE, &e.RIR, &e.TargetReps, &e.IsWarmUp,
+		&e.AvgHeartRate, &e.MaxHeartRate, &e.ElevationGain,
+		&e.Position, &e.GroupID, &e.CompletedAt, &e.RestSeconds,
+		&se.ClientUpdatedAt, &se.Seq,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return se, nil
+}
+
+func (r *repo) GetSyncWorkout(ctx context.Context, userID int64, uuid string) (*model.SyncWorkout, error) {
+	query, args, err := r.qb.
+		Select(syncWorkoutColumns...).
+		From("workouts w").
+		Where(squirrel.Eq{"w.user_id": userID, "w.uuid": uuid}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	sw, err := scanSyncWorkout(r.db.DB().QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrWorkoutNotFound
+		}
+		return nil, fmt.Errorf("failed to get workout: %w", err)
+	}
+
+	return sw, nil
+}
+
+func (r *repo) GetSyncEntry(ctx context.Context, userID int64, uuid string) (*model.SyncEntry, error) {
+	query, args, err := r.qb.
+		Select(syncEntryColumns...).
+		From("workout_exercises we").
+		Join("workouts w ON w.id = we.workout_id").
+		Where(squirrel.Eq{"w.user_id": userID, "we.uuid": uuid}).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	se, err := scanSyncEntry(r.db.DB().QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to get workout entry: %w", err)
+	}
+
+	return se, nil
+}
+
+func (r *repo) IsSyncDeleted(ctx context.Context, userID int64, entity, uuid string) (bool, error) {
+	query, args, err := r.qb.
+		Select("count(*)").
+		From("sync_tombstones").
+		Where(squirrel.Eq{"user_id": userID, "entity": entity, "uuid": uuid}).ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	var count int
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check tombstone: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// CreateSyncWorkout stores a workout created on a client under its UUID,
+// unique among the user's workouts.
+func (r *repo) CreateSyncWorkout(ctx context.Context, sw *model.SyncWorkout) (int64, error) {
+	w := sw.Workout
+	query, args, err := r.qb.
+		Insert("workouts").
+		Columns("uuid", "user_id", "date", "name", "notes", "started_at", "ended_at", "session_rpe", "client_updated_at").
+		Values(w.UUID, w.UserID, w.Date, w.Name, w.Notes, w.StartedAt, w.EndedAt, w.SessionRPE, sw.ClientUpdatedAt).
+		Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, apperrors.ErrInvalidSync
+		}
+		return 0, fmt.Errorf("failed to insert workout: %w", err)
+	}
+
+	return id, nil
+}
+
+func (r *repo) UpdateSyncWorkout(ctx context.Context, sw *model.SyncWorkout) error {
+	w := sw.Workout
+	query, args, err := r.qb.
+		Update("workouts").
+		Set("date", w.Date).
+		Set("name", w.Name).
+		Set("notes", w.Notes).
+		Set("started_at", w.StartedAt).
+		Set("ended_at", w.EndedAt).
+		Set("session_rpe", w.SessionRPE).
+		Set("client_updated_at", sw.ClientUpdatedAt).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{"id": w.ID, "user_id": w.UserID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update workout: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrWorkoutNotFound
+	}
+
+	return nil
+}
+
+// CreateSyncEntry stores an entry created on a client under its UUID; a zero
+// position appends it to the workout. Entry UUIDs are unique within their
+// workout, so one stored concurrently under the same UUID rejects the change.
+func (r *repo) CreateSyncEntry(ctx context.Context, se *model.SyncEntry) (int64, error) {
+	e := se.Entry
+	var position interface{} = e.Position
+	if e.Position <= 0 {
+		position = squirrel.Expr("(SELECT COALESCE(MAX(position), 0) + 1 FROM workout_exercises WHERE workout_id = ?)", e.WorkoutID)
+	}
+
+	query, args, err := r.qb.
+		Insert("workout_exercises").
+		Columns("uuid", "workout_id", "exercise_id", "sets", "reps", "weight", "duration", "distance", "rpe", "rir", "target_reps", "is_warmup", "avg_heart_rate", "max_heart_rate", "elevation_gain", "position", "group_id", "completed_at", "rest_seconds", "client_updated_at").
+		Values(e.UUID, e.WorkoutID, e.ExerciseID, e.Sets, e.Reps, e.Weight, e.Duration, e.Distance, e.RPE, e.RIR, e.TargetReps, e.IsWarmUp, e.AvgHeartRate, e.MaxHeartRate, e.ElevationGain, position, e.GroupID, e.CompletedAt, e.RestSeconds, se.ClientUpdatedAt).
+		Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	var id int64
+	err = r.db.DB().QueryRowContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, errors.Wrap(apperrors.ErrInvalidSync, "uuid already in use")
+		}
+		return 0, fmt.Errorf("failed to insert workout entry: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateSyncEntry replaces the entry with the client's version; a zero
+// position keeps the stored one.
+func (r *repo) UpdateSyncEntry(ctx context.Context, se *model.SyncEntry) error {
+	e := se.Entry
+	builder := r.qb.
+		Update("workout_exercises").
+		Set("exercise_id", e.ExerciseID).
+		Set("sets", e.Sets).
+		Set("reps", e.Reps).
+		Set("weight", e.Weight).
+		Set("duration", e.Duration).
+		Set("distance", e.Distance).
+		Set("rpe", e.RPE).
+		Set("rir", e.RIR).
+		Set("target_reps", e.TargetReps).
+		Set("is_warmup", e.IsWarmUp).
+		Set("avg_heart_rate", e.AvgHeartRate).
+		Set("max_heart_rate", e.MaxHeartRate).
+		Set("elevation_gain", e.ElevationGain).
+		Set("group_id", e.GroupID).
+		Set("completed_at", e.CompletedAt).
+		Set("rest_seconds", e.RestSeconds).
+		Set("client_updated_at", se.ClientUpdatedAt).
+		Where(squirrel.Eq{"id": e.ID, "workout_id": e.WorkoutID})
+	if e.Position > 0 {
+		builder = builder.Set("position", e.Position)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	tag, err := r.db.DB().ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update workout entry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrEntryNotFound
+	}
+
+	return nil
+}
+
+// DeleteWorkout removes the workout with its entries; the database records
+// tombstones for the clients.
+func (r *repo) DeleteWorkout(ctx context.Context, userID, workoutID int64) error {
+	query, args, err := r.qb.
+		Delete("workouts").
+		Where(squirrel.Eq{"id": workoutID, "user_id": userID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete workout: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) DeleteWorkoutExercise(ctx context.Context, workoutID, workoutExerciseID int64) error {
+	query, args, err := r.qb.
+		Delete("workout_exercises").
+		Where(squirrel.Eq{"id": workoutExerciseID, "workout_id": workoutID}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build delete query: %w", err)
+	}
+
+	if _, err = r.db.DB().ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete workout entry: %w", err)
+	}
+
+	return nil
+}
+
+// LockSyncChanges holds back the user's writes until the transaction ends.
+// Every change takes the same lock before its sequence number is drawn.
+func (r *repo) LockSyncChanges(ctx context.Context, userID int64) error {
+	_, err := r.db.DB().ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", userID)
+	if err != nil {
+		return fmt.Errorf("failed to lock sync changes: %w", err)
+	}
+
+	return nil
+}
+
+// ListWorkoutChanges returns the user's workouts changed after cursor in
+// change order.
+func (r *repo) ListWorkoutChanges(ctx context.Context, userID, cursor int64, limit uint64) ([]*model.SyncWorkout, error) {
+	query, args, err := r.qb.
+		Select(syncWorkoutColumns...).
+		From("workouts w").
+		Where(squirrel.Eq{"w.user_id": userID}).
+		Where(squirrel.Gt{"w.sync_seq": cursor}).
+		OrderBy("w.sync_seq").
+		Limit(limit).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workout changes: %w", err)
+	}
+	defer rows.Close()
+
+	var workouts []*model.SyncWorkout
+	for rows.Next() {
+		sw, err := scanSyncWorkout(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan workout change: %w", err)
+		}
+		workouts = append(workouts, sw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate workout changes: %w", err)
+	}
+
+	return workouts, nil
+}
+
+func (r *repo) ListEntryChanges(ctx context.Context, userID, cursor int64, limit uint64) ([]*model.SyncEntry, error) {
+	query, args, err := r.qb.
+		Select(syncEntryColumns...).
+		From("workout_exercises we").
+		Join("workouts w ON w.id = we.workout_id").
+		Where(squirrel.Eq{"w.user_id": userID}).
+		Where(squirrel.Gt{"we.sync_seq": cursor}).
+		OrderBy("we.sync_seq").
+		Limit(limit).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list entry changes: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*model.SyncEntry
+	for rows.Next() {
+		se, err := scanSyncEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan entry change: %w", err)
+		}
+		entries = append(entries, se)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate entry changes: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *repo) ListTombstones(ctx context.Context, userID, cursor int64, limit uint64) ([]*model.SyncTombstone, error) {
+	query, args, err := r.qb.
+		Select("entity", "uuid", "deleted_at", "sync_seq").
+		From("sync_tombstones").
+		Where(squirrel.Eq{"user_id": userID}).
+		Where(squirrel.Gt{"sync_seq": cursor}).
+		OrderBy("sync_seq").
+		Limit(limit).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build select query: %w", err)
+	}
+
+	rows, err := r.db.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tombstones: %w", err)
+	}
+	defer rows.Close()
+
+	var tombstones []*model.SyncTombstone
+	for rows.Next() {
+		var t model.SyncTombstone
+		if err = rows.Scan(&t.Entity, &t.UUID, &t.DeletedAt, &t.Seq); err != nil {
+			return nil, fmt.Errorf("failed to scan tombstone: %w", err)
+		}
+		tombstones = append(tombstones, &t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate tombstones: %w", err)
+	}
+
+	return tombstones, nil
+}
@@ -0,0 +1,163 @@
+package analytics
+
+import (
+	"context"
+	"math"
+	"time"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+const (
+	LoadSourceVolume = "volume"
+	LoadSourceSRPE   = "srpe"
+
+	LoadFlagACWRSpike    = "acwr_spike"
+	LoadFlagHighMonotony = "high_monotony"
+	LoadFlagHighStrain   = "high_strain"
+
+	acuteWindowDays   = 7
+	chronicWindowDays = 28
+)
+
+func (s *serv) GetTrainingLoad(ctx context.Context, userID int64, filter *model.TrainingLoadFilter) (*model.TrainingLoadReport, error) {
+	loc, err := s.userLocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the chronic window of the first requested day reaches back 27 days
+	from := filter.StartDate.AddDate(0, 0, -(chronicWindowDays - 1))
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	end := time.Date(filter.EndDate.Year(), filter.EndDate.Month(), filter.EndDate.Day(), 0, 0, 0, 0, loc)
+
+	loads, err := s.dailyLoads(ctx, userID, filter.Source, loc, start, end, filter.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.TrainingLoadReport{
+		Source:            filter.Source,
+		Timezone:          loc.String(),
+		ACWRThreshold:     filter.ACWRThreshold,
+		MonotonyThreshold: filter.MonotonyThreshold,
+		StrainThreshold:   filter.StrainThreshold,
+	}
+
+	series := make([]float64, 0, int(filter.EndDate.Sub(from).Hours()/24))
+	for date := from; date.Before(filter.EndDate); date = date.AddDate(0, 0, 1) {
+		series = append(series, loads[date])
+		if date.Before(filter.StartDate) {
+			continue
+		}
+
+		day := trainingLoadDay(date, series, filter)
+		report.Days = append(report.Days, day)
+		if len(day.Flags) > 0 {
+			report.Spikes = append(report.Spikes, day)
+		}
+	}
+
+	return report, nil
+}
+
+// dailyLoads returns the training load of every local day in [start, end)
+// keyed by the local date at midnight UTC.
+func (s *serv) dailyLoads(ctx context.Context, userID int64, source string, loc *time.Location, start, end time.Time, tags model.TagFilter) (map[time.Time]float64, error) {
+	loads := make(map[time.Time]float64)
+
+	switch source {
+	case LoadSourceVolume:
+		days, err := s.analyticsRepository.GetCalendarDays(ctx, userID, loc.String(), start, end, tags)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range days {
+			loads[d.Date] = d.Volume
+		}
+	case LoadSourceSRPE:
+		days, err := s.analyticsRepository.GetDailySessionLoad(ctx, userID, loc.String(), start, end, tags)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range days {
+			loads[d.Date] = d.Load
+		}
+	default:
+		return nil, apperrors.ErrInvalidLoadSource
+	}
+
+	return loads, nil
+}
+
+// trainingLoadDay computes the rolling metrics for the last day of series:
+// the coupled acute:chronic workload ratio of 7- and 28-day average loads and
+// Foster's monotony (mean / standard deviation) and strain (weekly load x
+// monotony) over the last 7 days.
+func trainingLoadDay(date time.Time, series []float64, filter *model.TrainingLoadFilter) *model.TrainingLoadDay {
+	acute := window(series, acuteWindowDays)
+	chronic := window(series, chronicWindowDays)
+
+	day := &model.TrainingLoadDay{
+		Date:        date,
+		Load:        series[len(series)-1],
+		AcuteLoad:   sum(acute) / acuteWindowDays,
+		ChronicLoad: sum(chronic) / chronicWindowDays,
+	}
+
+	if day.ChronicLoad > 0 {
+		day.ACWR = day.AcuteLoad / day.ChronicLoad
+	}
+
+	if sd := stddev(acute); sd > 0 {
+		day.Monotony = day.AcuteLoad / sd
+	}
+	day.Strain = sum(acute) * day.Monotony
+
+	if filter.ACWRThreshold > 0 && day.ACWR > filter.ACWRThreshold {
+		day.Flags = append(day.Flags, LoadFlagACWRSpike)
+	}
+	if filter.MonotonyThreshold > 0 && day.Monotony > filter.MonotonyThreshold {
+		day.Flags = append(day.Flags, LoadFlagHighMonotony)
+	}
+	if filter.StrainThreshold > 0 && day.Strain > filter.StrainThreshold {
+		day.Flags = append(day.Flags, LoadFlagHighStrain)
+	}
+
+	return day
+}
+
+// window returns the last n values of series, padded with leading zeros when
+// the series is shorter than n.
+func window(series []float64, n int) []float64 {
+	w := make([]float64, n)
+	if len(series) >= n {
+		copy(w, series[len(series)-n:])
+	} else {
+		copy(w[n-len(series):], series)
+	}
+	return w
+}
+
+func sum(values []float64) float64 {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func stddev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	mean := sum(values) / float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+
+	return math.Sqrt(variance / float64(len(values)))
+}
@@ -0,0 +1,163 @@
+package analytics
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+const loadEpsilon = 1e-9
+
+func repeatLoad(load float64, n int) []float64 {
+	series := make([]float64, n)
+	for i := range series {
+		series[i] = load
+	}
+	return series
+}
+
+func TestTrainingLoadDay(t *testing.T) {
+	date := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	thresholds := &model.TrainingLoadFilter{ACWRThreshold: 1.5, MonotonyThreshold: 2, StrainThreshold: 400}
+
+	tests := []struct {
+		name     string
+		series   []float64
+		filter   *model.TrainingLoadFilter
+		acute    float64
+		chronic  float64
+		acwr     float64
+		monotony float64
+		strain   float64
+		flags    []string
+	}{
+		{
+			name:   "no training",
+			series: repeatLoad(0, 28),
+			filter: thresholds,
+		},
+		{
+			name:    "constant load has zero monotony",
+			series:  repeatLoad(100, 28),
+			filter:  thresholds,
+			acute:   100,
+			chronic: 100,
+			acwr:    1,
+		},
+		{
+			name:     "short history is padded with rest days",
+			series:   []float64{50, 50, 50},
+			filter:   &model.TrainingLoadFilter{},
+			acute:    150.0 / 7,
+			chronic:  150.0 / 28,
+			acwr:     4,
+			monotony: 0.8660254037844386,
+			strain:   129.9038105676658,
+		},
+		{
+			name:    "acute spike over an idle base",
+			series:  append(repeatLoad(0, 21), repeatLoad(100, 7)...),
+			filter:  thresholds,
+			acute:   100,
+			chronic: 25,
+			acwr:    4,
+			flags:   []string{LoadFlagACWRSpike},
+		},
+		{
+			name:     "alternating days",
+			series:   append(repeatLoad(100, 21), 100, 0, 100, 0, 100, 0, 100),
+			filter:   &model.TrainingLoadFilter{ACWRThreshold: 1.5, MonotonyThreshold: 1, StrainThreshold: 400},
+			acute:    400.0 / 7,
+			chronic:  2500.0 / 28,
+			acwr:     (400.0 / 7) / (2500.0 / 28),
+			monotony: 1.1547005383792515,
+			strain:   461.8802153517006,
+			flags:    []string{LoadFlagHighMonotony, LoadFlagHighStrain},
+		},
+		{
+			name:     "zero thresholds disable flags",
+			series:   append(repeatLoad(0, 21), 100, 0, 100, 0, 100, 0, 100),
+			filter:   &model.TrainingLoadFilter{},
+			acute:    400.0 / 7,
+			chronic:  400.0 / 28,
+			acwr:     4,
+			monotony: 1.1547005383792515,
+			strain:   461.8802153517006,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			day := trainingLoadDay(date, tt.series, tt.filter)
+
+			if !day.Date.Equal(date) {
+				t.Errorf("date = %v, want %v", day.Date, date)
+			}
+			if day.Load != tt.series[len(tt.series)-1] {
+				t.Errorf("load = %v, want %v", day.Load, tt.series[len(tt.series)-1])
+			}
+			for _, m := range []struct {
+				name      string
+				got, want float64
+			}{
+				{"acute", day.AcuteLoad, tt.acute},
+				{"chronic", day.ChronicLoad, tt.chronic},
+				{"acwr", day.ACWR, tt.acwr},
+				{"monotony", day.Monotony, tt.monotony},
+				{"strain", day.Strain, tt.strain},
+			} {
+				if math.Abs(m.got-m.want) > loadEpsilon {
+					t.Errorf("%s = %v, want %v", m.name, m.got, m.want)
+				}
+			}
+			if !slices.Equal(day.Flags, tt.flags) {
+				t.Errorf("flags = %v, want %v", day.Flags, tt.flags)
+			}
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name   string
+		series []float64
+		n      int
+		want   []float64
+	}{
+		{"empty", nil, 3, []float64{0, 0, 0}},
+		{"shorter than window", []float64{1, 2}, 4, []float64{0, 0, 1, 2}},
+		{"exact", []float64{1, 2, 3}, 3, []float64{1, 2, 3}},
+		{"longer than window", []float64{1, 2, 3, 4, 5}, 3, []float64{3, 4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := window(tt.series, tt.n); !slices.Equal(got, tt.want) {
+				t.Errorf("window(%v, %d) = %v, want %v", tt.series, tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStddev(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"constant", []float64{5, 5, 5}, 0},
+		{"population", []float64{2, 4, 4, 4, 5, 5, 7, 9}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stddev(tt.values); math.Abs(got-tt.want) > loadEpsilon {
+				t.Errorf("stddev(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/client/db"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/biryanim/workoutbook/internal/service"
+)
+
+var _ service.AnalyticsService = (*serv)(nil)
+
+type serv struct {
+	analyticsRepository repository.AnalyticsRepository
+	userRepository      repository.UserRepository
+	txManager           db.TxManager
+}
+
+func New(analyticsRepository repository.AnalyticsRepository, userRepository repository.UserRepository, txManager db.TxManager) *serv {
+	return &serv{
+		analyticsRepository: analyticsRepository,
+		userRepository:      userRepository,
+		txManager:           txManager,
+	}
+}
+
+// userLocation returns the user's configured timezone, falling back to UTC
+// when it is unknown to the tz database.
+func (s *serv) userLocation(ctx context.Context, userID int64) (*time.Location, error) {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return time.UTC, nil
+	}
+
+	return loc, nil
+}
+
+// localDate returns the calendar date of t in loc as midnight UTC, the same
+// representation postgres uses when scanning a date column.
+func localDate(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// weekStart returns midnight of the Monday of the week t belongs to, matching
+// postgres date_trunc('week', ...).
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
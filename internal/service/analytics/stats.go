@@ -0,0 +1,145 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+const topExercisesLimit = 5
+
+func (s *serv) GetCalendar(ctx context.Context, userID int64, year int, tags model.TagFilter) (*model.Calendar, error) {
+	loc, err := s.userLocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if year == 0 {
+		year = time.Now().In(loc).Year()
+	}
+
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+	end := start.AddDate(1, 0, 0)
+
+	days, err := s.analyticsRepository.GetCalendarDays(ctx, userID, loc.String(), start, end, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[time.Time]*model.CalendarDay, len(days))
+	for _, d := range days {
+		byDate[d.Date] = d
+	}
+
+	calendar := &model.Calendar{
+		Year:     year,
+		Timezone: loc.String(),
+	}
+
+	// the heatmap needs every day of the year, including the empty ones
+	last := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+	for date := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); !date.After(last); date = date.AddDate(0, 0, 1) {
+		day, ok := byDate[date]
+		if !ok {
+			day = &model.CalendarDay{Date: date}
+		}
+		calendar.TotalWorkouts += day.Workouts
+		calendar.TotalVolume += day.Volume
+		calendar.Days = append(calendar.Days, day)
+	}
+
+	return calendar, nil
+}
+
+func (s *serv) GetSummary(ctx context.Context, userID int64, tags model.TagFilter) (*model.StatsSummary, error) {
+	loc, err := s.userLocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.StatsSummary{Timezone: loc.String()}
+
+	var days []*model.CalendarDay
+	err = s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		days, err = s.analyticsRepository.GetCalendarDays(ctx, userID, loc.String(), time.Time{}, time.Time{}, tags)
+		if err != nil {
+			return err
+		}
+
+		summary.AverageSessionDuration, err = s.analyticsRepository.GetAverageSessionDuration(ctx, userID, tags)
+		if err != nil {
+			return err
+		}
+
+		summary.TopExercises, err = s.analyticsRepository.GetTopExercises(ctx, userID, topExercisesLimit, tags)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	today := localDate(time.Now(), loc)
+	thisWeek := weekStart(today)
+	thisMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	thisYear := time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, d := range days {
+		if d.Date.After(today) {
+			continue
+		}
+		if !d.Date.Before(thisWeek) {
+			summary.WorkoutsThisWeek += d.Workouts
+		}
+		if !d.Date.Before(thisMonth) {
+			summary.WorkoutsThisMonth += d.Workouts
+		}
+		if !d.Date.Before(thisYear) {
+			summary.WorkoutsThisYear += d.Workouts
+		}
+	}
+
+	summary.CurrentWeeklyStreak, summary.LongestWeeklyStreak = weeklyStreaks(days, thisWeek)
+
+	return summary, nil
+}
+
+// weeklyStreaks counts runs of consecutive weeks with at least one workout.
+// The current streak stays alive until a whole week passes without training,
+// so an empty current week does not break it yet.
+func weeklyStreaks(days []*model.CalendarDay, thisWeek time.Time) (int, int) {
+	weeks := make(map[time.Time]bool)
+	for _, d := range days {
+		if d.Workouts > 0 {
+			weeks[weekStart(d.Date)] = true
+		}
+	}
+
+	longest := 0
+	for week := range weeks {
+		if weeks[week.AddDate(0, 0, -7)] {
+			continue
+		}
+		length := 0
+		for w := week; weeks[w]; w = w.AddDate(0, 0, 7) {
+			length++
+		}
+		if length > longest {
+			longest = length
+		}
+	}
+
+	current := 0
+	week := thisWeek
+	if !weeks[week] {
+		week = week.AddDate(0, 0, -7)
+	}
+	for ; weeks[week]; week = week.AddDate(0, 0, -7) {
+		current++
+	}
+
+	return current, longest
+}
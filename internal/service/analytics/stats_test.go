@@ -0,0 +1,147 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestWeekStart(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		want time.Time
+	}{
+		{"monday", date(2025, 9, 1), date(2025, 9, 1)},
+		{"midweek", time.Date(2025, 9, 3, 18, 30, 0, 0, time.UTC), date(2025, 9, 1)},
+		{"sunday belongs to the week before", date(2025, 9, 7), date(2025, 9, 1)},
+		{"week crossing the new year", date(2025, 1, 1), date(2024, 12, 30)},
+		{"keeps the location", time.Date(2025, 9, 7, 23, 0, 0, 0, moscow), time.Date(2025, 9, 1, 0, 0, 0, 0, moscow)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := weekStart(tt.t); !got.Equal(tt.want) || got.Location() != tt.want.Location() {
+				t.Errorf("weekStart(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalDate(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	newYork := time.FixedZone("EST", -5*60*60)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		loc  *time.Location
+		want time.Time
+	}{
+		{"same day", time.Date(2025, 9, 3, 12, 0, 0, 0, time.UTC), moscow, date(2025, 9, 3)},
+		{"already the new year east of utc", time.Date(2024, 12, 31, 22, 0, 0, 0, time.UTC), moscow, date(2025, 1, 1)},
+		{"still the old year west of utc", time.Date(2025, 1, 1, 3, 0, 0, 0, time.UTC), newYork, date(2024, 12, 31)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := localDate(tt.t, tt.loc); !got.Equal(tt.want) {
+				t.Errorf("localDate(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeeklyStreaks(t *testing.T) {
+	trained := func(dates ...time.Time) []*model.CalendarDay {
+		days := make([]*model.CalendarDay, 0, len(dates))
+		for _, d := range dates {
+			days = append(days, &model.CalendarDay{Date: d, Workouts: 1})
+		}
+		return days
+	}
+	thisWeek := date(2025, 9, 15)
+
+	tests := []struct {
+		name    string
+		days    []*model.CalendarDay
+		current int
+		longest int
+	}{
+		{"no workouts", nil, 0, 0},
+		{"trained this week", trained(date(2025, 9, 16)), 1, 1},
+		{
+			name:    "current week in progress keeps the streak",
+			days:    trained(date(2025, 9, 1), date(2025, 9, 10)),
+			current: 2,
+			longest: 2,
+		},
+		{
+			name:    "current week counts once trained",
+			days:    trained(date(2025, 9, 1), date(2025, 9, 10), date(2025, 9, 15)),
+			current: 3,
+			longest: 3,
+		},
+		{
+			name:    "a whole week without training breaks it",
+			days:    trained(date(2025, 8, 18), date(2025, 8, 25), date(2025, 9, 1)),
+			current: 0,
+			longest: 3,
+		},
+		{
+			name:    "gap week splits the runs",
+			days:    trained(date(2025, 8, 4), date(2025, 8, 11), date(2025, 8, 18), date(2025, 9, 1), date(2025, 9, 8)),
+			current: 2,
+			longest: 3,
+		},
+		{
+			name:    "several workouts in a week count once",
+			days:    trained(date(2025, 9, 8), date(2025, 9, 9), date(2025, 9, 14)),
+			current: 1,
+			longest: 1,
+		},
+		{
+			name:    "days without workouts are ignored",
+			days:    []*model.CalendarDay{{Date: date(2025, 9, 8)}, {Date: date(2025, 9, 15)}},
+			current: 0,
+			longest: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, longest := weeklyStreaks(tt.days, thisWeek)
+			if current != tt.current || longest != tt.longest {
+				t.Errorf("weeklyStreaks() = %d, %d, want %d, %d", current, longest, tt.current, tt.longest)
+			}
+		})
+	}
+}
+
+func TestWeeklyStreaksAcrossNewYear(t *testing.T) {
+	// sets logged just after midnight in Moscow fall on the day before in UTC,
+	// so the weeks only line up once the days are local
+	moscow := time.FixedZone("MSK", 3*60*60)
+	var days []*model.CalendarDay
+	for _, at := range []time.Time{
+		time.Date(2024, 12, 22, 21, 30, 0, 0, time.UTC),
+		time.Date(2024, 12, 31, 22, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 5, 21, 30, 0, 0, time.UTC),
+		time.Date(2025, 1, 12, 22, 0, 0, 0, time.UTC),
+	} {
+		days = append(days, &model.CalendarDay{Date: localDate(at, moscow), Workouts: 1})
+	}
+	thisWeek := weekStart(localDate(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC), moscow))
+
+	current, longest := weeklyStreaks(days, thisWeek)
+	if current != 4 || longest != 4 {
+		t.Errorf("weeklyStreaks() = %d, %d, want 4, 4", current, longest)
+	}
+}
@@ -0,0 +1,91 @@
+package analytics
+
+import (
+	"context"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+const (
+	VolumeStatusBelow   = "below"
+	VolumeStatusOptimal = "optimal"
+	VolumeStatusAbove   = "above"
+)
+
+func (s *serv) GetMuscleVolume(ctx context.Context, userID int64, filter *model.MuscleVolumeFilter) (*model.MuscleVolumeReport, error) {
+	var (
+		volumes []*model.MuscleGroupVolume
+		groups  []string
+		err     error
+	)
+
+	err = s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		volumes, err = s.analyticsRepository.GetWeeklyMuscleVolume(ctx, userID, filter)
+		if err != nil {
+			return err
+		}
+
+		groups, err = s.analyticsRepository.ListMuscleGroups(ctx)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byWeek := make(map[int64]map[string]*model.MuscleGroupVolume)
+	for _, v := range volumes {
+		key := weekStart(v.WeekStart).Unix()
+		if byWeek[key] == nil {
+			byWeek[key] = make(map[string]*model.MuscleGroupVolume)
+		}
+		byWeek[key][v.MuscleGroup] = v
+	}
+
+	report := &model.MuscleVolumeReport{
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+		MinSets:   filter.MinSets,
+		MaxSets:   filter.MaxSets,
+	}
+
+	// every week of the range lists every muscle group, so groups that were not
+	// trained at all show up with zero sets instead of disappearing
+	totals := make(map[string]float64)
+	weeks := 0
+	for week := weekStart(filter.StartDate); week.Before(filter.EndDate); week = week.AddDate(0, 0, 7) {
+		weeks++
+		weekly := &model.WeeklyMuscleVolume{WeekStart: week}
+		for _, group := range groups {
+			v, ok := byWeek[week.Unix()][group]
+			if !ok {
+				v = &model.MuscleGroupVolume{WeekStart: week, MuscleGroup: group}
+			}
+			v.Status = volumeStatus(v.Sets, filter.MinSets, filter.MaxSets)
+			totals[group] += v.Sets
+			weekly.MuscleGroups = append(weekly.MuscleGroups, v)
+		}
+		report.Weeks = append(report.Weeks, weekly)
+	}
+
+	for _, group := range groups {
+		if weeks > 0 && totals[group]/float64(weeks) < filter.MinSets {
+			report.Neglected = append(report.Neglected, group)
+		}
+	}
+
+	return report, nil
+}
+
+func volumeStatus(sets, minSets, maxSets float64) string {
+	switch {
+	case sets < minSets:
+		return VolumeStatusBelow
+	case sets > maxSets:
+		return VolumeStatusAbove
+	default:
+		return VolumeStatusOptimal
+	}
+}
@@ -0,0 +1,171 @@
+package coach
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/biryanim/workoutbook/internal/service"
+)
+
+var _ service.CoachService = (*serv)(nil)
+
+// recentWorkouts is the number of the latest workouts the dashboard shows of
+// each athlete.
+const recentWorkouts = 5
+
+type serv struct {
+	coachRepository   repository.CoachRepository
+	userRepository    repository.UserRepository
+	workoutRepository repository.WorkoutRepository
+	programService    service.ProgramService
+}
+
+// New takes the program service to reuse its schedules when reporting the
+// adherence of the athletes.
+func New(
+	coachRepository repository.CoachRepository,
+	userRepository repository.UserRepository,
+	workoutRepository repository.WorkoutRepository,
+	programService service.ProgramService,
+) *serv {
+	return &serv{
+		coachRepository:   coachRepository,
+		userRepository:    userRepository,
+		workoutRepository: workoutRepository,
+		programService:    programService,
+	}
+}
+
+// InviteAthlete invites whoever uses params.Email to be coached with
+// params.Permission. The invitation is pending until the user with that
+// email accepts it, and reads the same whether the email is registered or
+// not, so it does not tell which emails have accounts.
+func (s *serv) InviteAthlete(ctx context.Context, params *model.InviteAthleteParams) (*model.Coaching, error) {
+	coach, err := s.userRepository.GetByID(ctx, params.CoachID)
+	if err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(params.Email)
+	if email == strings.ToLower(coach.Email) {
+		return nil, apperrors.ErrInvalidCoaching
+	}
+
+	coaching := &model.Coaching{
+		CoachID:    params.CoachID,
+		Email:      email,
+		Permission: params.Permission,
+		Coach:      coach,
+		Athlete:    &model.User{Email: email},
+	}
+	coaching.ID, err = s.coachRepository.CreateCoaching(ctx, coaching)
+	if err != nil {
+		return nil, err
+	}
+
+	return coaching, nil
+}
+
+// ListAthletes returns the athletes of the coach, the invited ones included.
+func (s *serv) ListAthletes(ctx context.Context, coachID int64) ([]*model.Coaching, error) {
+	athletes, err := s.coachRepository.ListAthletes(ctx, coachID)
+	if err != nil {
+		return nil, err
+	}
+
+	return athletes, nil
+}
+
+// RemoveAthlete stops coaching the athlete.
+func (s *serv) RemoveAthlete(ctx context.Context, coachID, athleteID int64) error {
+	return s.coachRepository.DeleteCoaching(ctx, coachID, athleteID)
+}
+
+// WithdrawInvitation deletes an invitation of the coach nobody accepted yet.
+func (s *serv) WithdrawInvitation(ctx context.Context, coachID, coachingID int64) error {
+	return s.coachRepository.DeleteInvitation(ctx, coachID, coachingID)
+}
+
+// ListCoaches returns the coaches of the athlete, the pending invitations to
+// the athlete's email included.
+func (s *serv) ListCoaches(ctx context.Context, athleteID int64) ([]*model.Coaching, error) {
+	coaches, err := s.coachRepository.ListCoaches(ctx, athleteID)
+	if err != nil {
+		return nil, err
+	}
+
+	return coaches, nil
+}
+
+// AcceptCoach accepts the coach's invitation to the athlete's email, granting
+// the coach the permission it asked for.
+func (s *serv) AcceptCoach(ctx context.Context, athleteID, coachID int64) (*model.Coaching, error) {
+	if err := s.coachRepository.AcceptCoaching(ctx, coachID, athleteID); err != nil {
+		return nil, err
+	}
+
+	return s.coachRepository.GetCoaching(ctx, coachID, athleteID)
+}
+
+// UpdateCoach changes the permission the athlete grants the coach. Only the
+// athlete decides what the coach may do with their workouts.
+func (s *serv) UpdateCoach(ctx context.Context, params *model.UpdateCoachingParams) (*model.Coaching, error) {
+	if err := s.coachRepository.UpdatePermission(ctx, params); err != nil {
+		return nil, err
+	}
+
+	return s.coachRepository.GetCoaching(ctx, params.CoachID, params.AthleteID)
+}
+
+// RemoveCoach declines the coach's invitation or ends the coaching.
+func (s *serv) RemoveCoach(ctx context.Context, athleteID, coachID int64) error {
+	return s.coachRepository.DeclineCoaching(ctx, coachID, athleteID)
+}
+
+// GetDashboard summarizes every athlete who accepted the coach: their latest
+// workouts up to today and the adherence to their active programs.
+func (s *serv) GetDashboard(ctx context.Context, coachID int64) ([]*model.AthleteSummary, error) {
+	athletes, err := s.coachRepository.ListAthletes(ctx, coachID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*model.AthleteSummary, 0, len(athletes))
+	for _, coaching := range athletes {
+		if !coaching.Allows(model.PermissionView) {
+			continue
+		}
+
+		summary := &model.AthleteSummary{Coaching: coaching}
+		summary.RecentWorkouts, err = s.workoutRepository.ListWorkouts(ctx, coaching.AthleteID, &model.WorkoutsFilter{
+			EndDate: time.Now(),
+			Limit:   recentWorkouts,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		enrollments, err := s.programService.ListEnrollments(ctx, coaching.AthleteID)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range enrollments {
+			if !e.Active {
+				continue
+			}
+			adherence, err := s.programService.GetAdherence(ctx, coaching.AthleteID, e.ID)
+			if err != nil {
+				return nil, err
+			}
+			summary.Adherence = append(summary.Adherence, adherence)
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
@@ -0,0 +1,86 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/pkg/errors"
+)
+
+var _ service.IdempotencyService = (*serv)(nil)
+
+// leaseDuration bounds how long a request may hold its key unfinished; a key
+// left behind by a crashed request is taken over after it instead of the TTL.
+const leaseDuration = 5 * time.Minute
+
+type serv struct {
+	idempotencyRepository repository.IdempotencyRepository
+	ttl                   time.Duration
+}
+
+func New(idempotencyRepository repository.IdempotencyRepository, ttl time.Duration) *serv {
+	return &serv{
+		idempotencyRepository: idempotencyRepository,
+		ttl:                   ttl,
+	}
+}
+
+// Begin claims the key for the request with the given hash. A nil record
+// means the request is new and has to be handled; otherwise it is a replay
+// and the stored response is returned.
+func (s *serv) Begin(ctx context.Context, userID int64, key, requestHash string) (*model.IdempotencyRecord, error) {
+	rec := &model.IdempotencyRecord{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   time.Now().UTC().Add(leaseDuration),
+	}
+
+	// the key may be released between the two steps, so try once more
+	for attempt := 0; attempt < 2; attempt++ {
+		reserved, err := s.idempotencyRepository.Reserve(ctx, rec)
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			return nil, nil
+		}
+
+		stored, err := s.idempotencyRepository.Get(ctx, userID, key)
+		if errors.Is(err, apperrors.ErrIdempotencyMissing) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if stored.RequestHash != requestHash {
+			return nil, apperrors.ErrIdempotencyReused
+		}
+		if !stored.StatusCode.Valid {
+			return nil, apperrors.ErrRequestInProgress
+		}
+		return stored, nil
+	}
+
+	return nil, apperrors.ErrRequestInProgress
+}
+
+// Complete stores the response of the request and keeps it for the TTL.
+func (s *serv) Complete(ctx context.Context, rec *model.IdempotencyRecord) error {
+	rec.ExpiresAt = time.Now().UTC().Add(s.ttl)
+	return s.idempotencyRepository.Complete(ctx, rec)
+}
+
+// Release forgets the key so that a retry is handled again.
+func (s *serv) Release(ctx context.Context, userID int64, key string) error {
+	return s.idempotencyRepository.Delete(ctx, userID, key)
+}
+
+func (s *serv) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.idempotencyRepository.DeleteExpired(ctx)
+}
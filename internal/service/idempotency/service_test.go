@@ -0,0 +1,104 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+)
+
+// idempotencyRepository holds at most one record; missing makes Get miss
+// the record a failed Reserve ran into, as if it was released in between.
+type idempotencyRepository struct {
+	repository.IdempotencyRepository
+	rec      *model.IdempotencyRecord
+	reserves int
+	missing  bool
+}
+
+func (r *idempotencyRepository) Reserve(_ context.Context, rec *model.IdempotencyRecord) (bool, error) {
+	r.reserves++
+	if r.rec != nil {
+		return false, nil
+	}
+	stored := *rec
+	r.rec = &stored
+	return true, nil
+}
+
+func (r *idempotencyRepository) Get(context.Context, int64, string) (*model.IdempotencyRecord, error) {
+	if r.missing {
+		return nil, apperrors.ErrIdempotencyMissing
+	}
+	return r.rec, nil
+}
+
+func (r *idempotencyRepository) Complete(_ context.Context, rec *model.IdempotencyRecord) error {
+	r.rec.StatusCode = rec.StatusCode
+	r.rec.ExpiresAt = rec.ExpiresAt
+	return nil
+}
+
+func TestLeaseAndTTL(t *testing.T) {
+	const ttl = 24 * time.Hour
+	repo := &idempotencyRepository{}
+	s := New(repo, ttl)
+	ctx := context.Background()
+
+	start := time.Now()
+	if stored, err := s.Begin(ctx, 1, "key", "hash"); stored != nil || err != nil {
+		t.Fatalf("Begin() = %+v, %v, want a new request", stored, err)
+	}
+	if lease := repo.rec.ExpiresAt.Sub(start); lease < leaseDuration-time.Minute || lease > leaseDuration+time.Minute {
+		t.Errorf("reserved for %v, want the %v lease", lease, leaseDuration)
+	}
+
+	err := s.Complete(ctx, &model.IdempotencyRecord{UserID: 1, Key: "key", StatusCode: sql.NullInt32{Int32: 201, Valid: true}})
+	if err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if kept := repo.rec.ExpiresAt.Sub(start); kept < ttl-time.Minute || kept > ttl+time.Minute {
+		t.Errorf("response kept for %v, want the %v TTL", kept, ttl)
+	}
+}
+
+func TestBegin(t *testing.T) {
+	completed := func() *model.IdempotencyRecord {
+		return &model.IdempotencyRecord{RequestHash: "hash", StatusCode: sql.NullInt32{Int32: 201, Valid: true}}
+	}
+
+	tests := []struct {
+		name     string
+		repo     *idempotencyRepository
+		hash     string
+		replay   bool
+		err      error
+		reserves int
+	}{
+		{"new key", &idempotencyRepository{}, "hash", false, nil, 1},
+		{"replay", &idempotencyRepository{rec: completed()}, "hash", true, nil, 1},
+		{"different request", &idempotencyRepository{rec: completed()}, "other", false, apperrors.ErrIdempotencyReused, 1},
+		{"in progress", &idempotencyRepository{rec: &model.IdempotencyRecord{RequestHash: "hash"}}, "hash", false, apperrors.ErrRequestInProgress, 1},
+		{"released in between", &idempotencyRepository{rec: completed(), missing: true}, "hash", false, apperrors.ErrRequestInProgress, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored, err := New(tt.repo, time.Hour).Begin(context.Background(), 1, "key", tt.hash)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Begin() error = %v, want %v", err, tt.err)
+			}
+			if (stored != nil) != tt.replay {
+				t.Errorf("Begin() record = %+v, want replay %v", stored, tt.replay)
+			}
+			if tt.repo.reserves != tt.reserves {
+				t.Errorf("reserved %d times, want %d", tt.repo.reserves, tt.reserves)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/biryanim/workoutbook/internal/storage"
+	"github.com/pkg/errors"
+)
+
+var _ service.MediaService = (*serv)(nil)
+
+type mediaType struct {
+	kind string
+	ext  string
+}
+
+// mediaTypes are the accepted media by their sniffed content type.
+var mediaTypes = map[string]mediaType{
+	"image/jpeg": {kind: model.MediaKindImage, ext: ".jpg"},
+	"image/png":  {kind: model.MediaKindImage, ext: ".png"},
+	"image/webp": {kind: model.MediaKindImage, ext: ".webp"},
+	"image/gif":  {kind: model.MediaKindImage, ext: ".gif"},
+	"video/mp4":  {kind: model.MediaKindVideo, ext: ".mp4"},
+	"video/webm": {kind: model.MediaKindVideo, ext: ".webm"},
+}
+
+type serv struct {
+	mediaRepository   repository.MediaRepository
+	workoutRepository repository.WorkoutRepository
+	userRepository    repository.UserRepository
+	storage           storage.FileStorage
+}
+
+func New(
+	mediaRepository repository.MediaRepository,
+	workoutRepository repository.WorkoutRepository,
+	userRepository repository.UserRepository,
+	storage storage.FileStorage,
+) *serv {
+	return &serv{
+		mediaRepository:   mediaRepository,
+		workoutRepository: workoutRepository,
+		userRepository:    userRepository,
+		storage:           storage,
+	}
+}
+
+// Upload stores an instruction image or video of the exercise. The catalog
+// is shared by all users, so only administrators add to it. The type is told
+// from the content itself, not from what the client claims.
+func (s *serv) Upload(ctx context.Context, params *model.UploadMediaParams, r io.Reader) (*model.ExerciseMedia, error) {
+	user, err := s.userRepository.GetByID(ctx, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsAdmin {
+		return nil, apperrors.ErrMediaForbidden
+	}
+
+	_, err = s.workoutRepository.GetExerciseByID(ctx, params.ExerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if errors.Is(err, io.EOF) {
+		return nil, errors.Wrap(apperrors.ErrInvalidMedia, "empty file")
+	}
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read media: %w", err)
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	typ, ok := mediaTypes[contentType]
+	if !ok {
+		return nil, apperrors.ErrInvalidMedia
+	}
+
+	name := make([]byte, 16)
+	if _, err = rand.Read(name); err != nil {
+		return nil, fmt.Errorf("failed to generate media name: %w", err)
+	}
+
+	media := &model.ExerciseMedia{
+		ExerciseID:  params.ExerciseID,
+		Kind:        typ.kind,
+		StorageKey:  fmt.Sprintf("exercises/%d/%s%s", params.ExerciseID, hex.EncodeToString(name), typ.ext),
+		ContentType: contentType,
+		UploadedBy:  params.UserID,
+	}
+
+	media.Size, err = s.storage.Save(ctx, media.StorageKey, io.MultiReader(bytes.NewReader(head), r))
+	if err != nil {
+		return nil, err
+	}
+
+	media.ID, err = s.mediaRepository.AddMedia(ctx, media)
+	if err != nil {
+		if delErr := s.storage.Delete(ctx, media.StorageKey); delErr != nil {
+			return nil, fmt.Errorf("%w (failed to delete stored media: %v)", err, delErr)
+		}
+		return nil, err
+	}
+
+	return media, nil
+}
+
+func (s *serv) List(ctx context.Context, exerciseID int64) ([]*model.ExerciseMedia, error) {
+	_, err := s.workoutRepository.GetExerciseByID(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := s.mediaRepository.ListMedia(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// Open returns the media with its file, which the caller has to close.
+func (s *serv) Open(ctx context.Context, mediaID int64) (*model.ExerciseMedia, io.ReadCloser, error) {
+	media, err := s.mediaRepository.GetMedia(ctx, mediaID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := s.storage.Open(ctx, media.StorageKey)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, apperrors.ErrMediaNotFound
+		}
+		return nil, nil, err
+	}
+
+	return media, file, nil
+}
+
+// Delete removes media the user uploaded.
+func (s *serv) Delete(ctx context.Context, userID, mediaID int64) error {
+	media, err := s.mediaRepository.GetMedia(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+	if media.UploadedBy != userID {
+		return apperrors.ErrMediaNotFound
+	}
+
+	err = s.mediaRepository.DeleteMedia(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+
+	err = s.storage.Delete(ctx, media.StorageKey)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
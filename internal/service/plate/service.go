@@ -0,0 +1,148 @@
+package plate
+
+import (
+	"context"
+	"math"
+
+	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/plates"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/biryanim/workoutbook/internal/service"
+	"github.com/pkg/errors"
+)
+
+var _ service.PlateService = (*serv)(nil)
+
+type serv struct {
+	plateRepository   repository.PlateRepository
+	workoutRepository repository.WorkoutRepository
+	txManager         db.TxManager
+}
+
+func New(plateRepository repository.PlateRepository, workoutRepository repository.WorkoutRepository, txManager db.TxManager) *serv {
+	return &serv{
+		plateRepository:   plateRepository,
+		workoutRepository: workoutRepository,
+		txManager:         txManager,
+	}
+}
+
+// GetInventory returns the user's plates, or a standard kg gym set when the
+// user has not saved any.
+func (s *serv) GetInventory(ctx context.Context, userID int64) (*model.PlateInventory, error) {
+	inv, err := s.plateRepository.GetInventory(ctx, userID)
+	if errors.Is(err, apperrors.ErrPlatesNotFound) {
+		inv = plates.DefaultInventory(model.UnitKg)
+		inv.UserID = userID
+		return inv, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return inv, nil
+}
+
+func (s *serv) UpdateInventory(ctx context.Context, inv *model.PlateInventory) error {
+	seen := make(map[float64]bool, len(inv.Plates))
+	for _, p := range inv.Plates {
+		if seen[p.Weight] {
+			return apperrors.ErrInvalidPlates
+		}
+		seen[p.Weight] = true
+	}
+
+	return s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		return s.plateRepository.SaveInventory(ctx, inv)
+	})
+}
+
+// inventoryFor loads the user's inventory and converts a weight given in unit
+// to the inventory's unit. An empty unit means the inventory's own.
+func (s *serv) inventoryFor(ctx context.Context, userID int64, unit string) (*model.PlateInventory, func(float64) float64, error) {
+	inv, err := s.GetInventory(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if unit == "" {
+		unit = inv.Unit
+	}
+	convert := func(w float64) float64 {
+		return plates.Convert(w, unit, inv.Unit)
+	}
+
+	return inv, convert, nil
+}
+
+func barWeight(inv *model.PlateInventory, bar float64, ok bool, convert func(float64) float64) float64 {
+	if ok {
+		return convert(bar)
+	}
+	return inv.BarWeight
+}
+
+func (s *serv) CalculateLoading(ctx context.Context, params *model.PlateLoadParams) (*model.PlateLoading, error) {
+	inv, convert, err := s.inventoryFor(ctx, params.UserID, params.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	bar := barWeight(inv, params.BarWeight.Float64, params.BarWeight.Valid, convert)
+
+	return plates.Load(inv, convert(params.Target), bar), nil
+}
+
+func (s *serv) GenerateWarmUp(ctx context.Context, params *model.WarmUpParams) ([]*model.WarmUpSet, error) {
+	inv, convert, err := s.inventoryFor(ctx, params.UserID, params.Unit)
+	if err != nil {
+		return nil, err
+	}
+
+	bar := barWeight(inv, params.BarWeight.Float64, params.BarWeight.Valid, convert)
+
+	return plates.WarmUp(inv, convert(params.WorkingWeight), bar), nil
+}
+
+// InsertWarmUp logs the warm-up ramp for an exercise into a workout. Workouts
+// store weights in kg whatever unit the plates are in.
+func (s *serv) InsertWarmUp(ctx context.Context, params *model.InsertWarmUpParams) ([]*model.WarmUpSet, error) {
+	sets, err := s.GenerateWarmUp(ctx, &params.WarmUpParams)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		has, err := s.workoutRepository.IsUserHaveWorkout(ctx, params.UserID, params.WorkoutID, model.OwnerOnly)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return apperrors.ErrWorkoutNotFound
+		}
+
+		for _, set := range sets {
+			weight := plates.Convert(set.Loading.Achieved, set.Loading.Unit, model.UnitKg)
+			_, err = s.workoutRepository.AddWorkoutExercise(ctx, &model.WorkoutExercise{
+				WorkoutID:  params.WorkoutID,
+				ExerciseID: params.ExerciseID,
+				Sets:       1,
+				Reps:       set.Reps,
+				Weight:     math.Round(weight*100) / 100,
+				IsWarmUp:   true,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sets, nil
+}
@@ -0,0 +1,93 @@
+package program
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/generator"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/pkg/errors"
+)
+
+func (s *serv) ListGenerators(_ context.Context) []model.GeneratorInfo {
+	return generator.List()
+}
+
+// estimatedOneRM uses the Epley formula, the same one personal records are
+// ranked by.
+func estimatedOneRM(weight float64, reps int) float64 {
+	if reps <= 1 {
+		return weight
+	}
+	return weight * (1 + float64(reps)/30)
+}
+
+// liftMaxes picks the best estimated one-rep max for every main lift.
+func liftMaxes(records []*model.UserRecord) map[string]*model.LiftMax {
+	lifts := make(map[string]*model.LiftMax, len(records))
+	for _, rec := range records {
+		lift := rec.Exercise.Lift.String
+		oneRM := estimatedOneRM(rec.Weight, rec.Reps)
+		if cur, ok := lifts[lift]; !ok || oneRM > cur.OneRM {
+			lifts[lift] = &model.LiftMax{ExerciseID: rec.ExerciseID, Lift: lift, OneRM: oneRM}
+		}
+	}
+	return lifts
+}
+
+// GenerateProgram builds a program from the user's personal records, saves it
+// as the user's own and enrolls the user in it from params.StartDate.
+func (s *serv) GenerateProgram(ctx context.Context, params *model.GenerateProgramParams) (*model.Enrollment, error) {
+	gen, err := generator.Get(params.Generator)
+	if err != nil {
+		return nil, apperrors.ErrUnknownGenerator
+	}
+
+	records, err := s.programRepository.GetLiftRecords(ctx, params.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	generated, err := gen.Generate(&model.GeneratorInput{
+		Weeks:    params.Weeks,
+		Lifts:    liftMaxes(records),
+		Failures: params.Failures,
+	})
+	if err != nil {
+		if errors.Is(err, generator.ErrMissingLift) {
+			return nil, fmt.Errorf("%w: %v", apperrors.ErrMissingLiftRecord, err)
+		}
+		return nil, err
+	}
+
+	program := generated.Program
+	program.UserID = sql.NullInt64{Int64: params.UserID, Valid: true}
+	program.Generator = sql.NullString{String: params.Generator, Valid: true}
+
+	enrollment := &model.Enrollment{
+		UserID:        params.UserID,
+		StartDate:     dateOnly(params.StartDate),
+		Active:        true,
+		TrainingMaxes: generated.TrainingMaxes,
+		Program:       program,
+	}
+
+	err = s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		var errTx error
+		enrollment.ProgramID, errTx = s.CreateProgram(ctx, program)
+		if errTx != nil {
+			return errTx
+		}
+		program.ID = enrollment.ProgramID
+
+		enrollment.ID, errTx = s.Enroll(ctx, enrollment)
+		return errTx
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return enrollment, nil
+}
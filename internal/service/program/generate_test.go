@@ -0,0 +1,69 @@
+package program
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func TestLiftMaxes(t *testing.T) {
+	record := func(exerciseID int64, lift string, weight float64, reps int) *model.UserRecord {
+		return &model.UserRecord{
+			ExerciseID: exerciseID,
+			Weight:     weight,
+			Reps:       reps,
+			Exercise:   model.Exercise{Lift: sql.NullString{String: lift, Valid: true}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		records []*model.UserRecord
+		want    map[string]model.LiftMax
+	}{
+		{
+			name: "no records",
+			want: map[string]model.LiftMax{},
+		},
+		{
+			name:    "single",
+			records: []*model.UserRecord{record(1, model.LiftSquat, 150, 1)},
+			want:    map[string]model.LiftMax{model.LiftSquat: {ExerciseID: 1, Lift: model.LiftSquat, OneRM: 150}},
+		},
+		{
+			name: "best estimated max wins",
+			records: []*model.UserRecord{
+				record(1, model.LiftSquat, 150, 1),
+				record(2, model.LiftSquat, 135, 5),
+				record(3, model.LiftBench, 90, 15),
+			},
+			want: map[string]model.LiftMax{
+				model.LiftSquat: {ExerciseID: 2, Lift: model.LiftSquat, OneRM: 157.5},
+				model.LiftBench: {ExerciseID: 3, Lift: model.LiftBench, OneRM: 135},
+			},
+		},
+		{
+			name: "ties keep the first record",
+			records: []*model.UserRecord{
+				record(1, model.LiftDeadlift, 200, 1),
+				record(2, model.LiftDeadlift, 200, 1),
+			},
+			want: map[string]model.LiftMax{model.LiftDeadlift: {ExerciseID: 1, Lift: model.LiftDeadlift, OneRM: 200}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := liftMaxes(tt.records)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d lifts, want %d", len(got), len(tt.want))
+			}
+			for lift, want := range tt.want {
+				if m, ok := got[lift]; !ok || *m != want {
+					t.Errorf("liftMaxes()[%q] = %+v, want %+v", lift, m, want)
+				}
+			}
+		})
+	}
+}
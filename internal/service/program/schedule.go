@@ -0,0 +1,256 @@
+package program
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"time"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	// loadIncrement is the smallest jump loadable with standard plates.
+	loadIncrement = 2.5
+)
+
+// dateOnly truncates t to its calendar date as midnight UTC, the same
+// representation postgres uses when scanning a date column.
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// scheduledDay finds the program day that falls on date for an enrollment
+// starting at start. Week 1 day 1 is the start date itself.
+func scheduledDay(program *model.Program, start, date time.Time) (*model.ProgramDay, bool) {
+	offset := int(dateOnly(date).Sub(dateOnly(start)).Hours() / 24)
+	if offset < 0 || offset >= program.Weeks*7 {
+		return nil, false
+	}
+
+	week, day := offset/7+1, offset%7+1
+	for _, d := range program.Days {
+		if d.Week == week && d.Day == day {
+			return d, true
+		}
+	}
+
+	return nil, false
+}
+
+func roundLoad(weight float64) float64 {
+	return math.Round(weight/loadIncrement) * loadIncrement
+}
+
+// resolveExercise turns a prescription into a concrete target using the
+// enrollment's training maxes. A substitute takes the place of the program's
+// exercise with the same prescription.
+func resolveExercise(e *model.ProgramExercise, maxes map[int64]float64, subs map[int64]*model.ExerciseSubstitution) *model.ScheduledExercise {
+	res := &model.ScheduledExercise{
+		ProgramExerciseID: e.ID,
+		ExerciseID:        e.ExerciseID,
+		Exercise:          e.Exercise,
+		Sets:              e.Sets,
+		Reps:              e.Reps,
+		Type:              e.Type,
+		Value:             e.Value,
+		AMRAP:             e.AMRAP,
+		Notes:             e.Notes,
+	}
+	if sub, ok := subs[e.ID]; ok {
+		res.ReplacedExerciseID = sql.NullInt64{Int64: e.ExerciseID, Valid: true}
+		res.ExerciseID = sub.ExerciseID
+		res.Exercise = sub.Exercise
+	}
+
+	switch e.Type {
+	case model.PrescriptionPercentTM:
+		if tm, ok := maxes[res.ExerciseID]; ok {
+			res.Weight = sql.NullFloat64{Float64: roundLoad(tm * e.Value / 100), Valid: true}
+		}
+	case model.PrescriptionRPE:
+		res.TargetRPE = sql.NullFloat64{Float64: e.Value, Valid: true}
+	case model.PrescriptionFixed:
+		res.Weight = sql.NullFloat64{Float64: e.Value, Valid: true}
+	}
+
+	return res
+}
+
+func scheduleSession(enrollment *model.Enrollment, day *model.ProgramDay, date time.Time, maxes map[int64]float64, subs map[int64]*model.ExerciseSubstitution) *model.ScheduledSession {
+	session := &model.ScheduledSession{
+		EnrollmentID: enrollment.ID,
+		Date:         dateOnly(date),
+		Week:         day.Week,
+		Day:          day.Day,
+		Name:         day.Name,
+		Exercises:    make([]*model.ScheduledExercise, 0, len(day.Exercises)),
+	}
+	for _, e := range day.Exercises {
+		session.Exercises = append(session.Exercises, resolveExercise(e, maxes, subs))
+	}
+
+	return session
+}
+
+func trainingMaxes(enrollment *model.Enrollment) map[int64]float64 {
+	maxes := make(map[int64]float64, len(enrollment.TrainingMaxes))
+	for _, m := range enrollment.TrainingMaxes {
+		maxes[m.ExerciseID] = m.TrainingMax
+	}
+	return maxes
+}
+
+// substitutions indexes the enrollment's substitutions by the program
+// exercise they replace.
+func substitutions(enrollment *model.Enrollment) map[int64]*model.ExerciseSubstitution {
+	subs := make(map[int64]*model.ExerciseSubstitution, len(enrollment.Substitutions))
+	for _, sub := range enrollment.Substitutions {
+		subs[sub.ProgramExerciseID] = sub
+	}
+	return subs
+}
+
+func (s *serv) GetScheduledSession(ctx context.Context, userID, enrollmentID int64, date time.Time) (*model.ScheduledSession, error) {
+	enrollment, program, err := s.loadEnrollment(ctx, userID, enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	day, ok := scheduledDay(program, enrollment.StartDate, date)
+	if !ok {
+		return nil, apperrors.ErrNoSessionScheduled
+	}
+
+	sessions, err := s.programRepository.ListSessions(ctx, enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	session := scheduleSession(enrollment, day, date, trainingMaxes(enrollment), substitutions(enrollment))
+	session.WorkoutID = linkedWorkouts(sessions)[session.Date.Format(dateLayout)]
+
+	return session, nil
+}
+
+// GetAdherence compares the sessions planned up to today in the user's
+// timezone with the workouts linked to them.
+func (s *serv) GetAdherence(ctx context.Context, userID, enrollmentID int64) (*model.Adherence, error) {
+	enrollment, program, err := s.loadEnrollment(ctx, userID, enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	today := dateOnly(time.Now().In(loc))
+
+	sessions, err := s.programRepository.ListSessions(ctx, enrollmentID)
+	if err != nil {
+		return nil, err
+	}
+	linked := linkedWorkouts(sessions)
+	maxes := trainingMaxes(enrollment)
+	subs := substitutions(enrollment)
+
+	res := &model.Adherence{EnrollmentID: enrollmentID}
+	start := dateOnly(enrollment.StartDate)
+	for offset := 0; offset < program.Weeks*7; offset++ {
+		date := start.AddDate(0, 0, offset)
+		if date.After(today) {
+			break
+		}
+
+		day, ok := scheduledDay(program, start, date)
+		if !ok {
+			continue
+		}
+
+		session := scheduleSession(enrollment, day, date, maxes, subs)
+		session.WorkoutID = linked[date.Format(dateLayout)]
+		res.Planned++
+		if session.WorkoutID.Valid {
+			res.Completed++
+		}
+		res.Sessions = append(res.Sessions, session)
+	}
+
+	if res.Planned > 0 {
+		res.Rate = float64(res.Completed) / float64(res.Planned)
+	}
+
+	return res, nil
+}
+
+// SwapExercise replaces a program exercise in the enrollment's sessions with a
+// substitute of the same type and tracking profile, keeping its prescription.
+// The program itself may be shared, so only the enrollment is changed;
+// swapping back to the program's own exercise drops the substitution.
+func (s *serv) SwapExercise(ctx context.Context, params *model.SwapExerciseParams) (*model.ScheduledExercise, error) {
+	var res *model.ScheduledExercise
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		enrollment, program, err := s.loadEnrollment(ctx, params.UserID, params.ParentID)
+		if err != nil {
+			return err
+		}
+
+		entry := programExercise(program, params.EntryID)
+		if entry == nil {
+			return apperrors.ErrPlanEntryNotFound
+		}
+
+		substitute, err := s.workoutRepository.GetExerciseByID(ctx, params.ExerciseID)
+		if err != nil {
+			return err
+		}
+		if !model.Substitutable(&entry.Exercise, substitute) {
+			return apperrors.ErrInvalidSubstitute
+		}
+
+		subs := substitutions(enrollment)
+		if substitute.ID == entry.ExerciseID {
+			err = s.programRepository.DeleteSubstitution(ctx, enrollment.ID, entry.ID)
+			delete(subs, entry.ID)
+		} else {
+			sub := &model.ExerciseSubstitution{
+				EnrollmentID:      enrollment.ID,
+				ProgramExerciseID: entry.ID,
+				ExerciseID:        substitute.ID,
+				Exercise:          *substitute,
+			}
+			err = s.programRepository.SetSubstitution(ctx, sub)
+			subs[entry.ID] = sub
+		}
+		if err != nil {
+			return err
+		}
+
+		res = resolveExercise(entry, trainingMaxes(enrollment), subs)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func programExercise(program *model.Program, programExerciseID int64) *model.ProgramExercise {
+	for _, d := range program.Days {
+		for _, e := range d.Exercises {
+			if e.ID == programExerciseID {
+				return e
+			}
+		}
+	}
+	return nil
+}
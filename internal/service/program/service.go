@@ -0,0 +1,256 @@
+package program
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/biryanim/workoutbook/internal/service"
+)
+
+var _ service.ProgramService = (*serv)(nil)
+
+type serv struct {
+	programRepository repository.ProgramRepository
+	workoutRepository repository.WorkoutRepository
+	userRepository    repository.UserRepository
+	coachRepository   repository.CoachRepository
+	txManager         db.TxManager
+}
+
+func New(
+	programRepository repository.ProgramRepository,
+	workoutRepository repository.WorkoutRepository,
+	userRepository repository.UserRepository,
+	coachRepository repository.CoachRepository,
+	txManager db.TxManager,
+) *serv {
+	return &serv{
+		programRepository: programRepository,
+		workoutRepository: workoutRepository,
+		userRepository:    userRepository,
+		coachRepository:   coachRepository,
+		txManager:         txManager,
+	}
+}
+
+func validateProgram(program *model.Program) error {
+	if program.Weeks < 1 || program.Weeks > 52 {
+		return apperrors.ErrInvalidProgram
+	}
+
+	seen := make(map[[2]int]bool, len(program.Days))
+	for _, day := range program.Days {
+		if day.Week < 1 || day.Week > program.Weeks || day.Day < 1 || day.Day > 7 {
+			return apperrors.ErrInvalidProgram
+		}
+		key := [2]int{day.Week, day.Day}
+		if seen[key] {
+			return apperrors.ErrInvalidProgram
+		}
+		seen[key] = true
+
+		for _, e := range day.Exercises {
+			switch e.Type {
+			case model.PrescriptionPercentTM, model.PrescriptionRPE, model.PrescriptionFixed:
+			default:
+				return apperrors.ErrInvalidProgram
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *serv) CreateProgram(ctx context.Context, program *model.Program) (int64, error) {
+	if err := validateProgram(program); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		var errTx error
+		id, errTx = s.programRepository.CreateProgram(ctx, program)
+		if errTx != nil {
+			return errTx
+		}
+
+		for _, day := range program.Days {
+			day.ProgramID = id
+			dayID, errTx := s.programRepository.AddProgramDay(ctx, day)
+			if errTx != nil {
+				return errTx
+			}
+
+			for i, e := range day.Exercises {
+				e.ProgramDayID = dayID
+				e.Position = i + 1
+			}
+			if errTx = s.programRepository.AddProgramExercises(ctx, day.Exercises); errTx != nil {
+				return errTx
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *serv) ListPrograms(ctx context.Context, userID int64) ([]*model.Program, error) {
+	programs, err := s.programRepository.ListPrograms(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return programs, nil
+}
+
+// GetProgram returns the program with its days and their exercises.
+func (s *serv) GetProgram(ctx context.Context, userID, programID int64) (*model.Program, error) {
+	program, err := s.programRepository.GetProgram(ctx, programID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := s.programRepository.GetProgramDays(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	exercises, err := s.programRepository.GetProgramExercises(ctx, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[int64]*model.ProgramDay, len(days))
+	for _, day := range days {
+		byDay[day.ID] = day
+	}
+	for _, e := range exercises {
+		if day, ok := byDay[e.ProgramDayID]; ok {
+			day.Exercises = append(day.Exercises, e)
+		}
+	}
+	program.Days = days
+
+	return program, nil
+}
+
+func (s *serv) Enroll(ctx context.Context, enrollment *model.Enrollment) (int64, error) {
+	if _, err := s.programRepository.GetProgram(ctx, enrollment.ProgramID, enrollment.UserID); err != nil {
+		return 0, err
+	}
+
+	return s.enroll(ctx, enrollment)
+}
+
+// AssignProgram enrolls the athlete in enrollment.UserID in a program the
+// coach can see on behalf of a coach with the assign permission. The athlete
+// sees the program for as long as they are enrolled in it.
+func (s *serv) AssignProgram(ctx context.Context, coachID int64, enrollment *model.Enrollment) (int64, error) {
+	coaching, err := s.coachRepository.GetCoaching(ctx, coachID, enrollment.UserID)
+	if err != nil {
+		return 0, err
+	}
+	if !coaching.Allows(model.PermissionAssign) {
+		return 0, apperrors.ErrCoachingNotFound
+	}
+
+	if _, err = s.programRepository.GetProgram(ctx, enrollment.ProgramID, coachID); err != nil {
+		return 0, err
+	}
+
+	return s.enroll(ctx, enrollment)
+}
+
+func (s *serv) enroll(ctx context.Context, enrollment *model.Enrollment) (int64, error) {
+	var id int64
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		var errTx error
+		id, errTx = s.programRepository.CreateEnrollment(ctx, enrollment)
+		if errTx != nil {
+			return errTx
+		}
+
+		return s.programRepository.SetTrainingMaxes(ctx, id, enrollment.TrainingMaxes)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *serv) ListEnrollments(ctx context.Context, userID int64) ([]*model.Enrollment, error) {
+	enrollments, err := s.programRepository.ListEnrollments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return enrollments, nil
+}
+
+// LinkWorkout marks the session planned for session.PlannedDate as completed
+// by one of the user's workouts.
+func (s *serv) LinkWorkout(ctx context.Context, userID int64, session *model.EnrollmentSession) error {
+	enrollment, program, err := s.loadEnrollment(ctx, userID, session.EnrollmentID)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := scheduledDay(program, enrollment.StartDate, session.PlannedDate); !ok {
+		return apperrors.ErrNoSessionScheduled
+	}
+
+	has, err := s.workoutRepository.IsUserHaveWorkout(ctx, userID, session.WorkoutID, model.OwnerOnly)
+	if err != nil {
+		return err
+	}
+	if !has {
+		return apperrors.ErrWorkoutNotFound
+	}
+
+	return s.programRepository.LinkSession(ctx, session)
+}
+
+// loadEnrollment returns the user's enrollment with its training maxes and
+// the full program it follows.
+func (s *serv) loadEnrollment(ctx context.Context, userID, enrollmentID int64) (*model.Enrollment, *model.Program, error) {
+	enrollment, err := s.programRepository.GetEnrollment(ctx, enrollmentID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enrollment.TrainingMaxes, err = s.programRepository.GetTrainingMaxes(ctx, enrollmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enrollment.Substitutions, err = s.programRepository.GetSubstitutions(ctx, enrollmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	program, err := s.GetProgram(ctx, userID, enrollment.ProgramID)
+	if err != nil {
+		return nil, nil, err
+	}
+	enrollment.Program = program
+
+	return enrollment, program, nil
+}
+
+func linkedWorkouts(sessions []*model.EnrollmentSession) map[string]sql.NullInt64 {
+	linked := make(map[string]sql.NullInt64, len(sessions))
+	for _, s := range sessions {
+		linked[s.PlannedDate.Format(dateLayout)] = sql.NullInt64{Int64: s.WorkoutID, Valid: true}
+	}
+	return linked
+}
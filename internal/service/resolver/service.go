@@ -0,0 +1,102 @@
+package resolver
+
+import (
+	"context"
+	"database/sql"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/biryanim/workoutbook/internal/service"
+)
+
+var _ service.ResolverService = (*serv)(nil)
+
+type serv struct {
+	workoutRepository repository.WorkoutRepository
+	userRepository    repository.UserRepository
+}
+
+func New(workoutRepository repository.WorkoutRepository, userRepository repository.UserRepository) *serv {
+	return &serv{
+		workoutRepository: workoutRepository,
+		userRepository:    userRepository,
+	}
+}
+
+// Resolve maps a free-form exercise name, such as "bench", "BP" or
+// "жим лёжа", to the catalog exercises it may refer to, best matches first.
+// q.Locale is the language the client accepts; the one set in the profile
+// takes precedence.
+func (s *serv) Resolve(ctx context.Context, q *model.ResolveQuery) ([]*model.ExerciseCandidate, error) {
+	user, err := s.userRepository.GetByID(ctx, q.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := *q
+	query.Text = model.NormalizeExerciseName(q.Text)
+	query.Locale = model.ResolveLocale(user.Locale, q.Locale)
+	if query.Text == "" {
+		return nil, nil
+	}
+
+	candidates, err := s.workoutRepository.ResolveExercises(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	return candidates, nil
+}
+
+// ListAliases lists the built-in aliases of the exercise and the ones the user
+// added.
+func (s *serv) ListAliases(ctx context.Context, userID, exerciseID int64) ([]*model.ExerciseAlias, error) {
+	_, err := s.workoutRepository.GetExerciseByID(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := s.workoutRepository.ListExerciseAliases(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return aliases, nil
+}
+
+// AddAlias adds an alternative name to the exercise on behalf of the user in
+// alias.CreatedBy. The alias is the user's own: other users neither resolve
+// nor see it.
+func (s *serv) AddAlias(ctx context.Context, alias *model.ExerciseAlias) (*model.ExerciseAlias, error) {
+	_, err := s.workoutRepository.GetExerciseByID(ctx, alias.ExerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	alias.Normalized = model.NormalizeExerciseName(alias.Alias)
+	if alias.Normalized == "" {
+		return nil, apperrors.ErrInvalidAlias
+	}
+
+	alias.ID, err = s.workoutRepository.AddExerciseAlias(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	return alias, nil
+}
+
+// DeleteAlias deletes an alias the user added; built-in aliases and the ones
+// of other users are not theirs to delete.
+func (s *serv) DeleteAlias(ctx context.Context, userID, aliasID int64) error {
+	alias, err := s.workoutRepository.GetExerciseAlias(ctx, aliasID)
+	if err != nil {
+		return err
+	}
+	if alias.CreatedBy != (sql.NullInt64{Int64: userID, Valid: true}) {
+		return apperrors.ErrAliasNotFound
+	}
+
+	return s.workoutRepository.DeleteExerciseAlias(ctx, aliasID)
+}
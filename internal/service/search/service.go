@@ -0,0 +1,43 @@
+package search
+
+import (
+	"context"
+
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/biryanim/workoutbook/internal/service"
+)
+
+var _ service.SearchService = (*serv)(nil)
+
+type serv struct {
+	searchRepository repository.SearchRepository
+	userRepository   repository.UserRepository
+}
+
+func New(searchRepository repository.SearchRepository, userRepository repository.UserRepository) *serv {
+	return &serv{
+		searchRepository: searchRepository,
+		userRepository:   userRepository,
+	}
+}
+
+// Search finds the user's workouts and exercise entries and the catalog
+// exercises matching the query, best matches first. q.Locale is the language
+// the client accepts; the one set in the profile takes precedence.
+func (s *serv) Search(ctx context.Context, q *model.SearchQuery) (*model.SearchResults, error) {
+	user, err := s.userRepository.GetByID(ctx, q.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := *q
+	query.Locale = model.ResolveLocale(user.Locale, q.Locale)
+
+	results, err := s.searchRepository.Search(ctx, &query)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -2,6 +2,9 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
+
 	"github.com/biryanim/workoutbook/internal/model"
 )
 
@@ -11,14 +14,131 @@ type AuthService interface {
 	Check(ctx context.Context, token string) (int64, bool, error)
 }
 
+type UserService interface {
+	GetProfile(ctx context.Context, userID int64) (*model.User, error)
+	UpdateProfile(ctx context.Context, params *model.UpdateProfileParams) error
+	AddBodyWeight(ctx context.Context, bw *model.BodyWeight) (int64, error)
+	ListBodyWeights(ctx context.Context, userID int64) ([]*model.BodyWeight, error)
+	GetEquipment(ctx context.Context, userID int64) ([]*model.Equipment, error)
+	UpdateEquipment(ctx context.Context, userID int64, codes []string) ([]*model.Equipment, error)
+}
+
 type WorkoutService interface {
 	CreateWorkout(ctx context.Context, workout *model.Workout) (int64, error)
-	GetWorkouts(ctx context.Context, userId int64, pagination *model.WorkoutsFilter) ([]*model.Workout, error)
-	GetWorkout(ctx context.Context, userId, workoutId int64) (*model.WorkoutExercises, error)
+	GetWorkouts(ctx context.Context, userId int64, filter *model.WorkoutsFilter) (*model.WorkoutsPage, error)
+	GetWorkout(ctx context.Context, userId, workoutId int64, locale string) (*model.WorkoutExercises, error)
+	UpdateWorkout(ctx context.Context, params *model.UpdateWorkoutParams) error
+	ImportActivity(ctx context.Context, imp *model.ActivityImport) (int64, error)
+	ListExerciseMappings(ctx context.Context, source string) ([]*model.ExerciseMapping, error)
+	SetExerciseMapping(ctx context.Context, userID int64, mapping *model.ExerciseMapping) error
+	DeleteExerciseMapping(ctx context.Context, userID int64, source, externalName string) error
+	GetAthleteWorkouts(ctx context.Context, coachID, athleteID int64, filter *model.WorkoutsFilter) (*model.WorkoutsPage, error)
+	AssignWorkout(ctx context.Context, coachID int64, workout *model.Workout) (int64, error)
+	AddComment(ctx context.Context, comment *model.WorkoutComment) (*model.WorkoutComment, error)
+	ListComments(ctx context.Context, userID, workoutID int64) ([]*model.WorkoutComment, error)
+
+	StartSession(ctx context.Context, userID, workoutID int64) (*model.Workout, error)
+	LogSet(ctx context.Context, params *model.LogSetParams) (*model.WorkoutExercise, error)
+	FinishSession(ctx context.Context, params *model.FinishSessionParams) (*model.Workout, error)
+	SubscribeSession(ctx context.Context, userID, workoutID int64) (<-chan *model.SessionEvent, func(), error)
+	Sync(ctx context.Context, req *model.SyncRequest) (*model.SyncResponse, error)
 
 	AddExerciseToWorkout(ctx context.Context, userId int64, we *model.WorkoutExercise) error
-	GetExercises(ctx context.Context, exerciseType string) ([]*model.Exercise, error)
+	ReorderExercises(ctx context.Context, params *model.ReorderExercisesParams) error
+	GetExercises(ctx context.Context, userID int64, filter *model.ExercisesFilter) (*model.ExercisesPage, error)
+	GetExercise(ctx context.Context, userID, exerciseID int64, locale string) (*model.Exercise, error)
+	ListEquipment(ctx context.Context) ([]*model.Equipment, error)
+	ListMuscles(ctx context.Context) ([]*model.Muscle, error)
+	GetSubstitutes(ctx context.Context, q *model.SubstitutesQuery) ([]*model.Substitute, error)
+	SuggestNext(ctx context.Context, userID, exerciseID int64, targetReps int) (*model.ProgressionSuggestion, error)
+
+	CreateTemplate(ctx context.Context, template *model.WorkoutTemplate) (int64, error)
+	ListTemplates(ctx context.Context, userID int64) ([]*model.WorkoutTemplate, error)
+	GetTemplate(ctx context.Context, userID, templateID int64) (*model.WorkoutTemplate, error)
+	InstantiateTemplate(ctx context.Context, params *model.InstantiateTemplateParams) (*model.TemplateWorkout, error)
+	SwapTemplateExercise(ctx context.Context, params *model.SwapExerciseParams) (*model.TemplateExercise, error)
+
+	GetStrengthStandards(ctx context.Context, userID, exerciseID int64) (*model.ExerciseStandards, error)
+	SetStrengthStandards(ctx context.Context, userID int64, standards *model.ExerciseStandards) error
+	DeleteStrengthStandards(ctx context.Context, userID, exerciseID int64) error
+	UpdatePersonalRecord(ctx context.Context, userID int64, we *model.WorkoutExercise) error
+	GetPersonalRecords(ctx context.Context, userId int64, locale string) (*model.PersonalRecords, error)
+}
+
+type AnalyticsService interface {
+	GetMuscleVolume(ctx context.Context, userID int64, filter *model.MuscleVolumeFilter) (*model.MuscleVolumeReport, error)
+	GetCalendar(ctx context.Context, userID int64, year int, tags model.TagFilter) (*model.Calendar, error)
+	GetSummary(ctx context.Context, userID int64, tags model.TagFilter) (*model.StatsSummary, error)
+	GetTrainingLoad(ctx context.Context, userID int64, filter *model.TrainingLoadFilter) (*model.TrainingLoadReport, error)
+}
+
+type ProgramService interface {
+	CreateProgram(ctx context.Context, program *model.Program) (int64, error)
+	ListPrograms(ctx context.Context, userID int64) ([]*model.Program, error)
+	GetProgram(ctx context.Context, userID, programID int64) (*model.Program, error)
+	ListGenerators(ctx context.Context) []model.GeneratorInfo
+	GenerateProgram(ctx context.Context, params *model.GenerateProgramParams) (*model.Enrollment, error)
+
+	Enroll(ctx context.Context, enrollment *model.Enrollment) (int64, error)
+	AssignProgram(ctx context.Context, coachID int64, enrollment *model.Enrollment) (int64, error)
+	ListEnrollments(ctx context.Context, userID int64) ([]*model.Enrollment, error)
+	GetScheduledSession(ctx context.Context, userID, enrollmentID int64, date time.Time) (*model.ScheduledSession, error)
+	LinkWorkout(ctx context.Context, userID int64, session *model.EnrollmentSession) error
+	GetAdherence(ctx context.Context, userID, enrollmentID int64) (*model.Adherence, error)
+	SwapExercise(ctx context.Context, params *model.SwapExerciseParams) (*model.ScheduledExercise, error)
+}
+
+type PlateService interface {
+	GetInventory(ctx context.Context, userID int64) (*model.PlateInventory, error)
+	UpdateInventory(ctx context.Context, inv *model.PlateInventory) error
+	CalculateLoading(ctx context.Context, params *model.PlateLoadParams) (*model.PlateLoading, error)
+	GenerateWarmUp(ctx context.Context, params *model.WarmUpParams) ([]*model.WarmUpSet, error)
+	InsertWarmUp(ctx context.Context, params *model.InsertWarmUpParams) ([]*model.WarmUpSet, error)
+}
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, userID int64, key, requestHash string) (*model.IdempotencyRecord, error)
+	Complete(ctx context.Context, rec *model.IdempotencyRecord) error
+	Release(ctx context.Context, userID int64, key string) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type SearchService interface {
+	Search(ctx context.Context, q *model.SearchQuery) (*model.SearchResults, error)
+}
+
+type TagService interface {
+	CreateTag(ctx context.Context, tag *model.Tag) (int64, error)
+	GetTag(ctx context.Context, userID, tagID int64) (*model.Tag, error)
+	ListTags(ctx context.Context, userID int64) ([]*model.Tag, error)
+	UpdateTag(ctx context.Context, params *model.UpdateTagParams) (*model.Tag, error)
+	DeleteTag(ctx context.Context, userID, tagID int64) error
+	SetWorkoutTags(ctx context.Context, params *model.SetWorkoutTagsParams) ([]*model.Tag, error)
+	GetTagUsage(ctx context.Context, userID int64) ([]*model.TagUsage, error)
+}
+
+type ResolverService interface {
+	Resolve(ctx context.Context, q *model.ResolveQuery) ([]*model.ExerciseCandidate, error)
+	ListAliases(ctx context.Context, userID, exerciseID int64) ([]*model.ExerciseAlias, error)
+	AddAlias(ctx context.Context, alias *model.ExerciseAlias) (*model.ExerciseAlias, error)
+	DeleteAlias(ctx context.Context, userID, aliasID int64) error
+}
+
+type MediaService interface {
+	Upload(ctx context.Context, params *model.UploadMediaParams, r io.Reader) (*model.ExerciseMedia, error)
+	List(ctx context.Context, exerciseID int64) ([]*model.ExerciseMedia, error)
+	Open(ctx context.Context, mediaID int64) (*model.ExerciseMedia, io.ReadCloser, error)
+	Delete(ctx context.Context, userID, mediaID int64) error
+}
 
-	UpdatePersonalRecord(ctx context.Context, userID, exerciseID int64, weight float64, reps int) error
-	GetPersonalRecords(ctx context.Context, userId int64) ([]*model.UserRecord, error)
+type CoachService interface {
+	InviteAthlete(ctx context.Context, params *model.InviteAthleteParams) (*model.Coaching, error)
+	ListAthletes(ctx context.Context, coachID int64) ([]*model.Coaching, error)
+	RemoveAthlete(ctx context.Context, coachID, athleteID int64) error
+	WithdrawInvitation(ctx context.Context, coachID, coachingID int64) error
+	ListCoaches(ctx context.Context, athleteID int64) ([]*model.Coaching, error)
+	AcceptCoach(ctx context.Context, athleteID, coachID int64) (*model.Coaching, error)
+	UpdateCoach(ctx context.Context, params *model.UpdateCoachingParams) (*model.Coaching, error)
+	RemoveCoach(ctx context.Context, athleteID, coachID int64) error
+	GetDashboard(ctx context.Context, coachID int64) ([]*model.AthleteSummary, error)
 }
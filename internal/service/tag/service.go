@@ -0,0 +1,137 @@
+package tag
+
+import (
+	"context"
+	"slices"
+
+	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/biryanim/workoutbook/internal/service"
+)
+
+var _ service.TagService = (*serv)(nil)
+
+type serv struct {
+	tagRepository     repository.TagRepository
+	workoutRepository repository.WorkoutRepository
+	txManager         db.TxManager
+}
+
+func New(tagRepository repository.TagRepository, workoutRepository repository.WorkoutRepository, txManager db.TxManager) *serv {
+	return &serv{
+		tagRepository:     tagRepository,
+		workoutRepository: workoutRepository,
+		txManager:         txManager,
+	}
+}
+
+func (s *serv) CreateTag(ctx context.Context, tag *model.Tag) (int64, error) {
+	id, err := s.tagRepository.CreateTag(ctx, tag)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *serv) GetTag(ctx context.Context, userID, tagID int64) (*model.Tag, error) {
+	tag, err := s.tagRepository.GetTag(ctx, tagID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+func (s *serv) ListTags(ctx context.Context, userID int64) ([]*model.Tag, error) {
+	tags, err := s.tagRepository.ListTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (s *serv) UpdateTag(ctx context.Context, params *model.UpdateTagParams) (*model.Tag, error) {
+	var tag *model.Tag
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		if params.Name != nil || params.Color != nil {
+			err := s.tagRepository.UpdateTag(ctx, params)
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		tag, err = s.tagRepository.GetTag(ctx, params.ID, params.UserID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// DeleteTag deletes the tag and detaches it from the workouts.
+func (s *serv) DeleteTag(ctx context.Context, userID, tagID int64) error {
+	return s.tagRepository.DeleteTag(ctx, tagID, userID)
+}
+
+// SetWorkoutTags replaces the tags of the workout and returns them.
+func (s *serv) SetWorkoutTags(ctx context.Context, params *model.SetWorkoutTagsParams) ([]*model.Tag, error) {
+	tagIDs := slices.Clone(params.TagIDs)
+	slices.Sort(tagIDs)
+	tagIDs = slices.Compact(tagIDs)
+
+	var tags []*model.Tag
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		ok, err := s.workoutRepository.IsUserHaveWorkout(ctx, params.UserID, params.WorkoutID, model.OwnerOnly)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return apperrors.ErrWorkoutNotFound
+		}
+
+		if len(tagIDs) > 0 {
+			count, err := s.tagRepository.CountUserTags(ctx, params.UserID, tagIDs)
+			if err != nil {
+				return err
+			}
+			if count != len(tagIDs) {
+				return apperrors.ErrTagNotFound
+			}
+		}
+
+		err = s.tagRepository.SetWorkoutTags(ctx, params.WorkoutID, tagIDs)
+		if err != nil {
+			return err
+		}
+
+		workoutTags, err := s.tagRepository.ListWorkoutTags(ctx, []int64{params.WorkoutID})
+		if err != nil {
+			return err
+		}
+		for _, wt := range workoutTags {
+			tags = append(tags, wt.Tag)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+func (s *serv) GetTagUsage(ctx context.Context, userID int64) ([]*model.TagUsage, error) {
+	usage, err := s.tagRepository.GetTagUsage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,100 @@
+package user
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+	"github.com/biryanim/workoutbook/internal/service"
+)
+
+var _ service.UserService = (*serv)(nil)
+
+type serv struct {
+	userRepository repository.UserRepository
+	txManager      db.TxManager
+}
+
+func New(userRepository repository.UserRepository, txManager db.TxManager) *serv {
+	return &serv{
+		userRepository: userRepository,
+		txManager:      txManager,
+	}
+}
+
+func (s *serv) GetProfile(ctx context.Context, userID int64) (*model.User, error) {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *serv) UpdateProfile(ctx context.Context, params *model.UpdateProfileParams) error {
+	if params.Timezone != nil {
+		if _, err := time.LoadLocation(*params.Timezone); err != nil || *params.Timezone == "" {
+			return apperrors.ErrInvalidTimezone
+		}
+	}
+
+	return s.userRepository.UpdateProfile(ctx, params)
+}
+
+func (s *serv) AddBodyWeight(ctx context.Context, bw *model.BodyWeight) (int64, error) {
+	if bw.MeasuredAt.IsZero() {
+		bw.MeasuredAt = time.Now().UTC()
+	}
+
+	id, err := s.userRepository.AddBodyWeight(ctx, bw)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *serv) ListBodyWeights(ctx context.Context, userID int64) ([]*model.BodyWeight, error) {
+	weights, err := s.userRepository.ListBodyWeights(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return weights, nil
+}
+
+// GetEquipment returns the equipment the user has; none means any.
+func (s *serv) GetEquipment(ctx context.Context, userID int64) ([]*model.Equipment, error) {
+	equipment, err := s.userRepository.ListEquipment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return equipment, nil
+}
+
+// UpdateEquipment replaces the equipment the user has with the one of the
+// codes; an empty list makes any equipment available again.
+func (s *serv) UpdateEquipment(ctx context.Context, userID int64, codes []string) ([]*model.Equipment, error) {
+	codes = slices.Compact(slices.Sorted(slices.Values(codes)))
+
+	var equipment []*model.Equipment
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		err := s.userRepository.SetEquipment(ctx, userID, codes)
+		if err != nil {
+			return err
+		}
+
+		equipment, err = s.userRepository.ListEquipment(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return equipment, nil
+}
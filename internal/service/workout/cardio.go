@@ -0,0 +1,38 @@
+package workout
+
+import "github.com/biryanim/workoutbook/internal/model"
+
+// cardioMetrics derives pace and speed from the logged distance (km) and
+// duration (seconds) and estimates energy expenditure as MET x bodyweight (kg)
+// x hours.
+func cardioMetrics(we *model.WorkoutExercise, bodyweight float64) *model.CardioMetrics {
+	if we.Duration <= 0 {
+		return nil
+	}
+
+	metrics := &model.CardioMetrics{}
+	hours := float64(we.Duration) / 3600
+
+	if we.Distance > 0 {
+		pace := float64(we.Duration) / we.Distance
+		speed := we.Distance / hours
+		metrics.PaceSecondsPerKm, metrics.SpeedKmh = &pace, &speed
+	}
+
+	if bodyweight > 0 && we.Exercise.MET.Valid {
+		calories := we.Exercise.MET.Float64 * bodyweight * hours
+		metrics.Calories = &calories
+	}
+
+	return metrics
+}
+
+// splitPace returns the pace of a split in seconds per km, or nil when the
+// split has no distance.
+func splitPace(sp *model.Split) *float64 {
+	if sp.Distance <= 0 || sp.Duration <= 0 {
+		return nil
+	}
+	pace := float64(sp.Duration) / sp.Distance
+	return &pace
+}
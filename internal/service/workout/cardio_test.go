@@ -0,0 +1,119 @@
+package workout
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func TestCardioMetrics(t *testing.T) {
+	run := func(distance float64, duration int, met float64) *model.WorkoutExercise {
+		return &model.WorkoutExercise{
+			Distance: distance,
+			Duration: duration,
+			Exercise: model.Exercise{MET: sql.NullFloat64{Float64: met, Valid: met > 0}},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		we         *model.WorkoutExercise
+		bodyweight float64
+		none       bool
+		pace       *float64
+		speed      *float64
+		calories   *float64
+	}{
+		{
+			name:       "10 km in 50 minutes",
+			we:         run(10, 3000, 9.8),
+			bodyweight: 70,
+			pace:       floatPtr(300),
+			speed:      floatPtr(12),
+			calories:   floatPtr(571.67),
+		},
+		{
+			name:       "half marathon",
+			we:         run(21.0975, 6300, 9.8),
+			bodyweight: 80,
+			pace:       floatPtr(298.61),
+			speed:      floatPtr(12.06),
+			calories:   floatPtr(1372),
+		},
+		{
+			name:       "no distance, e.g. a rowing machine by time",
+			we:         run(0, 1800, 7),
+			bodyweight: 90,
+			calories:   floatPtr(315),
+		},
+		{
+			name:  "unknown bodyweight",
+			we:    run(5, 1500, 9.8),
+			pace:  floatPtr(300),
+			speed: floatPtr(12),
+		},
+		{
+			name:       "exercise without a MET",
+			we:         run(5, 1500, 0),
+			bodyweight: 70,
+			pace:       floatPtr(300),
+			speed:      floatPtr(12),
+		},
+		{
+			name:       "no duration",
+			we:         run(5, 0, 9.8),
+			bodyweight: 70,
+			none:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cardioMetrics(tt.we, tt.bodyweight)
+			if tt.none {
+				if got != nil {
+					t.Fatalf("cardioMetrics() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("cardioMetrics() = nil")
+			}
+
+			for _, m := range []struct {
+				name      string
+				got, want *float64
+			}{
+				{"pace", got.PaceSecondsPerKm, tt.pace},
+				{"speed", got.SpeedKmh, tt.speed},
+				{"calories", got.Calories, tt.calories},
+			} {
+				if !equalScore(m.got, m.want) {
+					t.Errorf("%s = %v, want %v", m.name, formatScore(m.got), formatScore(m.want))
+				}
+			}
+		})
+	}
+}
+
+func TestSplitPace(t *testing.T) {
+	tests := []struct {
+		name  string
+		split *model.Split
+		want  *float64
+	}{
+		{"kilometer split", &model.Split{Distance: 1, Duration: 275}, floatPtr(275)},
+		{"partial last split", &model.Split{Distance: 0.5, Duration: 140}, floatPtr(280)},
+		{"no distance", &model.Split{Duration: 60}, nil},
+		{"no duration", &model.Split{Distance: 1}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitPace(tt.split); !equalScore(got, tt.want) {
+				t.Errorf("splitPace() = %v, want %v", formatScore(got), formatScore(tt.want))
+			}
+		})
+	}
+}
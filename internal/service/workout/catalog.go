@@ -0,0 +1,155 @@
+package workout
+
+import (
+	"context"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+// GetExercise returns the exercise in the user's language; locale is the one
+// the client accepts.
+func (s *serv) GetExercise(ctx context.Context, userID, exerciseID int64, locale string) (*model.Exercise, error) {
+	var exercise *model.Exercise
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		var err error
+		exercise, err = s.workoutRepository.GetExerciseByID(ctx, exerciseID)
+		if err != nil {
+			return err
+		}
+
+		locale, err = s.resolveLocale(ctx, userID, locale)
+		if err != nil {
+			return err
+		}
+
+		err = s.localize(ctx, locale, []*model.Exercise{exercise})
+		if err != nil {
+			return err
+		}
+
+		return s.attachMuscles(ctx, []*model.Exercise{exercise})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return exercise, nil
+}
+
+func (s *serv) ListEquipment(ctx context.Context) ([]*model.Equipment, error) {
+	equipment, err := s.workoutRepository.ListEquipment(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return equipment, nil
+}
+
+func (s *serv) ListMuscles(ctx context.Context) ([]*model.Muscle, error) {
+	muscles, err := s.workoutRepository.ListMuscles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return muscles, nil
+}
+
+// GetSubstitutes suggests alternatives to the exercise, for when its
+// equipment is busy, among the exercises the user has the equipment for.
+// q.Locale is the language the client accepts.
+func (s *serv) GetSubstitutes(ctx context.Context, q *model.SubstitutesQuery) ([]*model.Substitute, error) {
+	var substitutes []*model.Substitute
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		_, err := s.workoutRepository.GetExerciseByID(ctx, q.ExerciseID)
+		if err != nil {
+			return err
+		}
+
+		query := *q
+		query.Locale, err = s.resolveLocale(ctx, q.UserID, q.Locale)
+		if err != nil {
+			return err
+		}
+
+		substitutes, err = s.workoutRepository.ListSubstitutes(ctx, &query)
+		if err != nil {
+			return err
+		}
+
+		exercises := make([]*model.Exercise, 0, len(substitutes))
+		for _, sub := range substitutes {
+			exercises = append(exercises, sub.Exercise)
+		}
+		return s.attachMuscles(ctx, exercises)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return substitutes, nil
+}
+
+// attachMuscles loads the primary and secondary muscles of the exercises into
+// them.
+func (s *serv) attachMuscles(ctx context.Context, exercises []*model.Exercise) error {
+	ids := make([]int64, 0, len(exercises))
+	byID := make(map[int64]*model.Exercise, len(exercises))
+	for _, e := range exercises {
+		ids = append(ids, e.ID)
+		byID[e.ID] = e
+	}
+
+	muscles, err := s.workoutRepository.ListExerciseMuscles(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, em := range muscles {
+		e := byID[em.ExerciseID]
+		if em.Primary {
+			e.PrimaryMuscles = append(e.PrimaryMuscles, em.Muscle)
+		} else {
+			e.SecondaryMuscles = append(e.SecondaryMuscles, em.Muscle)
+		}
+	}
+
+	return nil
+}
+
+// resolveLocale picks the language to return the catalog in: the one set in
+// the user's profile, then the accepted one, then the catalog's own.
+func (s *serv) resolveLocale(ctx context.Context, userID int64, accepted string) (string, error) {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return model.ResolveLocale(user.Locale, accepted), nil
+}
+
+// localize replaces the names and descriptions of the exercises with their
+// translations to the locale; exercises without one keep the built-in text.
+func (s *serv) localize(ctx context.Context, locale string, exercises []*model.Exercise) error {
+	ids := make([]int64, 0, len(exercises))
+	byID := make(map[int64][]*model.Exercise, len(exercises))
+	for _, e := range exercises {
+		if _, ok := byID[e.ID]; !ok {
+			ids = append(ids, e.ID)
+		}
+		byID[e.ID] = append(byID[e.ID], e)
+	}
+
+	translations, err := s.workoutRepository.ListExerciseTranslations(ctx, locale, ids)
+	if err != nil {
+		return err
+	}
+	for _, t := range translations {
+		for _, e := range byID[t.ExerciseID] {
+			e.Name = t.Name
+			if t.Description.Valid {
+				e.Description = t.Description.String
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package workout
+
+import (
+	"context"
+	"database/sql"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+// checkCoaching makes sure the coach is accepted by the athlete with a
+// permission that includes the required one.
+func (s *serv) checkCoaching(ctx context.Context, coachID, athleteID int64, permission string) error {
+	coaching, err := s.coachRepository.GetCoaching(ctx, coachID, athleteID)
+	if err != nil {
+		return err
+	}
+	if !coaching.Allows(permission) {
+		return apperrors.ErrCoachingNotFound
+	}
+
+	return nil
+}
+
+// GetAthleteWorkouts returns a page of the athlete's workouts to a coach with
+// the view permission.
+func (s *serv) GetAthleteWorkouts(ctx context.Context, coachID, athleteID int64, filter *model.WorkoutsFilter) (*model.WorkoutsPage, error) {
+	if err := s.checkCoaching(ctx, coachID, athleteID, model.PermissionView); err != nil {
+		return nil, err
+	}
+
+	return s.GetWorkouts(ctx, athleteID, filter)
+}
+
+// AssignWorkout plans a workout for the athlete in workout.UserID on behalf
+// of a coach with the assign permission, who then fills it with exercises
+// until the athlete starts it. The athlete runs the session, so it is
+// planned without one.
+func (s *serv) AssignWorkout(ctx context.Context, coachID int64, workout *model.Workout) (int64, error) {
+	if err := s.checkCoaching(ctx, coachID, workout.UserID, model.PermissionAssign); err != nil {
+		return 0, err
+	}
+
+	workout.StartedAt = sql.NullTime{}
+	workout.EndedAt = sql.NullTime{}
+	workout.SessionRPE = sql.NullFloat64{}
+	workout.AssignedBy = sql.NullInt64{Int64: coachID, Valid: true}
+
+	id, err := s.workoutRepository.CreateWorkout(ctx, workout)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// AddComment comments on a workout of the author or of an athlete the author
+// coaches with the comment permission.
+func (s *serv) AddComment(ctx context.Context, comment *model.WorkoutComment) (*model.WorkoutComment, error) {
+	if comment.Body == "" {
+		return nil, apperrors.ErrInvalidInput
+	}
+
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		_, err := s.workoutRepository.GetWorkoutByID(ctx, comment.WorkoutID, comment.AuthorID, model.PermissionComment)
+		if err != nil {
+			return err
+		}
+
+		author, err := s.userRepository.GetByID(ctx, comment.AuthorID)
+		if err != nil {
+			return err
+		}
+		comment.AuthorName = author.Name
+
+		comment.ID, err = s.workoutRepository.AddWorkoutComment(ctx, comment)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// ListComments returns the comments on a workout the user can view.
+func (s *serv) ListComments(ctx context.Context, userID, workoutID int64) ([]*model.WorkoutComment, error) {
+	has, err := s.workoutRepository.IsUserHaveWorkout(ctx, userID, workoutID, model.PermissionView)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, apperrors.ErrWorkoutNotFound
+	}
+
+	comments, err := s.workoutRepository.ListWorkoutComments(ctx, workoutID)
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
@@ -0,0 +1,249 @@
+package workout
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/importer"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/pkg/errors"
+)
+
+// ImportActivity creates a workout from a recorded activity file. The exercise
+// is taken from imp.ExerciseID or, when it is zero, from the sport recorded in
+// the file.
+func (s *serv) ImportActivity(ctx context.Context, imp *model.ActivityImport) (int64, error) {
+	activity, err := importer.Parse(imp.Format, imp.Raw)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", apperrors.ErrInvalidActivity, err)
+	}
+
+	if len(activity.Sets) > 0 {
+		return s.importStrengthActivity(ctx, activity, imp)
+	}
+
+	we := importer.Summarize(activity)
+
+	var workoutID int64
+	err = s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		we.ExerciseID = imp.ExerciseID
+		if we.ExerciseID == 0 {
+			we.ExerciseID, err = s.mapExercise(ctx, imp.UserID, model.MappingSourceSport, activity.Sport)
+			if err != nil {
+				return err
+			}
+		}
+
+		workoutID, err = s.workoutRepository.CreateWorkout(ctx, importedWorkout(activity, imp))
+		if err != nil {
+			return err
+		}
+
+		we.WorkoutID = workoutID
+		return s.saveActivity(ctx, activity, we, imp)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return workoutID, nil
+}
+
+// saveActivity stores the summarized exercise entry with its splits, samples
+// and the raw file and updates the personal record. The entry is checked
+// against the exercise's tracking profile like a manually logged one.
+func (s *serv) saveActivity(ctx context.Context, activity *model.Activity, we *model.WorkoutExercise, imp *model.ActivityImport) error {
+	id, err := s.addExercise(ctx, imp.UserID, we, false)
+	if err != nil {
+		return err
+	}
+
+	err = s.workoutRepository.AddSamples(ctx, id, activity.Points)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.workoutRepository.AddTrack(ctx, &model.WorkoutTrack{
+		WorkoutID:         we.WorkoutID,
+		WorkoutExerciseID: sql.NullInt64{Int64: id, Valid: true},
+		Format:            activity.Format,
+		Raw:               imp.Raw,
+	})
+	return err
+}
+
+func importedWorkout(activity *model.Activity, imp *model.ActivityImport) *model.Workout {
+	name := imp.Name
+	if name == "" {
+		name = activity.Name
+	}
+	if name == "" {
+		name = "Imported " + activity.Sport
+	}
+
+	date := activity.StartedAt
+	if date.IsZero() {
+		date = time.Now().UTC()
+	}
+
+	return &model.Workout{
+		UserID:    imp.UserID,
+		Date:      date,
+		Name:      name,
+		StartedAt: sql.NullTime{Time: activity.StartedAt, Valid: !activity.StartedAt.IsZero()},
+		EndedAt:   sql.NullTime{Time: activity.EndedAt, Valid: !activity.EndedAt.IsZero()},
+	}
+}
+
+// importStrengthActivity logs the sets recorded by a watch. Consecutive sets
+// of the same exercise with equal reps and weight are merged into one entry;
+// sets whose exercise category is not mapped to the catalog are skipped.
+func (s *serv) importStrengthActivity(ctx context.Context, activity *model.Activity, imp *model.ActivityImport) (int64, error) {
+	var workoutID int64
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		var (
+			entries []*model.WorkoutExercise
+			last    *model.WorkoutExercise
+		)
+		for _, set := range activity.Sets {
+			exerciseID, err := s.mapFITExercise(ctx, imp.UserID, set)
+			if errors.Is(err, apperrors.ErrExerciseNotMapped) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if last != nil && last.ExerciseID == exerciseID && last.Reps == set.Reps && last.Weight == set.Weight {
+				last.Sets++
+				last.Duration += set.Duration
+				continue
+			}
+			last = &model.WorkoutExercise{
+				ExerciseID: exerciseID,
+				Sets:       1,
+				Reps:       set.Reps,
+				Weight:     set.Weight,
+				Duration:   set.Duration,
+			}
+			entries = append(entries, last)
+		}
+		if len(entries) == 0 {
+			return apperrors.ErrExerciseNotMapped
+		}
+
+		var err error
+		workoutID, err = s.workoutRepository.CreateWorkout(ctx, importedWorkout(activity, imp))
+		if err != nil {
+			return err
+		}
+
+		for _, we := range entries {
+			we.WorkoutID = workoutID
+			if _, err = s.addExercise(ctx, imp.UserID, we, false); err != nil {
+				return err
+			}
+		}
+
+		_, err = s.workoutRepository.AddTrack(ctx, &model.WorkoutTrack{
+			WorkoutID: workoutID,
+			Format:    activity.Format,
+			Raw:       imp.Raw,
+		})
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return workoutID, nil
+}
+
+// ListExerciseMappings lists the mappings of source, of every source when it
+// is empty.
+func (s *serv) ListExerciseMappings(ctx context.Context, source string) ([]*model.ExerciseMapping, error) {
+	mappings, err := s.workoutRepository.ListExerciseMappings(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	return mappings, nil
+}
+
+// SetExerciseMapping maps the external name onto the exercise. Mappings are
+// shared by all users' imports, so only administrators change them.
+func (s *serv) SetExerciseMapping(ctx context.Context, userID int64, mapping *model.ExerciseMapping) error {
+	if err := s.checkAdmin(ctx, userID); err != nil {
+		return err
+	}
+
+	_, err := s.workoutRepository.GetExerciseByID(ctx, mapping.ExerciseID)
+	if err != nil {
+		return err
+	}
+
+	return s.workoutRepository.SetExerciseMapping(ctx, mapping)
+}
+
+// DeleteExerciseMapping deletes the mapping of the external name; imports
+// fall back to resolving the name.
+func (s *serv) DeleteExerciseMapping(ctx context.Context, userID int64, source, externalName string) error {
+	if err := s.checkAdmin(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.workoutRepository.DeleteExerciseMapping(ctx, source, externalName)
+}
+
+func (s *serv) checkAdmin(ctx context.Context, userID int64) error {
+	user, err := s.userRepository.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsAdmin {
+		return apperrors.ErrMappingForbidden
+	}
+
+	return nil
+}
+
+// mapFITExercise looks up the most specific mapping first, "category:subtype",
+// and falls back to the whole category.
+func (s *serv) mapFITExercise(ctx context.Context, userID int64, set *model.ActivitySet) (int64, error) {
+	if set.HasSubtype {
+		id, err := s.workoutRepository.GetMappedExerciseID(ctx, model.MappingSourceFITCategory, fmt.Sprintf("%s:%d", set.Category, set.Subtype))
+		if !errors.Is(err, apperrors.ErrExerciseNotMapped) {
+			return id, err
+		}
+	}
+
+	return s.mapExercise(ctx, userID, model.MappingSourceFITCategory, set.Category)
+}
+
+// mapExercise looks up the mapping of the external name and, when there is
+// none, takes the exercise the name resolves to if the match is confident,
+// so "bench_press" maps to the bench press without a mapping of its own. Only
+// the built-in aliases and the ones of the importing user are matched.
+func (s *serv) mapExercise(ctx context.Context, userID int64, source, externalName string) (int64, error) {
+	id, err := s.workoutRepository.GetMappedExerciseID(ctx, source, externalName)
+	if !errors.Is(err, apperrors.ErrExerciseNotMapped) {
+		return id, err
+	}
+
+	text := model.NormalizeExerciseName(externalName)
+	if text == "" {
+		return 0, err
+	}
+	candidates, resolveErr := s.workoutRepository.ResolveExercises(ctx, &model.ResolveQuery{UserID: userID, Text: text, Limit: 1})
+	if resolveErr != nil {
+		return 0, resolveErr
+	}
+	if len(candidates) == 0 || candidates[0].Score < model.ConfidentMatchScore {
+		return 0, err
+	}
+
+	return candidates[0].Exercise.ID, nil
+}
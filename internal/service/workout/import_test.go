@@ -0,0 +1,121 @@
+package workout
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+)
+
+// resolveRepository answers mapping lookups from mappings and resolves every
+// name to candidates; any other call panics.
+type resolveRepository struct {
+	repository.WorkoutRepository
+
+	mappings   map[string]int64
+	candidates []*model.ExerciseCandidate
+	queries    []*model.ResolveQuery
+}
+
+func (r *resolveRepository) GetMappedExerciseID(_ context.Context, source, externalName string) (int64, error) {
+	id, ok := r.mappings[source+"/"+externalName]
+	if !ok {
+		return 0, apperrors.ErrExerciseNotMapped
+	}
+	return id, nil
+}
+
+func (r *resolveRepository) ResolveExercises(_ context.Context, q *model.ResolveQuery) ([]*model.ExerciseCandidate, error) {
+	r.queries = append(r.queries, q)
+	return r.candidates, nil
+}
+
+func candidate(id int64, score float64) *model.ExerciseCandidate {
+	return &model.ExerciseCandidate{Exercise: &model.Exercise{ID: id}, Score: score}
+}
+
+func TestMapExercise(t *testing.T) {
+	const userID = 7
+
+	tests := []struct {
+		name         string
+		externalName string
+		mappings     map[string]int64
+		candidates   []*model.ExerciseCandidate
+		want         int64
+		wantErr      error
+		resolved     string
+	}{
+		{
+			name:         "mapping wins",
+			externalName: "Bench Press",
+			mappings:     map[string]int64{model.MappingSourceFITCategory + "/Bench Press": 3},
+			candidates:   []*model.ExerciseCandidate{candidate(4, 1)},
+			want:         3,
+		},
+		{
+			name:         "confident match",
+			externalName: "Bench_Press",
+			candidates:   []*model.ExerciseCandidate{candidate(4, 0.9), candidate(5, 0.85)},
+			want:         4,
+			resolved:     "bench press",
+		},
+		{
+			name:         "match at the threshold",
+			externalName: "bench",
+			candidates:   []*model.ExerciseCandidate{candidate(4, model.ConfidentMatchScore)},
+			want:         4,
+			resolved:     "bench",
+		},
+		{
+			name:         "weak match is not taken",
+			externalName: "bench",
+			candidates:   []*model.ExerciseCandidate{candidate(4, 0.79)},
+			wantErr:      apperrors.ErrExerciseNotMapped,
+			resolved:     "bench",
+		},
+		{
+			name:         "no candidates",
+			externalName: "zercher carry",
+			wantErr:      apperrors.ErrExerciseNotMapped,
+			resolved:     "zercher carry",
+		},
+		{
+			name:         "nothing to resolve",
+			externalName: "!!!",
+			candidates:   []*model.ExerciseCandidate{candidate(4, 1)},
+			wantErr:      apperrors.ErrExerciseNotMapped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &resolveRepository{mappings: tt.mappings, candidates: tt.candidates}
+			s := &serv{workoutRepository: repo}
+
+			got, err := s.mapExercise(context.Background(), userID, model.MappingSourceFITCategory, tt.externalName)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("mapExercise() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("mapExercise() = %d, want %d", got, tt.want)
+			}
+
+			if tt.resolved == "" {
+				if len(repo.queries) != 0 {
+					t.Errorf("resolved %q, want no lookup", repo.queries[0].Text)
+				}
+				return
+			}
+			if len(repo.queries) != 1 {
+				t.Fatalf("resolved %d times, want once", len(repo.queries))
+			}
+			if q := repo.queries[0]; q.Text != tt.resolved || q.UserID != userID || q.Limit != 1 {
+				t.Errorf("resolve query = %+v, want text %q of user %d", *q, tt.resolved, userID)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package workout
+
+import (
+	"context"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+// groupKind names a group without an explicit kind after its size.
+func groupKind(size int) string {
+	switch {
+	case size <= 1:
+		return model.ExerciseGroupSingle
+	case size == 2:
+		return model.ExerciseGroupSuperset
+	default:
+		return model.ExerciseGroupGiantSet
+	}
+}
+
+// exerciseBlocks groups position-ordered exercises into blocks. A group is
+// placed where its first exercise is, even if the rest come later.
+func exerciseBlocks(exercises []*model.WorkoutExercise, groups []*model.ExerciseGroup) []*model.ExerciseBlock {
+	kinds := make(map[int32]string, len(groups))
+	for _, g := range groups {
+		kinds[g.GroupID] = g.Kind
+	}
+
+	var blocks []*model.ExerciseBlock
+	byGroup := make(map[int32]*model.ExerciseBlock)
+	for _, we := range exercises {
+		if !we.GroupID.Valid {
+			blocks = append(blocks, &model.ExerciseBlock{
+				Kind:      model.ExerciseGroupSingle,
+				Exercises: []*model.WorkoutExercise{we},
+			})
+			continue
+		}
+
+		block, ok := byGroup[we.GroupID.Int32]
+		if !ok {
+			block = &model.ExerciseBlock{GroupID: we.GroupID}
+			byGroup[we.GroupID.Int32] = block
+			blocks = append(blocks, block)
+		}
+		block.Exercises = append(block.Exercises, we)
+	}
+
+	for id, block := range byGroup {
+		block.Kind = kinds[id]
+		if block.Kind == "" {
+			block.Kind = groupKind(len(block.Exercises))
+		}
+	}
+
+	return blocks
+}
+
+// ReorderExercises puts the workout's exercises in the given order. The order
+// must list every exercise of the workout exactly once. Coaches with the
+// assign permission may reorder the workouts they assigned until the athlete
+// starts them.
+func (s *serv) ReorderExercises(ctx context.Context, params *model.ReorderExercisesParams) error {
+	return s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		if _, err := s.workoutRepository.GetWorkoutByID(ctx, params.WorkoutID, params.UserID, model.PermissionAssign); err != nil {
+			return err
+		}
+
+		exercises, err := s.workoutRepository.GetExercisesByWorkoutID(ctx, params.WorkoutID)
+		if err != nil {
+			return err
+		}
+
+		if err = checkOrder(exercises, params.Order); err != nil {
+			return err
+		}
+
+		if err = s.workoutRepository.UpdateExerciseOrder(ctx, params.WorkoutID, params.Order); err != nil {
+			return err
+		}
+
+		for _, g := range params.Groups {
+			g.WorkoutID = params.WorkoutID
+		}
+		return s.workoutRepository.UpsertExerciseGroups(ctx, params.Groups)
+	})
+}
+
+// checkOrder makes sure the order lists every exercise of the workout exactly
+// once.
+func checkOrder(exercises []*model.WorkoutExercise, order []*model.ExerciseOrder) error {
+	if len(exercises) != len(order) {
+		return apperrors.ErrInvalidOrder
+	}
+
+	pending := make(map[int64]bool, len(exercises))
+	for _, we := range exercises {
+		pending[we.ID] = true
+	}
+	for _, o := range order {
+		if !pending[o.WorkoutExerciseID] {
+			return apperrors.ErrInvalidOrder
+		}
+		delete(pending, o.WorkoutExerciseID)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package workout
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"testing"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func groupedEntry(id int64, group int32) *model.WorkoutExercise {
+	return &model.WorkoutExercise{ID: id, GroupID: sql.NullInt32{Int32: group, Valid: group != 0}}
+}
+
+func TestExerciseBlocks(t *testing.T) {
+	type block struct {
+		kind string
+		ids  []int64
+	}
+
+	tests := []struct {
+		name      string
+		exercises []*model.WorkoutExercise
+		groups    []*model.ExerciseGroup
+		want      []block
+	}{
+		{"no exercises", nil, nil, nil},
+		{
+			name:      "singles",
+			exercises: []*model.WorkoutExercise{groupedEntry(1, 0), groupedEntry(2, 0)},
+			want:      []block{{model.ExerciseGroupSingle, []int64{1}}, {model.ExerciseGroupSingle, []int64{2}}},
+		},
+		{
+			name:      "superset by size",
+			exercises: []*model.WorkoutExercise{groupedEntry(1, 0), groupedEntry(2, 1), groupedEntry(3, 1), groupedEntry(4, 0)},
+			want: []block{
+				{model.ExerciseGroupSingle, []int64{1}},
+				{model.ExerciseGroupSuperset, []int64{2, 3}},
+				{model.ExerciseGroupSingle, []int64{4}},
+			},
+		},
+		{
+			name:      "giant set by size",
+			exercises: []*model.WorkoutExercise{groupedEntry(1, 2), groupedEntry(2, 2), groupedEntry(3, 2)},
+			want:      []block{{model.ExerciseGroupGiantSet, []int64{1, 2, 3}}},
+		},
+		{
+			name:      "group of one",
+			exercises: []*model.WorkoutExercise{groupedEntry(1, 5)},
+			want:      []block{{model.ExerciseGroupSingle, []int64{1}}},
+		},
+		{
+			name:      "explicit kind wins over size",
+			exercises: []*model.WorkoutExercise{groupedEntry(1, 1), groupedEntry(2, 1), groupedEntry(3, 1)},
+			groups:    []*model.ExerciseGroup{{GroupID: 1, Kind: model.ExerciseGroupCircuit}},
+			want:      []block{{model.ExerciseGroupCircuit, []int64{1, 2, 3}}},
+		},
+		{
+			name:      "group is placed at its first exercise",
+			exercises: []*model.WorkoutExercise{groupedEntry(1, 1), groupedEntry(2, 0), groupedEntry(3, 1), groupedEntry(4, 2), groupedEntry(5, 2)},
+			want: []block{
+				{model.ExerciseGroupSuperset, []int64{1, 3}},
+				{model.ExerciseGroupSingle, []int64{2}},
+				{model.ExerciseGroupSuperset, []int64{4, 5}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := exerciseBlocks(tt.exercises, tt.groups)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d blocks, want %d", len(got), len(tt.want))
+			}
+			for i, b := range got {
+				ids := make([]int64, 0, len(b.Exercises))
+				for _, we := range b.Exercises {
+					ids = append(ids, we.ID)
+				}
+				if b.Kind != tt.want[i].kind || !slices.Equal(ids, tt.want[i].ids) {
+					t.Errorf("block %d = %s %v, want %s %v", i, b.Kind, ids, tt.want[i].kind, tt.want[i].ids)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckOrder(t *testing.T) {
+	exercises := []*model.WorkoutExercise{groupedEntry(1, 0), groupedEntry(2, 0), groupedEntry(3, 0)}
+	order := func(ids ...int64) []*model.ExerciseOrder {
+		o := make([]*model.ExerciseOrder, 0, len(ids))
+		for _, id := range ids {
+			o = append(o, &model.ExerciseOrder{WorkoutExerciseID: id})
+		}
+		return o
+	}
+
+	tests := []struct {
+		name      string
+		exercises []*model.WorkoutExercise
+		order     []*model.ExerciseOrder
+		valid     bool
+	}{
+		{"same order", exercises, order(1, 2, 3), true},
+		{"permutation", exercises, order(3, 1, 2), true},
+		{"empty workout", nil, nil, true},
+		{"missing exercise", exercises, order(1, 2), false},
+		{"duplicate exercise", exercises, order(1, 2, 2), false},
+		{"foreign exercise", exercises, order(1, 2, 4), false},
+		{"extra exercise", exercises, order(1, 2, 3, 4), false},
+		{"order for an empty workout", nil, order(1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkOrder(tt.exercises, tt.order)
+			if tt.valid && err != nil {
+				t.Errorf("checkOrder() error = %v, want nil", err)
+			}
+			if !tt.valid && !errors.Is(err, apperrors.ErrInvalidOrder) {
+				t.Errorf("checkOrder() error = %v, want %v", err, apperrors.ErrInvalidOrder)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package workout
+
+import (
+	"context"
+	"math"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+const (
+	// progressionHistory is how many past sessions are looked at.
+	progressionHistory = 6
+	// deloadAfterMisses consecutive sessions short of the target reps drop
+	// the load by deloadFactor.
+	deloadAfterMisses = 3
+	deloadFactor      = 0.9
+
+	defaultLoadIncrement = 2.5
+)
+
+// sessionTarget returns the reps a session aimed for. Entries logged without
+// a target are held to the reps of the session before them.
+func sessionTarget(history []*model.ExerciseSession, i, targetReps int) int {
+	if history[i].TargetReps > 0 {
+		return history[i].TargetReps
+	}
+	if targetReps > 0 {
+		return targetReps
+	}
+	if i+1 < len(history) {
+		return history[i+1].Reps
+	}
+	return history[i].Reps
+}
+
+func roundToIncrement(weight, increment float64) float64 {
+	return math.Round(weight/increment) * increment
+}
+
+// suggestNext applies double progression to the history, newest session
+// first: add load once the target reps are hit, repeat it after a miss and
+// deload after deloadAfterMisses misses in a row. Exercises tracked without
+// load progress by reps and assisted ones by taking assistance off, which
+// ends at none; a deload adds assistance. Without reps to progress by, time
+// and distance are left to the athlete and only the load carries over.
+func suggestNext(exercise *model.Exercise, history []*model.ExerciseSession, targetReps int) *model.ProgressionSuggestion {
+	increment := exercise.LoadIncrement
+	if increment <= 0 {
+		increment = defaultLoadIncrement
+	}
+	tracked := profileMetrics(exercise.TrackingProfile)
+	assisted := exercise.TrackingProfile == model.TrackingAssisted
+
+	res := &model.ProgressionSuggestion{
+		ExerciseID: exercise.ID,
+		Action:     model.ProgressionStart,
+		Reps:       targetReps,
+		Increment:  increment,
+	}
+	if !tracked.reps {
+		res.Reps = 0
+	}
+	if len(history) == 0 {
+		return res
+	}
+
+	last := history[0]
+	res.Last = last
+	res.Sets = last.Sets
+	if !tracked.reps {
+		res.Action = model.ProgressionRepeat
+		if tracked.weight {
+			res.Weight = last.Weight
+		}
+		return res
+	}
+	if res.Reps == 0 {
+		res.Reps = sessionTarget(history, 0, 0)
+	}
+
+	for i := range history {
+		if history[i].Reps >= sessionTarget(history, i, targetReps) {
+			break
+		}
+		res.ConsecutiveMisses++
+	}
+
+	switch {
+	case res.ConsecutiveMisses == 0 && (!tracked.weight || last.Weight == 0):
+		res.Action = model.ProgressionAddReps
+		res.Reps = last.Reps + 1
+	case res.ConsecutiveMisses == 0 && assisted:
+		res.Action = model.ProgressionIncrease
+		res.Weight = math.Min(last.Weight+increment, 0)
+	case res.ConsecutiveMisses == 0:
+		res.Action = model.ProgressionIncrease
+		res.Weight = last.Weight + increment
+	case res.ConsecutiveMisses >= deloadAfterMisses && assisted:
+		res.Action = model.ProgressionDeload
+		res.Weight = math.Min(roundToIncrement(last.Weight*(2-deloadFactor), increment), last.Weight-increment)
+	case res.ConsecutiveMisses >= deloadAfterMisses && tracked.weight && last.Weight > 0:
+		res.Action = model.ProgressionDeload
+		res.Weight = math.Min(roundToIncrement(last.Weight*deloadFactor, increment), last.Weight-increment)
+		res.Weight = math.Max(res.Weight, 0)
+	default:
+		res.Action = model.ProgressionRepeat
+		if tracked.weight {
+			res.Weight = last.Weight
+		}
+	}
+
+	return res
+}
+
+func (s *serv) suggest(ctx context.Context, userID int64, exercise *model.Exercise, targetReps int) (*model.ProgressionSuggestion, error) {
+	history, err := s.workoutRepository.GetExerciseHistory(ctx, userID, exercise.ID, progressionHistory)
+	if err != nil {
+		return nil, err
+	}
+
+	return suggestNext(exercise, history, targetReps), nil
+}
+
+// SuggestNext suggests the load for the next session of an exercise. A zero
+// targetReps keeps the reps of the last session.
+func (s *serv) SuggestNext(ctx context.Context, userID, exerciseID int64, targetReps int) (*model.ProgressionSuggestion, error) {
+	exercise, err := s.workoutRepository.GetExerciseByID(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.suggest(ctx, userID, exercise, targetReps)
+}
@@ -0,0 +1,194 @@
+package workout
+
+import (
+	"testing"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func sessions(sets ...[3]float64) []*model.ExerciseSession {
+	history := make([]*model.ExerciseSession, 0, len(sets))
+	for _, s := range sets {
+		history = append(history, &model.ExerciseSession{Sets: 3, Reps: int(s[0]), Weight: s[1], TargetReps: int(s[2])})
+	}
+	return history
+}
+
+func TestSuggestNext(t *testing.T) {
+	tests := []struct {
+		name       string
+		exercise   *model.Exercise
+		history    []*model.ExerciseSession
+		targetReps int
+		want       model.ProgressionSuggestion
+	}{
+		{
+			name:       "no history",
+			exercise:   &model.Exercise{ID: 1},
+			targetReps: 5,
+			want:       model.ProgressionSuggestion{ExerciseID: 1, Action: model.ProgressionStart, Reps: 5, Increment: 2.5},
+		},
+		{
+			name:     "exercise increment",
+			exercise: &model.Exercise{ID: 1, LoadIncrement: 5},
+			history:  sessions([3]float64{5, 100, 5}),
+			want:     model.ProgressionSuggestion{ExerciseID: 1, Action: model.ProgressionIncrease, Sets: 3, Reps: 5, Weight: 105, Increment: 5},
+		},
+		{
+			name:       "target hit",
+			exercise:   &model.Exercise{ID: 1},
+			history:    sessions([3]float64{5, 100, 0}),
+			targetReps: 5,
+			want:       model.ProgressionSuggestion{ExerciseID: 1, Action: model.ProgressionIncrease, Sets: 3, Reps: 5, Weight: 102.5, Increment: 2.5},
+		},
+		{
+			name:     "logged target is kept",
+			exercise: &model.Exercise{ID: 1},
+			history:  sessions([3]float64{8, 60, 8}),
+			want:     model.ProgressionSuggestion{ExerciseID: 1, Action: model.ProgressionIncrease, Sets: 3, Reps: 8, Weight: 62.5, Increment: 2.5},
+		},
+		{
+			name:     "missing target falls back to the session before",
+			exercise: &model.Exercise{ID: 1},
+			history:  sessions([3]float64{4, 100, 0}, [3]float64{5, 100, 0}),
+			want:     model.ProgressionSuggestion{ExerciseID: 1, Action: model.ProgressionRepeat, Sets: 3, Reps: 5, Weight: 100, Increment: 2.5, ConsecutiveMisses: 1},
+		},
+		{
+			name:       "two misses repeat",
+			exercise:   &model.Exercise{ID: 1},
+			history:    sessions([3]float64{3, 100, 5}, [3]float64{4, 100, 5}, [3]float64{5, 97.5, 5}),
+			targetReps: 5,
+			want:       model.ProgressionSuggestion{ExerciseID: 1, Action: model.ProgressionRepeat, Sets: 3, Reps: 5, Weight: 100, Increment: 2.5, ConsecutiveMisses: 2},
+		},
+		{
+			name:       "three misses deload",
+			exercise:   &model.Exercise{ID: 1},
+			history:    sessions([3]float64{3, 100, 5}, [3]float64{4, 100, 5}, [3]float64{4, 100, 5}),
+			targetReps: 5,
+			want:       model.ProgressionSuggestion{ExerciseID: 1, Action: model.ProgressionDeload, Sets: 3, Reps: 5, Weight: 90, Increment: 2.5, ConsecutiveMisses: 3},
+		},
+		{
+			name:       "deload drops at least one increment",
+			exercise:   &model.Exercise{ID: 1},
+			history:    sessions([3]float64{3, 10, 5}, [3]float64{3, 10, 5}, [3]float64{3, 10, 5}),
+			targetReps: 5,
+			want:       model.ProgressionSuggestion{ExerciseID: 1, Action: model.ProgressionDeload, Sets: 3, Reps: 5, Weight: 7.5, Increment: 2.5, ConsecutiveMisses: 3},
+		},
+		{
+			name:       "deload stops at zero",
+			exercise:   &model.Exercise{ID: 1},
+			history:    sessions([3]float64{3, 2.5, 5}, [3]float64{3, 2.5, 5}, [3]float64{3, 2.5, 5}),
+			targetReps: 5,
+			want:       model.ProgressionSuggestion{ExerciseID: 1, Action: model.ProgressionDeload, Sets: 3, Reps: 5, Weight: 0, Increment: 2.5, ConsecutiveMisses: 3},
+		},
+		{
+			name:       "bodyweight adds reps",
+			exercise:   &model.Exercise{ID: 1},
+			history:    sessions([3]float64{10, 0, 10}),
+			targetReps: 10,
+			want:       model.ProgressionSuggestion{ExerciseID: 1, Action: model.ProgressionAddReps, Sets: 3, Reps: 11, Increment: 2.5},
+		},
+		{
+			name:       "bodyweight misses never deload",
+			exercise:   &model.Exercise{ID: 1},
+			history:    sessions([3]float64{8, 0, 10}, [3]float64{8, 0, 10}, [3]float64{8, 0, 10}),
+			targetReps: 10,
+			want:       model.ProgressionSuggestion{ExerciseID: 1, Action: model.ProgressionRepeat, Sets: 3, Reps: 10, Increment: 2.5, ConsecutiveMisses: 3},
+		},
+		{
+			name:       "time starts without reps",
+			exercise:   &model.Exercise{ID: 2, TrackingProfile: model.TrackingTime},
+			targetReps: 5,
+			want:       model.ProgressionSuggestion{ExerciseID: 2, Action: model.ProgressionStart, Increment: 2.5},
+		},
+		{
+			name:       "time repeats",
+			exercise:   &model.Exercise{ID: 2, TrackingProfile: model.TrackingTime},
+			history:    sessions([3]float64{0, 0, 0}),
+			targetReps: 5,
+			want:       model.ProgressionSuggestion{ExerciseID: 2, Action: model.ProgressionRepeat, Sets: 3, Increment: 2.5},
+		},
+		{
+			name:     "weighted time keeps the load",
+			exercise: &model.Exercise{ID: 2, TrackingProfile: model.TrackingWeightTime},
+			history:  sessions([3]float64{0, 40, 0}),
+			want:     model.ProgressionSuggestion{ExerciseID: 2, Action: model.ProgressionRepeat, Sets: 3, Weight: 40, Increment: 2.5},
+		},
+		{
+			name:     "distance repeats",
+			exercise: &model.Exercise{ID: 2, TrackingProfile: model.TrackingDistanceTime},
+			history:  sessions([3]float64{0, 0, 0}, [3]float64{0, 0, 0}, [3]float64{0, 0, 0}),
+			want:     model.ProgressionSuggestion{ExerciseID: 2, Action: model.ProgressionRepeat, Sets: 3, Increment: 2.5},
+		},
+		{
+			name:       "reps only adds reps",
+			exercise:   &model.Exercise{ID: 2, TrackingProfile: model.TrackingReps},
+			history:    sessions([3]float64{15, 0, 15}),
+			targetReps: 15,
+			want:       model.ProgressionSuggestion{ExerciseID: 2, Action: model.ProgressionAddReps, Sets: 3, Reps: 16, Increment: 2.5},
+		},
+		{
+			name:       "reps only never deloads",
+			exercise:   &model.Exercise{ID: 2, TrackingProfile: model.TrackingReps},
+			history:    sessions([3]float64{12, 0, 15}, [3]float64{12, 0, 15}, [3]float64{12, 0, 15}),
+			targetReps: 15,
+			want:       model.ProgressionSuggestion{ExerciseID: 2, Action: model.ProgressionRepeat, Sets: 3, Reps: 15, Increment: 2.5, ConsecutiveMisses: 3},
+		},
+		{
+			name:       "assisted takes assistance off",
+			exercise:   &model.Exercise{ID: 3, TrackingProfile: model.TrackingAssisted},
+			history:    sessions([3]float64{8, -20, 8}),
+			targetReps: 8,
+			want:       model.ProgressionSuggestion{ExerciseID: 3, Action: model.ProgressionIncrease, Sets: 3, Reps: 8, Weight: -17.5, Increment: 2.5},
+		},
+		{
+			name:       "assisted never crosses zero",
+			exercise:   &model.Exercise{ID: 3, TrackingProfile: model.TrackingAssisted},
+			history:    sessions([3]float64{8, -1, 8}),
+			targetReps: 8,
+			want:       model.ProgressionSuggestion{ExerciseID: 3, Action: model.ProgressionIncrease, Sets: 3, Reps: 8, Weight: 0, Increment: 2.5},
+		},
+		{
+			name:       "unassisted adds reps",
+			exercise:   &model.Exercise{ID: 3, TrackingProfile: model.TrackingAssisted},
+			history:    sessions([3]float64{8, 0, 8}),
+			targetReps: 8,
+			want:       model.ProgressionSuggestion{ExerciseID: 3, Action: model.ProgressionAddReps, Sets: 3, Reps: 9, Increment: 2.5},
+		},
+		{
+			name:       "assisted miss repeats",
+			exercise:   &model.Exercise{ID: 3, TrackingProfile: model.TrackingAssisted},
+			history:    sessions([3]float64{6, -20, 8}),
+			targetReps: 8,
+			want:       model.ProgressionSuggestion{ExerciseID: 3, Action: model.ProgressionRepeat, Sets: 3, Reps: 8, Weight: -20, Increment: 2.5, ConsecutiveMisses: 1},
+		},
+		{
+			name:       "assisted deload adds assistance",
+			exercise:   &model.Exercise{ID: 3, TrackingProfile: model.TrackingAssisted},
+			history:    sessions([3]float64{6, -20, 8}, [3]float64{6, -20, 8}, [3]float64{6, -20, 8}),
+			targetReps: 8,
+			want:       model.ProgressionSuggestion{ExerciseID: 3, Action: model.ProgressionDeload, Sets: 3, Reps: 8, Weight: -22.5, Increment: 2.5, ConsecutiveMisses: 3},
+		},
+		{
+			name:       "unassisted deload adds assistance",
+			exercise:   &model.Exercise{ID: 3, TrackingProfile: model.TrackingAssisted},
+			history:    sessions([3]float64{6, 0, 8}, [3]float64{6, 0, 8}, [3]float64{6, 0, 8}),
+			targetReps: 8,
+			want:       model.ProgressionSuggestion{ExerciseID: 3, Action: model.ProgressionDeload, Sets: 3, Reps: 8, Weight: -2.5, Increment: 2.5, ConsecutiveMisses: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := suggestNext(tt.exercise, tt.history, tt.targetReps)
+
+			if len(tt.history) > 0 && got.Last != tt.history[0] {
+				t.Errorf("last = %+v, want the newest session", got.Last)
+			}
+			got.Last = nil
+			if *got != tt.want {
+				t.Errorf("suggestNext() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
@@ -2,25 +2,46 @@ package workout
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
 	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/events"
 	"github.com/biryanim/workoutbook/internal/model"
 	"github.com/biryanim/workoutbook/internal/repository"
 	"github.com/biryanim/workoutbook/internal/service"
-	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+	"time"
 )
 
 var _ service.WorkoutService = (*serv)(nil)
 
 type serv struct {
-	workoutRepository repository.WorkoutRepository
-	txManager         db.TxManager
+	workoutRepository  repository.WorkoutRepository
+	userRepository     repository.UserRepository
+	templateRepository repository.TemplateRepository
+	tagRepository      repository.TagRepository
+	coachRepository    repository.CoachRepository
+	broker             *events.Broker
+	txManager          db.TxManager
 }
 
-func New(workoutRepository repository.WorkoutRepository, txManager db.TxManager) *serv {
+func New(
+	workoutRepository repository.WorkoutRepository,
+	userRepository repository.UserRepository,
+	templateRepository repository.TemplateRepository,
+	tagRepository repository.TagRepository,
+	coachRepository repository.CoachRepository,
+	broker *events.Broker,
+	txManager db.TxManager,
+) *serv {
 	return &serv{
-		workoutRepository: workoutRepository,
-		txManager:         txManager,
+		workoutRepository:  workoutRepository,
+		userRepository:     userRepository,
+		templateRepository: templateRepository,
+		tagRepository:      tagRepository,
+		coachRepository:    coachRepository,
+		broker:             broker,
+		txManager:          txManager,
 	}
 }
 
@@ -32,24 +53,58 @@ func (s *serv) CreateWorkout(ctx context.Context, workout *model.Workout) (int64
 	return id, nil
 }
 
-func (s *serv) GetWorkouts(ctx context.Context, userId int64, pagination *model.WorkoutsFilter) ([]*model.Workout, error) {
-	workouts, err := s.workoutRepository.ListWorkouts(ctx, userId, pagination)
+// GetWorkouts returns a page of the user's workouts with the total count of
+// workouts matching the filter.
+func (s *serv) GetWorkouts(ctx context.Context, userId int64, filter *model.WorkoutsFilter) (*model.WorkoutsPage, error) {
+	page := &model.WorkoutsPage{}
+
+	// one extra row tells whether another page follows
+	query := *filter
+	query.Limit = filter.Limit + 1
+
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		var err error
+		page.Workouts, err = s.workoutRepository.ListWorkouts(ctx, userId, &query)
+		if err != nil {
+			return err
+		}
+
+		page.Total, err = s.workoutRepository.CountWorkouts(ctx, userId, filter)
+		if err != nil {
+			return err
+		}
+
+		return s.attachTags(ctx, page.Workouts)
+	})
 	if err != nil {
 		return nil, err
 	}
-	return workouts, nil
+
+	if uint64(len(page.Workouts)) > filter.Limit {
+		page.Workouts = page.Workouts[:filter.Limit]
+		last := page.Workouts[len(page.Workouts)-1]
+		page.Next = &model.WorkoutCursor{Date: last.Date, ID: last.ID}
+	}
+
+	return page, nil
 }
 
-func (s *serv) GetWorkout(ctx context.Context, userId, workoutId int64) (*model.WorkoutExercises, error) {
+// GetWorkout returns the workout with the exercise names in the user's
+// language; locale is the one the client accepts. Coaches with the view
+// permission see the workouts of their athletes.
+func (s *serv) GetWorkout(ctx context.Context, userId, workoutId int64, locale string) (*model.WorkoutExercises, error) {
 
 	var (
-		workout = &model.WorkoutExercises{}
-		err     error
+		workout    = &model.WorkoutExercises{}
+		splits     []*model.Split
+		groups     []*model.ExerciseGroup
+		bodyweight float64
+		err        error
 	)
 
 	err = s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
 
-		workout.Workout, err = s.workoutRepository.GetWorkoutByID(ctx, workoutId, userId)
+		workout.Workout, err = s.workoutRepository.GetWorkoutByID(ctx, workoutId, userId, model.PermissionView)
 		if err != nil {
 			return err
 		}
@@ -58,6 +113,42 @@ func (s *serv) GetWorkout(ctx context.Context, userId, workoutId int64) (*model.
 		if err != nil {
 			return err
 		}
+
+		splits, err = s.workoutRepository.GetSplitsByWorkoutID(ctx, workoutId)
+		if err != nil {
+			return err
+		}
+
+		groups, err = s.workoutRepository.ListExerciseGroups(ctx, workoutId)
+		if err != nil {
+			return err
+		}
+
+		err = s.attachTags(ctx, []*model.Workout{workout.Workout})
+		if err != nil {
+			return err
+		}
+
+		locale, err = s.resolveLocale(ctx, userId, locale)
+		if err != nil {
+			return err
+		}
+		exercises := make([]*model.Exercise, 0, len(workout.Exercises))
+		for _, we := range workout.Exercises {
+			exercises = append(exercises, &we.Exercise)
+		}
+		err = s.localize(ctx, locale, exercises)
+		if err != nil {
+			return err
+		}
+
+		bw, err := s.userRepository.GetLatestBodyWeight(ctx, workout.Workout.UserID)
+		if err != nil && !errors.Is(err, apperrors.ErrBodyWeightNotFound) {
+			return err
+		}
+		if bw != nil {
+			bodyweight = bw.Weight
+		}
 		return nil
 	})
 
@@ -65,30 +156,64 @@ func (s *serv) GetWorkout(ctx context.Context, userId, workoutId int64) (*model.
 		return nil, err
 	}
 
+	byExercise := make(map[int64][]*model.Split)
+	for _, sp := range splits {
+		sp.PaceSecondsPerKm = splitPace(sp)
+		byExercise[sp.WorkoutExerciseID] = append(byExercise[sp.WorkoutExerciseID], sp)
+	}
+
+	for _, we := range workout.Exercises {
+		we.Splits = byExercise[we.ID]
+		we.Cardio = cardioMetrics(we, bodyweight)
+	}
+	workout.Blocks = exerciseBlocks(workout.Exercises, groups)
+
 	return workout, nil
 }
 
-func (s *serv) AddExerciseToWorkout(ctx context.Context, userId int64, we *model.WorkoutExercise) error {
+func (s *serv) UpdateWorkout(ctx context.Context, params *model.UpdateWorkoutParams) error {
 	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
-		has, err := s.workoutRepository.IsUserHaveWorkout(ctx, userId, we.WorkoutID)
+		workout, err := s.workoutRepository.GetWorkoutByID(ctx, params.ID, params.UserID, model.OwnerOnly)
 		if err != nil {
 			return err
 		}
-		if !has {
-			return fmt.Errorf("workout not found for user %d", userId)
-		}
 
-		_, err = s.workoutRepository.AddWorkoutExercise(ctx, we)
-		if err != nil {
-			return err
+		// the session bounds may be set one at a time, so check them against
+		// whatever is already stored
+		startedAt, endedAt := workout.StartedAt, workout.EndedAt
+		if params.StartedAt != nil {
+			startedAt = sql.NullTime{Time: *params.StartedAt, Valid: true}
+		}
+		if params.EndedAt != nil {
+			endedAt = sql.NullTime{Time: *params.EndedAt, Valid: true}
+		}
+		if startedAt.Valid && endedAt.Valid && endedAt.Time.Before(startedAt.Time) {
+			return apperrors.ErrInvalidWorkoutTime
 		}
 
-		err = s.UpdatePersonalRecord(ctx, userId, we.ExerciseID, we.Weight, we.Reps)
+		return s.workoutRepository.UpdateWorkout(ctx, params)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AddExerciseToWorkout adds the exercise to a workout of the user or, for
+// coaches with the assign permission, to one they assigned to their athlete
+// that has not started yet. Sets a coach plans are yet to be done, so they
+// make no records.
+func (s *serv) AddExerciseToWorkout(ctx context.Context, userId int64, we *model.WorkoutExercise) error {
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		workout, err := s.workoutRepository.GetWorkoutByID(ctx, we.WorkoutID, userId, model.PermissionAssign)
 		if err != nil {
 			return err
 		}
 
-		return nil
+		_, err = s.addExercise(ctx, workout.UserID, we, workout.UserID != userId)
+		return err
 	})
 
 	if err != nil {
@@ -98,40 +223,133 @@ func (s *serv) AddExerciseToWorkout(ctx context.Context, userId int64, we *model
 	return nil
 }
 
-func (s *serv) GetExercises(ctx context.Context, exerciseType string) ([]*model.Exercise, error) {
-	exrs, err := s.workoutRepository.GetExercises(ctx, exerciseType)
+// addExercise checks the metrics against the tracking profile of the exercise,
+// stores the exercise with its splits and group and, unless it is planned
+// for later, updates the personal record of userId, the workout owner. The
+// caller checks the access to the workout.
+func (s *serv) addExercise(ctx context.Context, userId int64, we *model.WorkoutExercise, planned bool) (int64, error) {
+	exercise, err := s.workoutRepository.GetExerciseByID(ctx, we.ExerciseID)
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+	if err = checkMetrics(exercise.TrackingProfile, we); err != nil {
+		return 0, err
 	}
 
-	return exrs, nil
+	we.Planned = planned
+	id, err := s.workoutRepository.AddWorkoutExercise(ctx, we)
+	if err != nil {
+		return 0, err
+	}
+
+	if we.GroupID.Valid && we.GroupKind != "" {
+		err = s.workoutRepository.UpsertExerciseGroups(ctx, []*model.ExerciseGroup{{
+			WorkoutID: we.WorkoutID,
+			GroupID:   we.GroupID.Int32,
+			Kind:      we.GroupKind,
+		}})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	for i, sp := range we.Splits {
+		sp.WorkoutExerciseID = id
+		sp.Number = i + 1
+	}
+	err = s.workoutRepository.AddSplits(ctx, we.Splits)
+	if err != nil {
+		return 0, err
+	}
+
+	if we.IsWarmUp || planned {
+		return id, nil
+	}
+
+	err = s.UpdatePersonalRecord(ctx, userId, we)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
 }
 
-func (s *serv) UpdatePersonalRecord(ctx context.Context, userID, exerciseID int64, weight float64, reps int) error {
+// GetExercises pages the catalog in the user's language; filter.Locale is the
+// one the client accepts.
+func (s *serv) GetExercises(ctx context.Context, userID int64, filter *model.ExercisesFilter) (*model.ExercisesPage, error) {
+	page := &model.ExercisesPage{}
+
+	query := *filter
+	query.Limit = filter.Limit + 1
+
 	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
-		record, err := s.workoutRepository.GetPersonalRecord(ctx, userID, exerciseID)
+		var err error
+		query.Locale, err = s.resolveLocale(ctx, userID, filter.Locale)
+		if err != nil {
+			return err
+		}
 
-		newMax := weight * (1 + float64(reps)/30)
-		currentMax := record.Weight * (1 + float64(record.Reps)/30)
+		page.Exercises, err = s.workoutRepository.GetExercises(ctx, &query)
+		if err != nil {
+			return err
+		}
+
+		page.Total, err = s.workoutRepository.CountExercises(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		return s.attachMuscles(ctx, page.Exercises)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(len(page.Exercises)) > filter.Limit {
+		page.Exercises = page.Exercises[:filter.Limit]
+		last := page.Exercises[len(page.Exercises)-1]
+		page.Next = &model.ExerciseCursor{Name: last.Name, ID: last.ID}
+	}
+
+	return page, nil
+}
+
+// UpdatePersonalRecord records the set as the personal record when it beats
+// the current one by the metrics of the exercise's tracking profile.
+func (s *serv) UpdatePersonalRecord(ctx context.Context, userID int64, we *model.WorkoutExercise) error {
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		exercise, err := s.workoutRepository.GetExerciseByID(ctx, we.ExerciseID)
+		if err != nil {
+			return err
+		}
 
 		user := &model.UserRecord{
 			UserID:     userID,
-			ExerciseID: exerciseID,
-			Weight:     weight,
-			Reps:       reps,
+			ExerciseID: we.ExerciseID,
+			Weight:     we.Weight,
+			Reps:       we.Reps,
+			Duration:   we.Duration,
+			Distance:   we.Distance,
+			Date:       time.Now().UTC(),
 		}
-		if err == pgx.ErrNoRows {
+
+		record, err := s.workoutRepository.GetPersonalRecord(ctx, userID, we.ExerciseID)
+		if errors.Is(err, apperrors.ErrRecordNotFound) {
 			_, err = s.workoutRepository.AddRecord(ctx, user)
 			if err != nil {
 				return err
 			}
 			return nil
-		} else if newMax > currentMax {
+		}
+		if err != nil {
+			return err
+		}
+
+		if betterRecord(exercise.TrackingProfile, user, record) {
 			err = s.workoutRepository.UpdatePersonalRecord(ctx, user)
 			if err != nil {
 				return err
 			}
-			return nil
 		}
 
 		return nil
@@ -143,11 +361,134 @@ func (s *serv) UpdatePersonalRecord(ctx context.Context, userID, exerciseID int6
 	return nil
 }
 
-func (s *serv) GetPersonalRecords(ctx context.Context, userId int64) ([]*model.UserRecord, error) {
-	records, err := s.workoutRepository.ListRecords(ctx, userId)
+// GetPersonalRecords returns the records with the exercise names in the
+// user's language; locale is the one the client accepts.
+func (s *serv) GetPersonalRecords(ctx context.Context, userId int64, locale string) (*model.PersonalRecords, error) {
+	var (
+		records    []*model.UserRecord
+		standards  []*model.StrengthStandard
+		sex        string
+		bodyweight float64
+	)
+
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		var err error
+		records, err = s.workoutRepository.ListRecords(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		user, err := s.userRepository.GetByID(ctx, userId)
+		if err != nil {
+			return err
+		}
+		sex = user.Sex.String
+
+		exercises := make([]*model.Exercise, 0, len(records))
+		for _, record := range records {
+			exercises = append(exercises, &record.Exercise)
+		}
+		err = s.localize(ctx, model.ResolveLocale(user.Locale, locale), exercises)
+		if err != nil {
+			return err
+		}
+
+		bw, err := s.userRepository.GetLatestBodyWeight(ctx, userId)
+		if err != nil && !errors.Is(err, apperrors.ErrBodyWeightNotFound) {
+			return err
+		}
+		if bw != nil {
+			bodyweight = bw.Weight
+		}
+
+		standards, err = s.workoutRepository.ListStrengthStandards(ctx, userId, sex)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byExercise := make(map[int64][]*model.StrengthStandard)
+	for _, standard := range standards {
+		byExercise[standard.ExerciseID] = append(byExercise[standard.ExerciseID], standard)
+	}
+
+	for _, record := range records {
+		if record.Exercise.TrackingProfile != model.TrackingWeightReps {
+			continue
+		}
+		record.Strength = strengthScore(record, sex, bodyweight, byExercise[record.ExerciseID])
+	}
+
+	return &model.PersonalRecords{
+		Records: records,
+		Summary: strengthSummary(records, sex, bodyweight),
+	}, nil
+}
+
+// GetStrengthStandards returns the levels the user's records of the exercise
+// are scored against: their own ones or else the built-in ones for their sex.
+func (s *serv) GetStrengthStandards(ctx context.Context, userID, exerciseID int64) (*model.ExerciseStandards, error) {
+	var standards []*model.StrengthStandard
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		user, err := s.userRepository.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		standards, err = s.workoutRepository.ListStrengthStandards(ctx, userID, user.Sex.String)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return records, nil
+	result := &model.ExerciseStandards{ExerciseID: exerciseID}
+	for _, standard := range standards {
+		if standard.ExerciseID != exerciseID {
+			continue
+		}
+		result.Custom = standard.Custom
+		result.Levels = append(result.Levels, standard)
+	}
+
+	return result, nil
+}
+
+// SetStrengthStandards replaces the built-in levels of the exercise with the
+// user's own ones, ranked in the order given.
+func (s *serv) SetStrengthStandards(ctx context.Context, userID int64, standards *model.ExerciseStandards) error {
+	if err := checkStandards(standards.Levels); err != nil {
+		return err
+	}
+
+	return s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		return s.workoutRepository.SaveStrengthStandards(ctx, userID, standards.ExerciseID, standards.Levels)
+	})
+}
+
+// DeleteStrengthStandards brings back the built-in levels of the exercise.
+func (s *serv) DeleteStrengthStandards(ctx context.Context, userID, exerciseID int64) error {
+	return s.workoutRepository.DeleteStrengthStandards(ctx, userID, exerciseID)
+}
+
+// attachTags loads the tags of the workouts into them.
+func (s *serv) attachTags(ctx context.Context, workouts []*model.Workout) error {
+	ids := make([]int64, 0, len(workouts))
+	byID := make(map[int64]*model.Workout, len(workouts))
+	for _, w := range workouts {
+		ids = append(ids, w.ID)
+		byID[w.ID] = w
+	}
+
+	tags, err := s.tagRepository.ListWorkoutTags(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, wt := range tags {
+		w := byID[wt.WorkoutID]
+		w.Tags = append(w.Tags, wt.Tag)
+	}
+
+	return nil
 }
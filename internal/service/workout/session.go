@@ -0,0 +1,218 @@
+package workout
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/pkg/errors"
+)
+
+// StartSession marks the workout as in progress. Starting it again keeps the
+// original start time so that every device can safely call it.
+func (s *serv) StartSession(ctx context.Context, userID, workoutID int64) (*model.Workout, error) {
+	var workout *model.Workout
+
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		var err error
+		workout, err = s.workoutRepository.GetWorkoutByID(ctx, workoutID, userID, model.OwnerOnly)
+		if err != nil {
+			return err
+		}
+		if workout.EndedAt.Valid {
+			return apperrors.ErrWorkoutFinished
+		}
+		if workout.StartedAt.Valid {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		workout.StartedAt = sql.NullTime{Time: now, Valid: true}
+		return s.workoutRepository.UpdateWorkout(ctx, &model.UpdateWorkoutParams{
+			ID:        workoutID,
+			UserID:    userID,
+			StartedAt: &now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.broker.Publish(&model.SessionEvent{
+		Type:      model.SessionEventStarted,
+		WorkoutID: workoutID,
+		At:        time.Now().UTC(),
+		Workout:   workout,
+	})
+
+	return workout, nil
+}
+
+// LogSet records a single set the moment it is done, in place of a set the
+// coach planned when there is one. The rest before it is the time since the
+// previous logged set; the first set of a session has none. Every follower
+// of the session gets the set and a new rest timer.
+func (s *serv) LogSet(ctx context.Context, params *model.LogSetParams) (*model.WorkoutExercise, error) {
+	set := params.Set
+	now := time.Now().UTC()
+
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		workout, err := s.workoutRepository.GetWorkoutByID(ctx, set.WorkoutID, params.UserID, model.OwnerOnly)
+		if err != nil {
+			return err
+		}
+		if !workout.StartedAt.Valid {
+			return apperrors.ErrWorkoutNotStarted
+		}
+		if workout.EndedAt.Valid {
+			return apperrors.ErrWorkoutFinished
+		}
+
+		last, err := s.workoutRepository.GetLastSetTime(ctx, set.WorkoutID)
+		if err != nil {
+			return err
+		}
+		if last.Valid && now.After(last.Time) {
+			set.RestSeconds = sql.NullInt32{Int32: int32(now.Sub(last.Time) / time.Second), Valid: true}
+		}
+		set.Sets = 1
+		set.CompletedAt = sql.NullTime{Time: now, Valid: true}
+
+		set.ID, err = s.performSet(ctx, params.UserID, set)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rest := params.RestSeconds
+	if rest <= 0 {
+		rest = model.DefaultRestSeconds
+	}
+	s.broker.Publish(&model.SessionEvent{
+		Type:      model.SessionEventSetLogged,
+		WorkoutID: set.WorkoutID,
+		At:        now,
+		Set:       set,
+	})
+	s.broker.Publish(&model.SessionEvent{
+		Type:      model.SessionEventRestTimer,
+		WorkoutID: set.WorkoutID,
+		At:        now,
+		Rest:      &model.RestTimer{StartedAt: now, Seconds: rest},
+	})
+
+	return set, nil
+}
+
+// performSet stores a set logged live. When the coach planned the exercise
+// for the workout, the set is done in place of one planned set, which gives
+// it its target reps and group: the last set left of a planned entry turns
+// it into the performed one and otherwise the set goes right before it.
+func (s *serv) performSet(ctx context.Context, userID int64, set *model.WorkoutExercise) (int64, error) {
+	planned, err := s.workoutRepository.GetPlannedEntry(ctx, set.WorkoutID, set.ExerciseID)
+	if errors.Is(err, apperrors.ErrEntryNotFound) {
+		return s.addExercise(ctx, userID, set, false)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if !set.TargetReps.Valid && planned.Reps > 0 {
+		set.TargetReps = sql.NullInt32{Int32: int32(planned.Reps), Valid: true}
+	}
+	if !set.GroupID.Valid {
+		set.GroupID = planned.GroupID
+	}
+
+	if planned.Sets > 1 {
+		if err = s.workoutRepository.UpdatePlannedSets(ctx, planned.ID, planned.Sets-1); err != nil {
+			return 0, err
+		}
+		set.Position = planned.Position
+		return s.addExercise(ctx, userID, set, false)
+	}
+
+	exercise, err := s.workoutRepository.GetExerciseByID(ctx, set.ExerciseID)
+	if err != nil {
+		return 0, err
+	}
+	if err = checkMetrics(exercise.TrackingProfile, set); err != nil {
+		return 0, err
+	}
+
+	set.ID = planned.ID
+	set.Position = planned.Position
+	if err = s.workoutRepository.PerformPlannedEntry(ctx, set); err != nil {
+		return 0, err
+	}
+	if set.IsWarmUp {
+		return set.ID, nil
+	}
+
+	return set.ID, s.UpdatePersonalRecord(ctx, userID, set)
+}
+
+// FinishSession closes the workout; its duration is then known.
+func (s *serv) FinishSession(ctx context.Context, params *model.FinishSessionParams) (*model.Workout, error) {
+	var workout *model.Workout
+
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		var err error
+		workout, err = s.workoutRepository.GetWorkoutByID(ctx, params.WorkoutID, params.UserID, model.OwnerOnly)
+		if err != nil {
+			return err
+		}
+		if !workout.StartedAt.Valid {
+			return apperrors.ErrWorkoutNotStarted
+		}
+		if workout.EndedAt.Valid {
+			return apperrors.ErrWorkoutFinished
+		}
+
+		now := time.Now().UTC()
+		if now.Before(workout.StartedAt.Time) {
+			return apperrors.ErrInvalidWorkoutTime
+		}
+		workout.EndedAt = sql.NullTime{Time: now, Valid: true}
+		if params.SessionRPE != nil {
+			workout.SessionRPE = sql.NullFloat64{Float64: *params.SessionRPE, Valid: true}
+		}
+
+		return s.workoutRepository.UpdateWorkout(ctx, &model.UpdateWorkoutParams{
+			ID:         params.WorkoutID,
+			UserID:     params.UserID,
+			EndedAt:    &now,
+			SessionRPE: params.SessionRPE,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.broker.Publish(&model.SessionEvent{
+		Type:      model.SessionEventFinished,
+		WorkoutID: params.WorkoutID,
+		At:        workout.EndedAt.Time,
+		Workout:   workout,
+	})
+
+	return workout, nil
+}
+
+// SubscribeSession follows the events of the user's workout, or of an
+// athlete's one for coaches with the view permission, until cancel is called.
+func (s *serv) SubscribeSession(ctx context.Context, userID, workoutID int64) (<-chan *model.SessionEvent, func(), error) {
+	has, err := s.workoutRepository.IsUserHaveWorkout(ctx, userID, workoutID, model.PermissionView)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !has {
+		return nil, nil, apperrors.ErrWorkoutNotFound
+	}
+
+	ch, cancel := s.broker.Subscribe(workoutID)
+	return ch, cancel, nil
+}
@@ -0,0 +1,194 @@
+package workout
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+)
+
+// sessionRepository holds at most one planned entry of a barbell exercise
+// and remembers the sets added and performed and the records kept.
+type sessionRepository struct {
+	repository.WorkoutRepository
+	planned     *model.WorkoutExercise
+	plannedSets int
+	added       []*model.WorkoutExercise
+	performed   *model.WorkoutExercise
+	records     int
+}
+
+func (r *sessionRepository) GetPlannedEntry(context.Context, int64, int64) (*model.WorkoutExercise, error) {
+	if r.planned == nil {
+		return nil, apperrors.ErrEntryNotFound
+	}
+	planned := *r.planned
+	return &planned, nil
+}
+
+func (r *sessionRepository) UpdatePlannedSets(_ context.Context, _ int64, sets int) error {
+	r.plannedSets = sets
+	return nil
+}
+
+func (r *sessionRepository) PerformPlannedEntry(_ context.Context, we *model.WorkoutExercise) error {
+	r.performed = we
+	return nil
+}
+
+func (r *sessionRepository) AddWorkoutExercise(_ context.Context, we *model.WorkoutExercise) (int64, error) {
+	r.added = append(r.added, we)
+	return int64(100 + len(r.added)), nil
+}
+
+func (r *sessionRepository) AddSplits(context.Context, []*model.Split) error {
+	return nil
+}
+
+func (r *sessionRepository) GetExerciseByID(_ context.Context, exerciseID int64) (*model.Exercise, error) {
+	return &model.Exercise{ID: exerciseID, Type: "strength", TrackingProfile: model.TrackingWeightReps}, nil
+}
+
+func (r *sessionRepository) GetPersonalRecord(context.Context, int64, int64) (*model.UserRecord, error) {
+	return nil, apperrors.ErrRecordNotFound
+}
+
+func (r *sessionRepository) AddRecord(context.Context, *model.UserRecord) (int64, error) {
+	r.records++
+	return int64(r.records), nil
+}
+
+func TestPerformSet(t *testing.T) {
+	plannedEntry := func(sets int) *model.WorkoutExercise {
+		return &model.WorkoutExercise{
+			ID:         7,
+			WorkoutID:  1,
+			ExerciseID: benchPress,
+			Sets:       sets,
+			Reps:       5,
+			Weight:     100,
+			Position:   3,
+			GroupID:    sql.NullInt32{Int32: 2, Valid: true},
+			Planned:    true,
+		}
+	}
+	target := func(reps int32) sql.NullInt32 {
+		return sql.NullInt32{Int32: reps, Valid: true}
+	}
+
+	tests := []struct {
+		name        string
+		planned     *model.WorkoutExercise
+		set         model.WorkoutExercise
+		err         error
+		id          int64
+		plannedSets int
+		performed   bool
+		targetReps  sql.NullInt32
+		group       sql.NullInt32
+		records     int
+	}{
+		{
+			name:    "nothing planned",
+			set:     model.WorkoutExercise{Reps: 5, Weight: 100},
+			id:      101,
+			records: 1,
+		},
+		{
+			name:        "one of several planned sets",
+			planned:     plannedEntry(3),
+			set:         model.WorkoutExercise{Reps: 6, Weight: 100},
+			id:          101,
+			plannedSets: 2,
+			targetReps:  target(5),
+			group:       sql.NullInt32{Int32: 2, Valid: true},
+			records:     1,
+		},
+		{
+			name:       "last planned set",
+			planned:    plannedEntry(1),
+			set:        model.WorkoutExercise{Reps: 4, Weight: 105},
+			id:         7,
+			performed:  true,
+			targetReps: target(5),
+			group:      sql.NullInt32{Int32: 2, Valid: true},
+			records:    1,
+		},
+		{
+			name:       "own target and group kept",
+			planned:    plannedEntry(1),
+			set:        model.WorkoutExercise{Reps: 8, Weight: 80, TargetReps: target(8), GroupID: sql.NullInt32{Int32: 4, Valid: true}},
+			id:         7,
+			performed:  true,
+			targetReps: target(8),
+			group:      sql.NullInt32{Int32: 4, Valid: true},
+			records:    1,
+		},
+		{
+			name:       "warm-up leaves records alone",
+			planned:    plannedEntry(1),
+			set:        model.WorkoutExercise{Reps: 10, Weight: 60, IsWarmUp: true},
+			id:         7,
+			performed:  true,
+			targetReps: target(5),
+			group:      sql.NullInt32{Int32: 2, Valid: true},
+		},
+		{
+			name:    "invalid metrics",
+			planned: plannedEntry(1),
+			set:     model.WorkoutExercise{Weight: 100},
+			err:     apperrors.ErrInvalidMetrics,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &sessionRepository{planned: tt.planned}
+			s := &serv{workoutRepository: repo, txManager: noTx{}}
+			set := tt.set
+			set.WorkoutID = 1
+			set.ExerciseID = benchPress
+
+			id, err := s.performSet(context.Background(), 1, &set)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("performSet() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				if repo.performed != nil || len(repo.added) != 0 || repo.records != 0 {
+					t.Errorf("performed %+v and added %d sets, want nothing stored", repo.performed, len(repo.added))
+				}
+				return
+			}
+
+			if id != tt.id {
+				t.Errorf("id = %d, want %d", id, tt.id)
+			}
+			if repo.plannedSets != tt.plannedSets {
+				t.Errorf("planned sets left = %d, want %d", repo.plannedSets, tt.plannedSets)
+			}
+			if performed := repo.performed != nil; performed != tt.performed || performed == (len(repo.added) == 1) {
+				t.Fatalf("performed %+v and added %d sets, want performed %v", repo.performed, len(repo.added), tt.performed)
+			}
+			stored := repo.performed
+			if stored == nil {
+				stored = repo.added[0]
+			}
+			if stored.Planned {
+				t.Error("set is stored as planned, want it done")
+			}
+			if tt.planned != nil && stored.Position != tt.planned.Position {
+				t.Errorf("position = %d, want the planned %d", stored.Position, tt.planned.Position)
+			}
+			if stored.TargetReps != tt.targetReps || stored.GroupID != tt.group {
+				t.Errorf("target reps, group = %+v, %+v, want %+v, %+v", stored.TargetReps, stored.GroupID, tt.targetReps, tt.group)
+			}
+			if repo.records != tt.records {
+				t.Errorf("records = %d, want %d", repo.records, tt.records)
+			}
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package workout
+
+import (
+	"math"
+	"unicode/utf8"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+const (
+	maxStandardLevels    = 10
+	maxStandardLevelName = 20
+	// bodyweight multiples are stored as DECIMAL(4,2)
+	maxBodyweightMultiple = 99.99
+)
+
+// estimatedOneRM uses the Epley formula, the same one personal records are
+// ranked by.
+func estimatedOneRM(weight float64, reps int) float64 {
+	if reps <= 1 {
+		return weight
+	}
+	return weight * (1 + float64(reps)/30)
+}
+
+func polynomial(x float64, coefs []float64) float64 {
+	var (
+		sum   float64
+		power = 1.0
+	)
+	for _, c := range coefs {
+		sum += c * power
+		power *= x
+	}
+	return sum
+}
+
+func clamp(x, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, x))
+}
+
+var (
+	wilksMale   = []float64{-216.0475144, 16.2606339, -0.002388645, -0.00113732, 7.01863e-06, -1.291e-08}
+	wilksFemale = []float64{594.31747775582, -27.23842536447, 0.82112226871, -0.00930733913, 4.731582e-05, -9.054e-08}
+
+	dotsMale   = []float64{-307.75076, 24.0900756, -0.1918759221, 0.0007391293, -0.000001093}
+	dotsFemale = []float64{-57.96288, 13.6175032, -0.1126655495, 0.0005158568, -0.0000010706}
+)
+
+// wilks returns the original Wilks points of lifted kilograms.
+func wilks(lifted, bodyweight float64, sex string) float64 {
+	if sex == model.SexFemale {
+		return lifted * 500 / polynomial(clamp(bodyweight, 26.51, 154.53), wilksFemale)
+	}
+	return lifted * 500 / polynomial(clamp(bodyweight, 40, 201.9), wilksMale)
+}
+
+func dots(lifted, bodyweight float64, sex string) float64 {
+	if sex == model.SexFemale {
+		return lifted * 500 / polynomial(clamp(bodyweight, 40, 150), dotsFemale)
+	}
+	return lifted * 500 / polynomial(clamp(bodyweight, 40, 210), dotsMale)
+}
+
+type ipfGLCoefficients struct {
+	a, b, c float64
+}
+
+var (
+	ipfGLTotalMale   = ipfGLCoefficients{1199.72839, 1025.18162, 0.00921}
+	ipfGLTotalFemale = ipfGLCoefficients{610.32796, 1045.59282, 0.03048}
+	ipfGLBenchMale   = ipfGLCoefficients{320.98041, 281.40258, 0.01008}
+	ipfGLBenchFemale = ipfGLCoefficients{142.40398, 442.52671, 0.04724}
+)
+
+// ipfGL returns IPF GoodLift points for classic (raw) lifting. The IPF only
+// publishes coefficients for the three-lift total and for bench press alone.
+func ipfGL(lifted, bodyweight float64, sex string, benchOnly bool) float64 {
+	coefs := ipfGLTotalMale
+	switch {
+	case benchOnly && sex == model.SexFemale:
+		coefs = ipfGLBenchFemale
+	case benchOnly:
+		coefs = ipfGLBenchMale
+	case sex == model.SexFemale:
+		coefs = ipfGLTotalFemale
+	}
+	return lifted * 100 / (coefs.a - coefs.b*math.Exp(-coefs.c*bodyweight))
+}
+
+// strengthScore scores a single record. sex and bodyweight may be unknown, in
+// which case only the scores that do not depend on them are filled in.
+func strengthScore(record *model.UserRecord, sex string, bodyweight float64, standards []*model.StrengthStandard) *model.StrengthScore {
+	score := &model.StrengthScore{
+		EstimatedOneRM: estimatedOneRM(record.Weight, record.Reps),
+	}
+	if bodyweight <= 0 {
+		return score
+	}
+
+	multiple := score.EstimatedOneRM / bodyweight
+	score.BodyweightMultiple = &multiple
+
+	// standards are ordered by rank, so the last one reached is the level
+	for _, standard := range standards {
+		if multiple < standard.BodyweightMultiple {
+			score.NextLevel = standard.Level
+			next := standard.BodyweightMultiple * bodyweight
+			score.NextLevelOneRM = &next
+			break
+		}
+		score.Level = standard.Level
+	}
+
+	if sex == "" || !record.Exercise.Lift.Valid {
+		return score
+	}
+
+	w := wilks(score.EstimatedOneRM, bodyweight, sex)
+	d := dots(score.EstimatedOneRM, bodyweight, sex)
+	score.Wilks, score.DOTS = &w, &d
+	if record.Exercise.Lift.String == model.LiftBench {
+		gl := ipfGL(score.EstimatedOneRM, bodyweight, sex, true)
+		score.IPFGL = &gl
+	}
+
+	return score
+}
+
+// strengthSummary scores the powerlifting total of squat, bench press and
+// deadlift. The total is only scored once all three lifts have a record.
+func strengthSummary(records []*model.UserRecord, sex string, bodyweight float64) *model.StrengthSummary {
+	summary := &model.StrengthSummary{Sex: sex}
+	if bodyweight > 0 {
+		summary.Bodyweight = &bodyweight
+	}
+
+	lifts := make(map[string]bool)
+	for _, r := range records {
+		if !r.Exercise.Lift.Valid {
+			continue
+		}
+		switch lift := r.Exercise.Lift.String; lift {
+		case model.LiftSquat, model.LiftBench, model.LiftDeadlift:
+			if !lifts[lift] {
+				lifts[lift] = true
+				summary.Total += estimatedOneRM(r.Weight, r.Reps)
+			}
+		}
+	}
+
+	if len(lifts) < 3 || sex == "" || bodyweight <= 0 {
+		return summary
+	}
+
+	w := wilks(summary.Total, bodyweight, sex)
+	d := dots(summary.Total, bodyweight, sex)
+	gl := ipfGL(summary.Total, bodyweight, sex, false)
+	summary.Wilks, summary.DOTS, summary.IPFGL = &w, &d, &gl
+
+	return summary
+}
+
+// checkStandards validates the levels a user sets for an exercise and ranks
+// them in the order given: the names are distinct and every level needs a
+// bigger bodyweight multiple, rounded as it is stored, than the one before.
+func checkStandards(levels []*model.StrengthStandard) error {
+	if len(levels) == 0 || len(levels) > maxStandardLevels {
+		return apperrors.ErrInvalidStandards
+	}
+
+	seen := make(map[string]bool, len(levels))
+	for i, level := range levels {
+		level.BodyweightMultiple = math.Round(level.BodyweightMultiple*100) / 100
+		if level.Level == "" || utf8.RuneCountInString(level.Level) > maxStandardLevelName || seen[level.Level] {
+			return apperrors.ErrInvalidStandards
+		}
+		if level.BodyweightMultiple <= 0 || level.BodyweightMultiple > maxBodyweightMultiple {
+			return apperrors.ErrInvalidStandards
+		}
+		if i > 0 && level.BodyweightMultiple <= levels[i-1].BodyweightMultiple {
+			return apperrors.ErrInvalidStandards
+		}
+		seen[level.Level] = true
+		level.Rank = i + 1
+	}
+
+	return nil
+}
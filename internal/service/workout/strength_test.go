@@ -0,0 +1,308 @@
+package workout
+
+import (
+	"database/sql"
+	"math"
+	"testing"
+
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+const scoreEpsilon = 0.01
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func equalScore(got, want *float64) bool {
+	if got == nil || want == nil {
+		return got == want
+	}
+	return math.Abs(*got-*want) < scoreEpsilon
+}
+
+func formatScore(v *float64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+func TestEstimatedOneRM(t *testing.T) {
+	tests := []struct {
+		name   string
+		weight float64
+		reps   int
+		want   float64
+	}{
+		{"no reps", 100, 0, 100},
+		{"single", 100, 1, 100},
+		{"epley", 100, 5, 116.6667},
+		{"ten reps", 60, 10, 80},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := estimatedOneRM(tt.weight, tt.reps); math.Abs(got-tt.want) > scoreEpsilon {
+				t.Errorf("estimatedOneRM(%v, %d) = %v, want %v", tt.weight, tt.reps, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoints(t *testing.T) {
+	tests := []struct {
+		name       string
+		formula    func(lifted, bodyweight float64, sex string) float64
+		lifted     float64
+		bodyweight float64
+		sex        string
+		want       float64
+	}{
+		{"wilks male", wilks, 600, 100, model.SexMale, 365.15},
+		{"wilks female", wilks, 400, 60, model.SexFemale, 445.95},
+		{"wilks clamps bodyweight", wilks, 600, 250, model.SexMale, 318.90},
+		{"dots male", dots, 600, 100, model.SexMale, 369.31},
+		{"dots female", dots, 400, 60, model.SexFemale, 443.42},
+		{"ipf gl total", func(l, bw float64, sex string) float64 { return ipfGL(l, bw, sex, false) }, 600, 100, model.SexMale, 75.80},
+		{"ipf gl bench male", func(l, bw float64, sex string) float64 { return ipfGL(l, bw, sex, true) }, 150, 100, model.SexMale, 68.72},
+		{"ipf gl bench female", func(l, bw float64, sex string) float64 { return ipfGL(l, bw, sex, true) }, 80, 60, model.SexFemale, 68.73},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.formula(tt.lifted, tt.bodyweight, tt.sex); math.Abs(got-tt.want) > scoreEpsilon {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrengthScore(t *testing.T) {
+	bench := model.Exercise{Lift: sql.NullString{String: model.LiftBench, Valid: true}}
+	squat := model.Exercise{Lift: sql.NullString{String: model.LiftSquat, Valid: true}}
+	standards := []*model.StrengthStandard{
+		{Level: "novice", Rank: 1, BodyweightMultiple: 0.5},
+		{Level: "intermediate", Rank: 2, BodyweightMultiple: 1},
+		{Level: "advanced", Rank: 3, BodyweightMultiple: 1.5},
+	}
+
+	tests := []struct {
+		name       string
+		record     *model.UserRecord
+		sex        string
+		bodyweight float64
+		want       *model.StrengthScore
+	}{
+		{
+			name:   "unknown bodyweight",
+			record: &model.UserRecord{Weight: 100, Reps: 1, Exercise: bench},
+			sex:    model.SexMale,
+			want:   &model.StrengthScore{EstimatedOneRM: 100},
+		},
+		{
+			name:       "below every standard",
+			record:     &model.UserRecord{Weight: 40, Reps: 1, Exercise: squat},
+			bodyweight: 100,
+			want: &model.StrengthScore{
+				EstimatedOneRM:     40,
+				BodyweightMultiple: floatPtr(0.4),
+				NextLevel:          "novice",
+				NextLevelOneRM:     floatPtr(50),
+			},
+		},
+		{
+			name:       "between standards without sex",
+			record:     &model.UserRecord{Weight: 120, Reps: 1, Exercise: squat},
+			bodyweight: 100,
+			want: &model.StrengthScore{
+				EstimatedOneRM:     120,
+				BodyweightMultiple: floatPtr(1.2),
+				Level:              "intermediate",
+				NextLevel:          "advanced",
+				NextLevelOneRM:     floatPtr(150),
+			},
+		},
+		{
+			name:       "top standard reached",
+			record:     &model.UserRecord{Weight: 200, Reps: 1, Exercise: model.Exercise{}},
+			sex:        model.SexMale,
+			bodyweight: 100,
+			want: &model.StrengthScore{
+				EstimatedOneRM:     200,
+				BodyweightMultiple: floatPtr(2),
+				Level:              "advanced",
+			},
+		},
+		{
+			name:       "non-bench lift has no ipf gl",
+			record:     &model.UserRecord{Weight: 600, Reps: 1, Exercise: squat},
+			sex:        model.SexMale,
+			bodyweight: 100,
+			want: &model.StrengthScore{
+				EstimatedOneRM:     600,
+				BodyweightMultiple: floatPtr(6),
+				Wilks:              floatPtr(365.15),
+				DOTS:               floatPtr(369.31),
+				Level:              "advanced",
+			},
+		},
+		{
+			name:       "bench has ipf gl",
+			record:     &model.UserRecord{Weight: 150, Reps: 1, Exercise: bench},
+			sex:        model.SexMale,
+			bodyweight: 100,
+			want: &model.StrengthScore{
+				EstimatedOneRM:     150,
+				BodyweightMultiple: floatPtr(1.5),
+				Wilks:              floatPtr(91.29),
+				DOTS:               floatPtr(92.33),
+				IPFGL:              floatPtr(68.72),
+				Level:              "advanced",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strengthScore(tt.record, tt.sex, tt.bodyweight, standards)
+
+			if math.Abs(got.EstimatedOneRM-tt.want.EstimatedOneRM) > scoreEpsilon {
+				t.Errorf("estimated 1RM = %v, want %v", got.EstimatedOneRM, tt.want.EstimatedOneRM)
+			}
+			if got.Level != tt.want.Level || got.NextLevel != tt.want.NextLevel {
+				t.Errorf("levels = %q/%q, want %q/%q", got.Level, got.NextLevel, tt.want.Level, tt.want.NextLevel)
+			}
+			for _, s := range []struct {
+				name      string
+				got, want *float64
+			}{
+				{"bodyweight multiple", got.BodyweightMultiple, tt.want.BodyweightMultiple},
+				{"next level 1RM", got.NextLevelOneRM, tt.want.NextLevelOneRM},
+				{"wilks", got.Wilks, tt.want.Wilks},
+				{"dots", got.DOTS, tt.want.DOTS},
+				{"ipf gl", got.IPFGL, tt.want.IPFGL},
+			} {
+				if !equalScore(s.got, s.want) {
+					t.Errorf("%s = %v, want %v", s.name, formatScore(s.got), formatScore(s.want))
+				}
+			}
+		})
+	}
+}
+
+func TestStrengthSummary(t *testing.T) {
+	lift := func(name string, weight float64) *model.UserRecord {
+		return &model.UserRecord{
+			Weight:   weight,
+			Reps:     1,
+			Exercise: model.Exercise{Lift: sql.NullString{String: name, Valid: name != ""}},
+		}
+	}
+	full := []*model.UserRecord{
+		lift(model.LiftSquat, 220),
+		lift(model.LiftBench, 150),
+		lift(model.LiftDeadlift, 230),
+	}
+
+	tests := []struct {
+		name       string
+		records    []*model.UserRecord
+		sex        string
+		bodyweight float64
+		total      float64
+		scored     bool
+	}{
+		{"no records", nil, model.SexMale, 100, 0, false},
+		{"missing a lift", full[:2], model.SexMale, 100, 370, false},
+		{"other lifts are ignored", append([]*model.UserRecord{lift(model.LiftOHP, 80), lift("", 300)}, full...), model.SexMale, 100, 600, true},
+		{"first record of a lift counts", append(full, lift(model.LiftSquat, 100)), model.SexMale, 100, 600, true},
+		{"unknown sex", full, "", 100, 600, false},
+		{"unknown bodyweight", full, model.SexMale, 0, 600, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strengthSummary(tt.records, tt.sex, tt.bodyweight)
+
+			if math.Abs(got.Total-tt.total) > scoreEpsilon {
+				t.Errorf("total = %v, want %v", got.Total, tt.total)
+			}
+			if (got.Bodyweight != nil) != (tt.bodyweight > 0) {
+				t.Errorf("bodyweight = %v, want %v", formatScore(got.Bodyweight), tt.bodyweight)
+			}
+			if !tt.scored {
+				if got.Wilks != nil || got.DOTS != nil || got.IPFGL != nil {
+					t.Errorf("total is scored, want no points")
+				}
+				return
+			}
+			for _, s := range []struct {
+				name      string
+				got, want *float64
+			}{
+				{"wilks", got.Wilks, floatPtr(365.15)},
+				{"dots", got.DOTS, floatPtr(369.31)},
+				{"ipf gl", got.IPFGL, floatPtr(75.80)},
+			} {
+				if !equalScore(s.got, s.want) {
+					t.Errorf("%s = %v, want %v", s.name, formatScore(s.got), formatScore(s.want))
+				}
+			}
+		})
+	}
+}
+
+func TestCheckStandards(t *testing.T) {
+	ladder := func(levels ...any) []*model.StrengthStandard {
+		var standards []*model.StrengthStandard
+		for i := 0; i < len(levels); i += 2 {
+			standards = append(standards, &model.StrengthStandard{
+				Level:              levels[i].(string),
+				BodyweightMultiple: levels[i+1].(float64),
+			})
+		}
+		return standards
+	}
+
+	tests := []struct {
+		name      string
+		levels    []*model.StrengthStandard
+		valid     bool
+		multiples []float64
+	}{
+		{"growing levels", ladder("novice", 1.0, "advanced", 1.5, "elite", 2.25), true, []float64{1, 1.5, 2.25}},
+		{"single level", ladder("strong", 2.0), true, []float64{2}},
+		{"multiples are rounded as stored", ladder("novice", 1.004, "advanced", 1.256), true, []float64{1, 1.26}},
+		{"no levels", nil, false, nil},
+		{"too many levels", ladder("1", 1.0, "2", 2.0, "3", 3.0, "4", 4.0, "5", 5.0, "6", 6.0, "7", 7.0, "8", 8.0, "9", 9.0, "10", 10.0, "11", 11.0), false, nil},
+		{"empty name", ladder("", 1.0), false, nil},
+		{"name too long", ladder("очень-очень-сильный-атлет", 1.0), false, nil},
+		{"duplicate name", ladder("novice", 1.0, "novice", 1.5), false, nil},
+		{"zero multiple", ladder("novice", 0.0), false, nil},
+		{"multiple too big", ladder("novice", 100.0), false, nil},
+		{"falling multiple", ladder("novice", 1.5, "advanced", 1.0), false, nil},
+		{"equal multiple", ladder("novice", 1.0, "advanced", 1.0), false, nil},
+		{"equal once rounded", ladder("novice", 1.001, "advanced", 1.004), false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkStandards(tt.levels)
+			if (err == nil) != tt.valid {
+				t.Fatalf("checkStandards() error = %v, want valid %v", err, tt.valid)
+			}
+			if !tt.valid {
+				return
+			}
+			for i, level := range tt.levels {
+				if level.Rank != i+1 {
+					t.Errorf("level %q rank = %d, want %d", level.Level, level.Rank, i+1)
+				}
+				if level.BodyweightMultiple != tt.multiples[i] {
+					t.Errorf("level %q multiple = %v, want %v", level.Level, level.BodyweightMultiple, tt.multiples[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+package workout
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/pkg/errors"
+)
+
+// defaultSyncLimit bounds each kind of server change returned by one sync.
+const defaultSyncLimit = 500
+
+// syncOrder applies parents before children: workouts are created before
+// their entries and entries are deleted before their workouts.
+func syncOrder(ch *model.SyncChange) int {
+	switch {
+	case ch.Entity == model.SyncEntityWorkout && ch.Op == model.SyncOpUpsert:
+		return 0
+	case ch.Entity == model.SyncEntityEntry && ch.Op == model.SyncOpUpsert:
+		return 1
+	case ch.Entity == model.SyncEntityEntry:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// Sync applies the changes a client queued, possibly offline, and returns
+// the server changes after the client's cursor.
+//
+// Conflicts are resolved by the client timestamps, so every device ends up
+// with the same state whatever order they sync in: the later edit wins, on a
+// tie a delete wins over an edit and a stored version over an incoming one.
+// Timestamps from the future are taken as now. Deleted workouts and entries
+// are never brought back.
+func (s *serv) Sync(ctx context.Context, req *model.SyncRequest) (*model.SyncResponse, error) {
+	changes := make([]*model.SyncChange, len(req.Changes))
+	copy(changes, req.Changes)
+	sort.SliceStable(changes, func(i, j int) bool {
+		return syncOrder(changes[i]) < syncOrder(changes[j])
+	})
+
+	resp := &model.SyncResponse{}
+	now := time.Now().UTC()
+	for _, ch := range changes {
+		if ch.ClientUpdatedAt.After(now) {
+			ch.ClientUpdatedAt = now
+		}
+
+		var res *model.SyncResult
+		err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+			var err error
+			res, err = s.applyChange(ctx, req.UserID, ch)
+			return err
+		})
+		if err != nil {
+			if !isRejection(err) {
+				return nil, err
+			}
+			res = &model.SyncResult{Status: model.SyncStatusRejected, Error: err.Error()}
+		}
+		res.Entity, res.UUID = ch.Entity, ch.UUID
+		resp.Results = append(resp.Results, res)
+	}
+
+	err := s.pullChanges(ctx, req, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// isRejection tells the errors caused by the change itself, which only fail
+// that change, from those that fail the whole sync.
+func isRejection(err error) bool {
+	return errors.Is(err, apperrors.ErrInvalidSync) ||
+		errors.Is(err, apperrors.ErrWorkoutNotFound) ||
+		errors.Is(err, apperrors.ErrExerciseNotFound) ||
+		errors.Is(err, apperrors.ErrInvalidMetrics) ||
+		errors.Is(err, apperrors.ErrInvalidWorkoutTime)
+}
+
+func (s *serv) applyChange(ctx context.Context, userID int64, ch *model.SyncChange) (*model.SyncResult, error) {
+	deleted, err := s.workoutRepository.IsSyncDeleted(ctx, userID, ch.Entity, ch.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if deleted {
+		if ch.Op == model.SyncOpDelete {
+			return &model.SyncResult{Status: model.SyncStatusApplied}, nil
+		}
+		return nil, errors.Wrap(apperrors.ErrInvalidSync, "deleted")
+	}
+
+	switch {
+	case ch.Entity == model.SyncEntityWorkout && ch.Op == model.SyncOpUpsert:
+		return s.upsertWorkout(ctx, userID, ch)
+	case ch.Entity == model.SyncEntityWorkout:
+		return s.deleteWorkout(ctx, userID, ch)
+	case ch.Op == model.SyncOpUpsert:
+		return s.upsertEntry(ctx, userID, ch)
+	default:
+		return s.deleteEntry(ctx, userID, ch)
+	}
+}
+
+func (s *serv) upsertWorkout(ctx context.Context, userID int64, ch *model.SyncChange) (*model.SyncResult, error) {
+	sw := ch.Workout
+	if sw == nil || sw.Workout == nil {
+		return nil, apperrors.ErrInvalidSync
+	}
+	w := sw.Workout
+	w.UUID, w.UserID = ch.UUID, userID
+	sw.ClientUpdatedAt = ch.ClientUpdatedAt
+	if w.StartedAt.Valid && w.EndedAt.Valid && w.EndedAt.Time.Before(w.StartedAt.Time) {
+		return nil, apperrors.ErrInvalidWorkoutTime
+	}
+
+	stored, err := s.workoutRepository.GetSyncWorkout(ctx, userID, ch.UUID)
+	if errors.Is(err, apperrors.ErrWorkoutNotFound) {
+		w.ID, err = s.workoutRepository.CreateSyncWorkout(ctx, sw)
+		if err != nil {
+			return nil, err
+		}
+		return &model.SyncResult{Status: model.SyncStatusApplied}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !ch.ClientUpdatedAt.After(stored.ClientUpdatedAt) {
+		return &model.SyncResult{Status: model.SyncStatusConflict, Workout: stored}, nil
+	}
+
+	w.ID = stored.Workout.ID
+	err = s.workoutRepository.UpdateSyncWorkout(ctx, sw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SyncResult{Status: model.SyncStatusApplied}, nil
+}
+
+func (s *serv) deleteWorkout(ctx context.Context, userID int64, ch *model.SyncChange) (*model.SyncResult, error) {
+	stored, err := s.workoutRepository.GetSyncWorkout(ctx, userID, ch.UUID)
+	if errors.Is(err, apperrors.ErrWorkoutNotFound) {
+		// never reached the server, nothing to delete
+		return &model.SyncResult{Status: model.SyncStatusApplied}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ch.ClientUpdatedAt.Before(stored.ClientUpdatedAt) {
+		return &model.SyncResult{Status: model.SyncStatusConflict, Workout: stored}, nil
+	}
+
+	err = s.workoutRepository.DeleteWorkout(ctx, userID, stored.Workout.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SyncResult{Status: model.SyncStatusApplied}, nil
+}
+
+func (s *serv) upsertEntry(ctx context.Context, userID int64, ch *model.SyncChange) (*model.SyncResult, error) {
+	se := ch.Entry
+	if se == nil || se.Entry == nil {
+		return nil, apperrors.ErrInvalidSync
+	}
+	e := se.Entry
+	e.UUID = ch.UUID
+	se.ClientUpdatedAt = ch.ClientUpdatedAt
+
+	workout, err := s.workoutRepository.GetSyncWorkout(ctx, userID, se.WorkoutUUID)
+	if err != nil {
+		return nil, err
+	}
+	e.WorkoutID = workout.Workout.ID
+
+	exercise, err := s.workoutRepository.GetExerciseByID(ctx, e.ExerciseID)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkMetrics(exercise.TrackingProfile, e); err != nil {
+		return nil, err
+	}
+
+	stored, err := s.workoutRepository.GetSyncEntry(ctx, userID, ch.UUID)
+	if errors.Is(err, apperrors.ErrEntryNotFound) {
+		e.ID, err = s.workoutRepository.CreateSyncEntry(ctx, se)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsWarmUp {
+			err = s.UpdatePersonalRecord(ctx, userID, e)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &model.SyncResult{Status: model.SyncStatusApplied}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.WorkoutUUID != workout.Workout.UUID {
+		return nil, errors.Wrap(apperrors.ErrInvalidSync, "entry belongs to another workout")
+	}
+	if !ch.ClientUpdatedAt.After(stored.ClientUpdatedAt) {
+		return &model.SyncResult{Status: model.SyncStatusConflict, Entry: stored}, nil
+	}
+
+	e.ID = stored.Entry.ID
+	err = s.workoutRepository.UpdateSyncEntry(ctx, se)
+	if err != nil {
+		return nil, err
+	}
+	if !e.IsWarmUp {
+		err = s.UpdatePersonalRecord(ctx, userID, e)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.SyncResult{Status: model.SyncStatusApplied}, nil
+}
+
+func (s *serv) deleteEntry(ctx context.Context, userID int64, ch *model.SyncChange) (*model.SyncResult, error) {
+	stored, err := s.workoutRepository.GetSyncEntry(ctx, userID, ch.UUID)
+	if errors.Is(err, apperrors.ErrEntryNotFound) {
+		return &model.SyncResult{Status: model.SyncStatusApplied}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ch.ClientUpdatedAt.Before(stored.ClientUpdatedAt) {
+		return &model.SyncResult{Status: model.SyncStatusConflict, Entry: stored}, nil
+	}
+
+	err = s.workoutRepository.DeleteWorkoutExercise(ctx, stored.Entry.WorkoutID, stored.Entry.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SyncResult{Status: model.SyncStatusApplied}, nil
+}
+
+// pullChanges reads up to limit changes of each kind. When a kind fills its
+// limit the page is cut at its last change so that the next sync resumes
+// without gaps. The user's changes are locked meanwhile, so all kinds are
+// read as of the same point.
+func (s *serv) pullChanges(ctx context.Context, req *model.SyncRequest, resp *model.SyncResponse) error {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultSyncLimit
+	}
+
+	var (
+		workouts []*model.SyncWorkout
+		entries  []*model.SyncEntry
+		deleted  []*model.SyncTombstone
+	)
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		err := s.workoutRepository.LockSyncChanges(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+
+		workouts, err = s.workoutRepository.ListWorkoutChanges(ctx, req.UserID, req.Cursor, limit)
+		if err != nil {
+			return err
+		}
+
+		entries, err = s.workoutRepository.ListEntryChanges(ctx, req.UserID, req.Cursor, limit)
+		if err != nil {
+			return err
+		}
+
+		deleted, err = s.workoutRepository.ListTombstones(ctx, req.UserID, req.Cursor, limit)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	var bound int64 = -1
+	cut := func(n int, last int64) {
+		if uint64(n) == limit && (bound < 0 || last < bound) {
+			bound = last
+		}
+	}
+	if len(workouts) > 0 {
+		cut(len(workouts), workouts[len(workouts)-1].Seq)
+	}
+	if len(entries) > 0 {
+		cut(len(entries), entries[len(entries)-1].Seq)
+	}
+	if len(deleted) > 0 {
+		cut(len(deleted), deleted[len(deleted)-1].Seq)
+	}
+
+	resp.HasMore = bound >= 0
+	resp.Cursor = req.Cursor
+	for _, w := range workouts {
+		if resp.HasMore && w.Seq > bound {
+			break
+		}
+		resp.Workouts = append(resp.Workouts, w)
+		resp.Cursor = max(resp.Cursor, w.Seq)
+	}
+	for _, e := range entries {
+		if resp.HasMore && e.Seq > bound {
+			break
+		}
+		resp.Entries = append(resp.Entries, e)
+		resp.Cursor = max(resp.Cursor, e.Seq)
+	}
+	for _, t := range deleted {
+		if resp.HasMore && t.Seq > bound {
+			break
+		}
+		resp.Deleted = append(resp.Deleted, t)
+		resp.Cursor = max(resp.Cursor, t.Seq)
+	}
+
+	return nil
+}
@@ -0,0 +1,481 @@
+package workout
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/biryanim/workoutbook/internal/client/db"
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+)
+
+// noTx runs the handlers without a transaction.
+type noTx struct{}
+
+func (noTx) ReadCommited(ctx context.Context, f db.Handler) error {
+	return f(ctx)
+}
+
+// syncRepository keeps one user's synced workouts and entries in memory,
+// keyed by UUID; any call outside of sync panics.
+type syncRepository struct {
+	repository.WorkoutRepository
+
+	workouts  map[string]*model.SyncWorkout
+	entries   map[string]*model.SyncEntry
+	deleted   map[string]bool
+	exercises map[int64]*model.Exercise
+	records   []*model.UserRecord
+	lastID    int64
+	failWith  error
+}
+
+func newSyncRepository() *syncRepository {
+	return &syncRepository{
+		workouts: make(map[string]*model.SyncWorkout),
+		entries:  make(map[string]*model.SyncEntry),
+		deleted:  make(map[string]bool),
+		exercises: map[int64]*model.Exercise{
+			benchPress: {ID: benchPress, TrackingProfile: model.TrackingWeightReps},
+		},
+	}
+}
+
+const benchPress = 1
+
+func (r *syncRepository) IsSyncDeleted(_ context.Context, _ int64, entity, uuid string) (bool, error) {
+	if r.failWith != nil {
+		return false, r.failWith
+	}
+	return r.deleted[entity+"/"+uuid], nil
+}
+
+func (r *syncRepository) GetSyncWorkout(_ context.Context, _ int64, uuid string) (*model.SyncWorkout, error) {
+	sw, ok := r.workouts[uuid]
+	if !ok {
+		return nil, apperrors.ErrWorkoutNotFound
+	}
+	return sw, nil
+}
+
+func (r *syncRepository) CreateSyncWorkout(_ context.Context, sw *model.SyncWorkout) (int64, error) {
+	r.lastID++
+	sw.Workout.ID = r.lastID
+	r.workouts[sw.Workout.UUID] = sw
+	return sw.Workout.ID, nil
+}
+
+func (r *syncRepository) UpdateSyncWorkout(_ context.Context, sw *model.SyncWorkout) error {
+	r.workouts[sw.Workout.UUID] = sw
+	return nil
+}
+
+func (r *syncRepository) DeleteWorkout(_ context.Context, _, workoutID int64) error {
+	for uuid, sw := range r.workouts {
+		if sw.Workout.ID != workoutID {
+			continue
+		}
+		for entryUUID, se := range r.entries {
+			if se.WorkoutUUID == uuid {
+				delete(r.entries, entryUUID)
+				r.deleted[model.SyncEntityEntry+"/"+entryUUID] = true
+			}
+		}
+		delete(r.workouts, uuid)
+		r.deleted[model.SyncEntityWorkout+"/"+uuid] = true
+	}
+	return nil
+}
+
+func (r *syncRepository) GetSyncEntry(_ context.Context, _ int64, uuid string) (*model.SyncEntry, error) {
+	se, ok := r.entries[uuid]
+	if !ok {
+		return nil, apperrors.ErrEntryNotFound
+	}
+	return se, nil
+}
+
+func (r *syncRepository) CreateSyncEntry(_ context.Context, se *model.SyncEntry) (int64, error) {
+	r.lastID++
+	se.Entry.ID = r.lastID
+	r.entries[se.Entry.UUID] = se
+	return se.Entry.ID, nil
+}
+
+func (r *syncRepository) UpdateSyncEntry(_ context.Context, se *model.SyncEntry) error {
+	r.entries[se.Entry.UUID] = se
+	return nil
+}
+
+func (r *syncRepository) DeleteWorkoutExercise(_ context.Context, _, workoutExerciseID int64) error {
+	for uuid, se := range r.entries {
+		if se.Entry.ID == workoutExerciseID {
+			delete(r.entries, uuid)
+			r.deleted[model.SyncEntityEntry+"/"+uuid] = true
+		}
+	}
+	return nil
+}
+
+func (r *syncRepository) GetExerciseByID(_ context.Context, exerciseID int64) (*model.Exercise, error) {
+	e, ok := r.exercises[exerciseID]
+	if !ok {
+		return nil, apperrors.ErrExerciseNotFound
+	}
+	return e, nil
+}
+
+func (r *syncRepository) GetPersonalRecord(_ context.Context, _, exerciseID int64) (*model.UserRecord, error) {
+	for _, rec := range r.records {
+		if rec.ExerciseID == exerciseID {
+			return rec, nil
+		}
+	}
+	return nil, apperrors.ErrRecordNotFound
+}
+
+func (r *syncRepository) AddRecord(_ context.Context, rec *model.UserRecord) (int64, error) {
+	r.records = append(r.records, rec)
+	return int64(len(r.records)), nil
+}
+
+func (r *syncRepository) UpdatePersonalRecord(_ context.Context, rec *model.UserRecord) error {
+	for i, stored := range r.records {
+		if stored.ExerciseID == rec.ExerciseID {
+			r.records[i] = rec
+		}
+	}
+	return nil
+}
+
+func (r *syncRepository) LockSyncChanges(context.Context, int64) error {
+	return nil
+}
+
+func (r *syncRepository) ListWorkoutChanges(context.Context, int64, int64, uint64) ([]*model.SyncWorkout, error) {
+	return nil, nil
+}
+
+func (r *syncRepository) ListEntryChanges(context.Context, int64, int64, uint64) ([]*model.SyncEntry, error) {
+	return nil, nil
+}
+
+func (r *syncRepository) ListTombstones(context.Context, int64, int64, uint64) ([]*model.SyncTombstone, error) {
+	return nil, nil
+}
+
+const (
+	workoutUUID = "00000000-0000-0000-0000-00000000000a"
+	otherUUID   = "00000000-0000-0000-0000-00000000000b"
+	entryUUID   = "00000000-0000-0000-0000-000000000001"
+)
+
+var syncedAt = time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+
+func upsertWorkout(uuid, name string, at time.Time) *model.SyncChange {
+	return &model.SyncChange{
+		Entity:          model.SyncEntityWorkout,
+		Op:              model.SyncOpUpsert,
+		UUID:            uuid,
+		ClientUpdatedAt: at,
+		Workout:         &model.SyncWorkout{Workout: &model.Workout{Name: name, Date: at}},
+	}
+}
+
+func upsertEntry(uuid, workout string, weight float64, at time.Time) *model.SyncChange {
+	return &model.SyncChange{
+		Entity:          model.SyncEntityEntry,
+		Op:              model.SyncOpUpsert,
+		UUID:            uuid,
+		ClientUpdatedAt: at,
+		Entry: &model.SyncEntry{
+			WorkoutUUID: workout,
+			Entry:       &model.WorkoutExercise{ExerciseID: benchPress, Sets: 1, Reps: 5, Weight: weight},
+		},
+	}
+}
+
+func deleteChange(entity, uuid string, at time.Time) *model.SyncChange {
+	return &model.SyncChange{Entity: entity, Op: model.SyncOpDelete, UUID: uuid, ClientUpdatedAt: at}
+}
+
+// seed stores a workout with one 100 kg entry, both last edited at syncedAt.
+func seed(r *syncRepository) {
+	r.workouts[workoutUUID] = &model.SyncWorkout{
+		ClientUpdatedAt: syncedAt,
+		Workout:         &model.Workout{ID: 100, UUID: workoutUUID, Name: "stored"},
+	}
+	r.workouts[otherUUID] = &model.SyncWorkout{
+		ClientUpdatedAt: syncedAt,
+		Workout:         &model.Workout{ID: 101, UUID: otherUUID, Name: "other"},
+	}
+	r.entries[entryUUID] = &model.SyncEntry{
+		WorkoutUUID:     workoutUUID,
+		ClientUpdatedAt: syncedAt,
+		Entry:           &model.WorkoutExercise{ID: 200, UUID: entryUUID, WorkoutID: 100, ExerciseID: benchPress, Sets: 1, Reps: 5, Weight: 100},
+	}
+	r.lastID = 200
+}
+
+func TestSyncConflicts(t *testing.T) {
+	before, after := syncedAt.Add(-time.Minute), syncedAt.Add(time.Minute)
+
+	warmUp := upsertEntry(entryUUID, workoutUUID, 60, after)
+	warmUp.Entry.Entry.IsWarmUp = true
+
+	endsFirst := upsertWorkout("00000000-0000-0000-0000-00000000000c", "backwards", after)
+	endsFirst.Workout.Workout.StartedAt = sql.NullTime{Time: after, Valid: true}
+	endsFirst.Workout.Workout.EndedAt = sql.NullTime{Time: syncedAt, Valid: true}
+
+	badMetrics := upsertEntry(entryUUID, workoutUUID, 100, after)
+	badMetrics.Entry.Entry.Reps = 0
+
+	tests := []struct {
+		name    string
+		prepare func(r *syncRepository)
+		changes []*model.SyncChange
+		want    []string
+		check   func(t *testing.T, r *syncRepository)
+	}{
+		{
+			name:    "newer workout edit wins",
+			changes: []*model.SyncChange{upsertWorkout(workoutUUID, "edited", after)},
+			want:    []string{model.SyncStatusApplied},
+			check: func(t *testing.T, r *syncRepository) {
+				if sw := r.workouts[workoutUUID]; sw.Workout.Name != "edited" || sw.Workout.ID != 100 || !sw.ClientUpdatedAt.Equal(after) {
+					t.Errorf("stored workout = %+v, want the edit", sw.Workout)
+				}
+			},
+		},
+		{
+			name:    "older workout edit loses",
+			changes: []*model.SyncChange{upsertWorkout(workoutUUID, "stale", before)},
+			want:    []string{model.SyncStatusConflict},
+			check:   storedWorkoutName("stored"),
+		},
+		{
+			name:    "stored workout wins a tie",
+			changes: []*model.SyncChange{upsertWorkout(workoutUUID, "tied", syncedAt)},
+			want:    []string{model.SyncStatusConflict},
+			check:   storedWorkoutName("stored"),
+		},
+		{
+			name:    "delete older than the last edit loses",
+			changes: []*model.SyncChange{deleteChange(model.SyncEntityWorkout, workoutUUID, before)},
+			want:    []string{model.SyncStatusConflict},
+			check:   storedWorkoutName("stored"),
+		},
+		{
+			name:    "delete wins a tie and takes the entries along",
+			changes: []*model.SyncChange{deleteChange(model.SyncEntityWorkout, workoutUUID, syncedAt)},
+			want:    []string{model.SyncStatusApplied},
+			check: func(t *testing.T, r *syncRepository) {
+				if _, ok := r.workouts[workoutUUID]; ok {
+					t.Error("workout is still stored")
+				}
+				if !r.deleted[model.SyncEntityEntry+"/"+entryUUID] {
+					t.Error("entry of the deleted workout is not tombstoned")
+				}
+			},
+		},
+		{
+			name:    "deleted workout is not brought back",
+			prepare: tombstone(model.SyncEntityWorkout, "00000000-0000-0000-0000-00000000000c"),
+			changes: []*model.SyncChange{upsertWorkout("00000000-0000-0000-0000-00000000000c", "revived", after)},
+			want:    []string{model.SyncStatusRejected},
+		},
+		{
+			name:    "deleted entry is not brought back",
+			prepare: tombstone(model.SyncEntityEntry, "00000000-0000-0000-0000-000000000002"),
+			changes: []*model.SyncChange{upsertEntry("00000000-0000-0000-0000-000000000002", workoutUUID, 120, after)},
+			want:    []string{model.SyncStatusRejected},
+		},
+		{
+			name:    "deleting again is applied",
+			prepare: tombstone(model.SyncEntityEntry, "00000000-0000-0000-0000-000000000002"),
+			changes: []*model.SyncChange{deleteChange(model.SyncEntityEntry, "00000000-0000-0000-0000-000000000002", before)},
+			want:    []string{model.SyncStatusApplied},
+		},
+		{
+			name:    "newer entry delete wins",
+			changes: []*model.SyncChange{deleteChange(model.SyncEntityEntry, entryUUID, after)},
+			want:    []string{model.SyncStatusApplied},
+			check: func(t *testing.T, r *syncRepository) {
+				if _, ok := r.entries[entryUUID]; ok || !r.deleted[model.SyncEntityEntry+"/"+entryUUID] {
+					t.Error("entry is not deleted")
+				}
+			},
+		},
+		{
+			name:    "deleting what never synced is applied",
+			changes: []*model.SyncChange{deleteChange(model.SyncEntityWorkout, "00000000-0000-0000-0000-0000000000ff", after)},
+			want:    []string{model.SyncStatusApplied},
+		},
+		{
+			name: "new workout and its entry in one batch",
+			changes: []*model.SyncChange{
+				upsertEntry("00000000-0000-0000-0000-000000000002", "00000000-0000-0000-0000-00000000000c", 80, after),
+				upsertWorkout("00000000-0000-0000-0000-00000000000c", "new", after),
+			},
+			want: []string{model.SyncStatusApplied, model.SyncStatusApplied},
+			check: func(t *testing.T, r *syncRepository) {
+				se := r.entries["00000000-0000-0000-0000-000000000002"]
+				if se == nil || se.Entry.WorkoutID != r.workouts["00000000-0000-0000-0000-00000000000c"].Workout.ID {
+					t.Errorf("entry = %+v, want it in the new workout", se)
+				}
+				storedRecord(80)(t, r)
+			},
+		},
+		{
+			name:    "newer entry edit wins and updates the record",
+			changes: []*model.SyncChange{upsertEntry(entryUUID, workoutUUID, 120, after)},
+			want:    []string{model.SyncStatusApplied},
+			check: func(t *testing.T, r *syncRepository) {
+				if se := r.entries[entryUUID]; se.Entry.Weight != 120 || se.Entry.ID != 200 {
+					t.Errorf("stored entry = %+v, want the edit", se.Entry)
+				}
+				storedRecord(120)(t, r)
+			},
+		},
+		{
+			name:    "edited warm-up sets no record",
+			changes: []*model.SyncChange{warmUp},
+			want:    []string{model.SyncStatusApplied},
+			check:   storedRecord(0),
+		},
+		{
+			name:    "older entry edit loses",
+			changes: []*model.SyncChange{upsertEntry(entryUUID, workoutUUID, 120, before)},
+			want:    []string{model.SyncStatusConflict},
+			check: func(t *testing.T, r *syncRepository) {
+				if w := r.entries[entryUUID].Entry.Weight; w != 100 {
+					t.Errorf("stored weight = %v, want 100", w)
+				}
+				storedRecord(0)(t, r)
+			},
+		},
+		{
+			name:    "older entry delete loses",
+			changes: []*model.SyncChange{deleteChange(model.SyncEntityEntry, entryUUID, before)},
+			want:    []string{model.SyncStatusConflict},
+		},
+		{
+			name:    "entry moved to another workout is rejected",
+			changes: []*model.SyncChange{upsertEntry(entryUUID, otherUUID, 120, after)},
+			want:    []string{model.SyncStatusRejected},
+		},
+		{
+			name:    "entry of an unknown workout is rejected",
+			changes: []*model.SyncChange{upsertEntry("00000000-0000-0000-0000-000000000002", "00000000-0000-0000-0000-0000000000ff", 80, after)},
+			want:    []string{model.SyncStatusRejected},
+		},
+		{
+			name:    "entry breaking the tracking profile is rejected",
+			changes: []*model.SyncChange{badMetrics},
+			want:    []string{model.SyncStatusRejected},
+		},
+		{
+			name:    "workout ending before it starts is rejected",
+			changes: []*model.SyncChange{endsFirst},
+			want:    []string{model.SyncStatusRejected},
+		},
+		{
+			name:    "upsert without a payload is rejected",
+			changes: []*model.SyncChange{{Entity: model.SyncEntityWorkout, Op: model.SyncOpUpsert, UUID: workoutUUID, ClientUpdatedAt: after}},
+			want:    []string{model.SyncStatusRejected},
+		},
+		{
+			name:    "rejection fails only its own change",
+			changes: []*model.SyncChange{upsertEntry(entryUUID, otherUUID, 120, after), upsertWorkout(workoutUUID, "edited", after)},
+			want:    []string{model.SyncStatusApplied, model.SyncStatusRejected},
+			check:   storedWorkoutName("edited"),
+		},
+		{
+			name:    "timestamps from the future are taken as now",
+			changes: []*model.SyncChange{upsertWorkout(workoutUUID, "future", time.Now().Add(24*time.Hour))},
+			want:    []string{model.SyncStatusApplied},
+			check: func(t *testing.T, r *syncRepository) {
+				if at := r.workouts[workoutUUID].ClientUpdatedAt; at.After(time.Now()) {
+					t.Errorf("stored client time %v is in the future", at)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newSyncRepository()
+			seed(r)
+			if tt.prepare != nil {
+				tt.prepare(r)
+			}
+			s := &serv{workoutRepository: r, txManager: noTx{}}
+
+			resp, err := s.Sync(context.Background(), &model.SyncRequest{UserID: 1, Changes: tt.changes})
+			if err != nil {
+				t.Fatalf("Sync() error = %v", err)
+			}
+			if len(resp.Results) != len(tt.want) {
+				t.Fatalf("got %d results, want %d", len(resp.Results), len(tt.want))
+			}
+			for i, res := range resp.Results {
+				if res.Status != tt.want[i] {
+					t.Errorf("result %d (%s %s) = %s %q, want %s", i, res.Entity, res.UUID, res.Status, res.Error, tt.want[i])
+				}
+				if res.Status == model.SyncStatusConflict && res.Workout == nil && res.Entry == nil {
+					t.Errorf("conflict %d does not return the stored version", i)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, r)
+			}
+		})
+	}
+}
+
+func TestSyncInfrastructureError(t *testing.T) {
+	r := newSyncRepository()
+	r.failWith = errors.New("connection reset")
+	s := &serv{workoutRepository: r, txManager: noTx{}}
+
+	_, err := s.Sync(context.Background(), &model.SyncRequest{
+		UserID:  1,
+		Changes: []*model.SyncChange{upsertWorkout(workoutUUID, "edited", syncedAt)},
+	})
+	if !errors.Is(err, r.failWith) {
+		t.Errorf("Sync() error = %v, want %v", err, r.failWith)
+	}
+}
+
+func tombstone(entity, uuid string) func(r *syncRepository) {
+	return func(r *syncRepository) {
+		r.deleted[entity+"/"+uuid] = true
+	}
+}
+
+func storedWorkoutName(name string) func(t *testing.T, r *syncRepository) {
+	return func(t *testing.T, r *syncRepository) {
+		if got := r.workouts[workoutUUID].Workout.Name; got != name {
+			t.Errorf("stored workout name = %q, want %q", got, name)
+		}
+	}
+}
+
+// storedRecord checks the bench press record; zero means none.
+func storedRecord(weight float64) func(t *testing.T, r *syncRepository) {
+	return func(t *testing.T, r *syncRepository) {
+		var got float64
+		for _, rec := range r.records {
+			if rec.ExerciseID == benchPress {
+				got = rec.Weight
+			}
+		}
+		if got != weight {
+			t.Errorf("bench press record = %v, want %v", got, weight)
+		}
+	}
+}
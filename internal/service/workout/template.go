@@ -0,0 +1,155 @@
+package workout
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func (s *serv) CreateTemplate(ctx context.Context, template *model.WorkoutTemplate) (int64, error) {
+	var id int64
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		var errTx error
+		id, errTx = s.templateRepository.CreateTemplate(ctx, template)
+		if errTx != nil {
+			return errTx
+		}
+
+		for i, e := range template.Exercises {
+			e.TemplateID = id
+			e.Position = i + 1
+		}
+		return s.templateRepository.AddTemplateExercises(ctx, template.Exercises)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *serv) ListTemplates(ctx context.Context, userID int64) ([]*model.WorkoutTemplate, error) {
+	templates, err := s.templateRepository.ListTemplates(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (s *serv) GetTemplate(ctx context.Context, userID, templateID int64) (*model.WorkoutTemplate, error) {
+	template, err := s.templateRepository.GetTemplate(ctx, templateID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	template.Exercises, err = s.templateRepository.GetTemplateExercises(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// InstantiateTemplate creates an empty workout from the template and suggests
+// a load for each planned exercise. Exercises with a fixed weight in the
+// template keep it until there is history to progress from.
+func (s *serv) InstantiateTemplate(ctx context.Context, params *model.InstantiateTemplateParams) (*model.TemplateWorkout, error) {
+	res := &model.TemplateWorkout{}
+
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		template, err := s.GetTemplate(ctx, params.UserID, params.TemplateID)
+		if err != nil {
+			return err
+		}
+
+		workout := &model.Workout{
+			UserID: params.UserID,
+			Date:   params.Date,
+			Name:   params.Name,
+			Notes:  template.Notes,
+		}
+		if workout.Name == "" {
+			workout.Name = template.Name
+		}
+		if workout.Date.IsZero() {
+			workout.Date = time.Now().UTC()
+		}
+
+		res.WorkoutID, err = s.workoutRepository.CreateWorkout(ctx, workout)
+		if err != nil {
+			return err
+		}
+
+		for _, te := range template.Exercises {
+			suggestion, err := s.suggest(ctx, params.UserID, &te.Exercise, te.Reps)
+			if err != nil {
+				return err
+			}
+			if suggestion.Action == model.ProgressionStart && te.Weight.Valid {
+				suggestion.Weight = te.Weight.Float64
+			}
+			if suggestion.Sets == 0 || te.Sets > 0 {
+				suggestion.Sets = te.Sets
+			}
+
+			res.Exercises = append(res.Exercises, &model.PlannedExercise{
+				TemplateExercise: te,
+				Suggestion:       suggestion,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SwapTemplateExercise replaces the exercise of a template entry with a
+// substitute of the same type and tracking profile; the sets, reps, weight
+// and notes planned for the entry stay as they are.
+func (s *serv) SwapTemplateExercise(ctx context.Context, params *model.SwapExerciseParams) (*model.TemplateExercise, error) {
+	var entry *model.TemplateExercise
+	err := s.txManager.ReadCommited(ctx, func(ctx context.Context) error {
+		template, err := s.GetTemplate(ctx, params.UserID, params.ParentID)
+		if err != nil {
+			return err
+		}
+
+		i := slices.IndexFunc(template.Exercises, func(e *model.TemplateExercise) bool {
+			return e.ID == params.EntryID
+		})
+		if i < 0 {
+			return apperrors.ErrPlanEntryNotFound
+		}
+		entry = template.Exercises[i]
+
+		substitute, err := s.workoutRepository.GetExerciseByID(ctx, params.ExerciseID)
+		if err != nil {
+			return err
+		}
+		if substitute.ID == entry.ExerciseID || !model.Substitutable(&entry.Exercise, substitute) {
+			return apperrors.ErrInvalidSubstitute
+		}
+
+		err = s.templateRepository.ReplaceTemplateExercise(ctx, entry.ID, substitute.ID)
+		if err != nil {
+			return err
+		}
+		entry.ExerciseID = substitute.ID
+		entry.Exercise = *substitute
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
@@ -0,0 +1,125 @@
+package workout
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/biryanim/workoutbook/internal/repository"
+)
+
+// catalogRepository serves the exercises of the catalog by id.
+type catalogRepository struct {
+	repository.WorkoutRepository
+	exercises map[int64]*model.Exercise
+}
+
+func (r *catalogRepository) GetExerciseByID(_ context.Context, exerciseID int64) (*model.Exercise, error) {
+	ex, ok := r.exercises[exerciseID]
+	if !ok {
+		return nil, apperrors.ErrExerciseNotFound
+	}
+	copied := *ex
+	return &copied, nil
+}
+
+// templateRepository holds one template with one entry and remembers the
+// exercise the entry was swapped to.
+type templateRepository struct {
+	repository.TemplateRepository
+	entry    *model.TemplateExercise
+	replaced int64
+}
+
+func (r *templateRepository) GetTemplate(_ context.Context, templateID, userID int64) (*model.WorkoutTemplate, error) {
+	if templateID != r.entry.TemplateID || userID != 1 {
+		return nil, apperrors.ErrTemplateNotFound
+	}
+	return &model.WorkoutTemplate{ID: templateID, UserID: userID}, nil
+}
+
+func (r *templateRepository) GetTemplateExercises(context.Context, int64) ([]*model.TemplateExercise, error) {
+	entry := *r.entry
+	return []*model.TemplateExercise{&entry}, nil
+}
+
+func (r *templateRepository) ReplaceTemplateExercise(_ context.Context, _, exerciseID int64) error {
+	r.replaced = exerciseID
+	return nil
+}
+
+func TestSwapTemplateExercise(t *testing.T) {
+	const (
+		templateID = 5
+		entryID    = 7
+		squat      = 1
+		frontSquat = 2
+		pistol     = 3
+		wallSit    = 4
+		rowing     = 5
+	)
+	exercises := map[int64]*model.Exercise{
+		squat:      {ID: squat, Type: "strength", TrackingProfile: model.TrackingWeightReps},
+		frontSquat: {ID: frontSquat, Type: "strength", TrackingProfile: model.TrackingWeightReps},
+		pistol:     {ID: pistol, Type: "strength", TrackingProfile: model.TrackingReps},
+		wallSit:    {ID: wallSit, Type: "strength", TrackingProfile: model.TrackingTime},
+		rowing:     {ID: rowing, Type: "cardio", TrackingProfile: model.TrackingDistanceTime},
+	}
+
+	tests := []struct {
+		name       string
+		entryID    int64
+		substitute int64
+		err        error
+	}{
+		{"same type and profile", entryID, frontSquat, nil},
+		{"same exercise", entryID, squat, apperrors.ErrInvalidSubstitute},
+		{"bodyweight variant", entryID, pistol, apperrors.ErrInvalidSubstitute},
+		{"timed variant", entryID, wallSit, apperrors.ErrInvalidSubstitute},
+		{"other type", entryID, rowing, apperrors.ErrInvalidSubstitute},
+		{"unknown exercise", entryID, 99, apperrors.ErrExerciseNotFound},
+		{"unknown entry", 8, frontSquat, apperrors.ErrPlanEntryNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templates := &templateRepository{entry: &model.TemplateExercise{
+				ID:         entryID,
+				TemplateID: templateID,
+				ExerciseID: squat,
+				Sets:       5,
+				Reps:       5,
+				Exercise:   *exercises[squat],
+			}}
+			s := &serv{
+				workoutRepository:  &catalogRepository{exercises: exercises},
+				templateRepository: templates,
+				txManager:          noTx{},
+			}
+
+			entry, err := s.SwapTemplateExercise(context.Background(), &model.SwapExerciseParams{
+				UserID:     1,
+				ParentID:   templateID,
+				EntryID:    tt.entryID,
+				ExerciseID: tt.substitute,
+			})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("SwapTemplateExercise() error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				if templates.replaced != 0 {
+					t.Errorf("entry swapped to %d, want it kept", templates.replaced)
+				}
+				return
+			}
+			if templates.replaced != tt.substitute || entry.ExerciseID != tt.substitute || entry.Exercise.ID != tt.substitute {
+				t.Errorf("swapped to %d, entry = %+v, want %d", templates.replaced, entry, tt.substitute)
+			}
+			if entry.Sets != 5 || entry.Reps != 5 {
+				t.Errorf("prescription = %dx%d, want it kept at 5x5", entry.Sets, entry.Reps)
+			}
+		})
+	}
+}
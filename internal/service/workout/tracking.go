@@ -0,0 +1,94 @@
+package workout
+
+import (
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+	"github.com/pkg/errors"
+)
+
+// metrics tells which metrics a set of a tracking profile is logged with.
+// Duration is accepted with any profile, watches record it for every set, but
+// only required where it is the measure. A distance is tracked but may be
+// missing when a duration is logged, as on a stationary bike.
+type metrics struct {
+	reps, weight, duration, distance bool
+}
+
+var trackedMetrics = map[string]metrics{
+	model.TrackingWeightReps:   {reps: true, weight: true},
+	model.TrackingReps:         {reps: true},
+	model.TrackingTime:         {duration: true},
+	model.TrackingWeightTime:   {weight: true, duration: true},
+	model.TrackingDistanceTime: {distance: true},
+	model.TrackingAssisted:     {reps: true, weight: true},
+}
+
+// profileMetrics returns the metrics of the tracking profile; exercises
+// without one are tracked by weight and reps.
+func profileMetrics(profile string) metrics {
+	tracked, ok := trackedMetrics[profile]
+	if !ok {
+		return trackedMetrics[model.TrackingWeightReps]
+	}
+
+	return tracked
+}
+
+// checkMetrics checks that a set is logged with the metrics of the exercise's
+// tracking profile and carries none of the others, and that its heart rates
+// agree.
+func checkMetrics(profile string, we *model.WorkoutExercise) error {
+	tracked := profileMetrics(profile)
+
+	switch {
+	case tracked.reps && we.Reps <= 0:
+		return errors.Wrapf(apperrors.ErrInvalidMetrics, "reps required for %s", profile)
+	case !tracked.reps && we.Reps != 0:
+		return errors.Wrapf(apperrors.ErrInvalidMetrics, "reps not tracked for %s", profile)
+	case !tracked.weight && we.Weight != 0:
+		return errors.Wrapf(apperrors.ErrInvalidMetrics, "weight not tracked for %s", profile)
+	case profile == model.TrackingAssisted && we.Weight > 0:
+		return errors.Wrap(apperrors.ErrInvalidMetrics, "assistance is logged as a negative weight")
+	case profile != model.TrackingAssisted && we.Weight < 0:
+		return errors.Wrap(apperrors.ErrInvalidMetrics, "weight must not be negative")
+	case we.Duration < 0:
+		return errors.Wrap(apperrors.ErrInvalidMetrics, "duration must not be negative")
+	case tracked.duration && we.Duration == 0:
+		return errors.Wrapf(apperrors.ErrInvalidMetrics, "duration required for %s", profile)
+	case we.Distance < 0:
+		return errors.Wrap(apperrors.ErrInvalidMetrics, "distance must not be negative")
+	case tracked.distance && we.Distance == 0 && we.Duration == 0:
+		return errors.Wrapf(apperrors.ErrInvalidMetrics, "distance or duration required for %s", profile)
+	case !tracked.distance && (we.Distance != 0 || len(we.Splits) > 0):
+		return errors.Wrapf(apperrors.ErrInvalidMetrics, "distance not tracked for %s", profile)
+	case we.AvgHeartRate.Valid && we.MaxHeartRate.Valid && we.MaxHeartRate.Int32 < we.AvgHeartRate.Int32:
+		return errors.Wrap(apperrors.ErrInvalidMetrics, "max heart rate must not be below the average")
+	}
+
+	return nil
+}
+
+// betterRecord tells whether the set beats the record under the tracking
+// profile: by estimated 1RM for weight and reps, by reps or time when only
+// those are tracked, by load and then time, by distance and then the faster
+// time, sets without a distance never winning on time, and for assisted sets
+// by the least assistance and then reps.
+func betterRecord(profile string, set, record *model.UserRecord) bool {
+	switch profile {
+	case model.TrackingReps:
+		return set.Reps > record.Reps
+	case model.TrackingTime:
+		return set.Duration > record.Duration
+	case model.TrackingWeightTime:
+		return set.Weight > record.Weight ||
+			set.Weight == record.Weight && set.Duration > record.Duration
+	case model.TrackingDistanceTime:
+		return set.Distance > record.Distance ||
+			set.Distance == record.Distance && set.Distance > 0 && set.Duration > 0 && (record.Duration == 0 || set.Duration < record.Duration)
+	case model.TrackingAssisted:
+		return set.Weight > record.Weight ||
+			set.Weight == record.Weight && set.Reps > record.Reps
+	default:
+		return estimatedOneRM(set.Weight, set.Reps) > estimatedOneRM(record.Weight, record.Reps)
+	}
+}
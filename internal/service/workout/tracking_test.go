@@ -0,0 +1,116 @@
+package workout
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	apperrors "github.com/biryanim/workoutbook/internal/errors"
+	"github.com/biryanim/workoutbook/internal/model"
+)
+
+func heartRate(bpm int32) sql.NullInt32 {
+	return sql.NullInt32{Int32: bpm, Valid: true}
+}
+
+func TestCheckMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		set     model.WorkoutExercise
+		valid   bool
+	}{
+		{"weight and reps", model.TrackingWeightReps, model.WorkoutExercise{Reps: 5, Weight: 100}, true},
+		{"no profile is weight and reps", "", model.WorkoutExercise{Reps: 5, Weight: 100}, true},
+		{"unweighted set", model.TrackingWeightReps, model.WorkoutExercise{Reps: 5}, true},
+		{"missing reps", model.TrackingWeightReps, model.WorkoutExercise{Weight: 100}, false},
+		{"negative weight", model.TrackingWeightReps, model.WorkoutExercise{Reps: 5, Weight: -10}, false},
+		{"watch duration is accepted", model.TrackingWeightReps, model.WorkoutExercise{Reps: 5, Weight: 100, Duration: 40}, true},
+		{"negative duration", model.TrackingWeightReps, model.WorkoutExercise{Reps: 5, Weight: 100, Duration: -1}, false},
+		{"distance not tracked", model.TrackingWeightReps, model.WorkoutExercise{Reps: 5, Weight: 100, Distance: 10}, false},
+		{"splits not tracked", model.TrackingWeightReps, model.WorkoutExercise{Reps: 5, Weight: 100, Splits: []*model.Split{{}}}, false},
+
+		{"reps only", model.TrackingReps, model.WorkoutExercise{Reps: 12}, true},
+		{"weight on reps only", model.TrackingReps, model.WorkoutExercise{Reps: 12, Weight: 10}, false},
+
+		{"time", model.TrackingTime, model.WorkoutExercise{Duration: 60}, true},
+		{"time without duration", model.TrackingTime, model.WorkoutExercise{}, false},
+		{"reps on time", model.TrackingTime, model.WorkoutExercise{Reps: 1, Duration: 60}, false},
+
+		{"weighted time", model.TrackingWeightTime, model.WorkoutExercise{Weight: 20, Duration: 60}, true},
+		{"weighted time without duration", model.TrackingWeightTime, model.WorkoutExercise{Weight: 20}, false},
+
+		{"distance and time", model.TrackingDistanceTime, model.WorkoutExercise{Distance: 5000, Duration: 1500}, true},
+		{"distance only", model.TrackingDistanceTime, model.WorkoutExercise{Distance: 5000}, true},
+		{"duration only", model.TrackingDistanceTime, model.WorkoutExercise{Duration: 1800}, true},
+		{"neither distance nor duration", model.TrackingDistanceTime, model.WorkoutExercise{}, false},
+		{"negative distance", model.TrackingDistanceTime, model.WorkoutExercise{Distance: -5, Duration: 1800}, false},
+		{"reps on distance", model.TrackingDistanceTime, model.WorkoutExercise{Reps: 1, Distance: 5000}, false},
+
+		{"assistance", model.TrackingAssisted, model.WorkoutExercise{Reps: 8, Weight: -20}, true},
+		{"no assistance", model.TrackingAssisted, model.WorkoutExercise{Reps: 8}, true},
+		{"positive assistance", model.TrackingAssisted, model.WorkoutExercise{Reps: 8, Weight: 20}, false},
+
+		{"heart rates", model.TrackingDistanceTime, model.WorkoutExercise{Duration: 1800, AvgHeartRate: heartRate(150), MaxHeartRate: heartRate(180)}, true},
+		{"equal heart rates", model.TrackingDistanceTime, model.WorkoutExercise{Duration: 1800, AvgHeartRate: heartRate(150), MaxHeartRate: heartRate(150)}, true},
+		{"max heart rate below average", model.TrackingDistanceTime, model.WorkoutExercise{Duration: 1800, AvgHeartRate: heartRate(150), MaxHeartRate: heartRate(140)}, false},
+		{"max heart rate alone", model.TrackingDistanceTime, model.WorkoutExercise{Duration: 1800, MaxHeartRate: heartRate(90)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMetrics(tt.profile, &tt.set)
+			if tt.valid && err != nil {
+				t.Errorf("checkMetrics() error = %v, want nil", err)
+			}
+			if !tt.valid && !errors.Is(err, apperrors.ErrInvalidMetrics) {
+				t.Errorf("checkMetrics() error = %v, want %v", err, apperrors.ErrInvalidMetrics)
+			}
+		})
+	}
+}
+
+func TestBetterRecord(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		set     model.UserRecord
+		record  model.UserRecord
+		want    bool
+	}{
+		{"higher estimated max", model.TrackingWeightReps, model.UserRecord{Weight: 90, Reps: 8}, model.UserRecord{Weight: 100, Reps: 3}, true},
+		{"heavier single loses on estimate", "", model.UserRecord{Weight: 102.5, Reps: 1}, model.UserRecord{Weight: 100, Reps: 3}, false},
+		{"equal estimate", model.TrackingWeightReps, model.UserRecord{Weight: 100, Reps: 3}, model.UserRecord{Weight: 100, Reps: 3}, false},
+
+		{"more reps", model.TrackingReps, model.UserRecord{Reps: 21}, model.UserRecord{Reps: 20}, true},
+		{"fewer reps", model.TrackingReps, model.UserRecord{Reps: 19}, model.UserRecord{Reps: 20}, false},
+
+		{"longer hold", model.TrackingTime, model.UserRecord{Duration: 121}, model.UserRecord{Duration: 120}, true},
+		{"shorter hold", model.TrackingTime, model.UserRecord{Duration: 119}, model.UserRecord{Duration: 120}, false},
+
+		{"heavier carry", model.TrackingWeightTime, model.UserRecord{Weight: 40, Duration: 30}, model.UserRecord{Weight: 35, Duration: 60}, true},
+		{"same load for longer", model.TrackingWeightTime, model.UserRecord{Weight: 40, Duration: 61}, model.UserRecord{Weight: 40, Duration: 60}, true},
+		{"lighter carry", model.TrackingWeightTime, model.UserRecord{Weight: 30, Duration: 90}, model.UserRecord{Weight: 35, Duration: 60}, false},
+
+		{"longer distance", model.TrackingDistanceTime, model.UserRecord{Distance: 10000, Duration: 3600}, model.UserRecord{Distance: 5000, Duration: 1200}, true},
+		{"same distance faster", model.TrackingDistanceTime, model.UserRecord{Distance: 5000, Duration: 1190}, model.UserRecord{Distance: 5000, Duration: 1200}, true},
+		{"same distance slower", model.TrackingDistanceTime, model.UserRecord{Distance: 5000, Duration: 1210}, model.UserRecord{Distance: 5000, Duration: 1200}, false},
+		{"timed set beats untimed", model.TrackingDistanceTime, model.UserRecord{Distance: 5000, Duration: 1300}, model.UserRecord{Distance: 5000}, true},
+		{"untimed set never wins on time", model.TrackingDistanceTime, model.UserRecord{Distance: 5000}, model.UserRecord{Distance: 5000, Duration: 1200}, false},
+		{"duration only never wins on time", model.TrackingDistanceTime, model.UserRecord{Duration: 1700}, model.UserRecord{Duration: 1800}, false},
+		{"duration only beats nothing", model.TrackingDistanceTime, model.UserRecord{Duration: 1800}, model.UserRecord{}, false},
+
+		{"less assistance", model.TrackingAssisted, model.UserRecord{Weight: -10, Reps: 5}, model.UserRecord{Weight: -20, Reps: 10}, true},
+		{"same assistance more reps", model.TrackingAssisted, model.UserRecord{Weight: -20, Reps: 11}, model.UserRecord{Weight: -20, Reps: 10}, true},
+		{"more assistance", model.TrackingAssisted, model.UserRecord{Weight: -30, Reps: 15}, model.UserRecord{Weight: -20, Reps: 10}, false},
+		{"unassisted beats assisted", model.TrackingAssisted, model.UserRecord{Reps: 1}, model.UserRecord{Weight: -5, Reps: 12}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := betterRecord(tt.profile, &tt.set, &tt.record); got != tt.want {
+				t.Errorf("betterRecord() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
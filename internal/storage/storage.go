@@ -0,0 +1,15 @@
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// FileStorage keeps uploaded files under keys chosen by the caller. Keys are
+// slash separated relative paths. Opening or deleting a missing key fails
+// with an error matching fs.ErrNotExist.
+type FileStorage interface {
+	Save(ctx context.Context, key string, r io.Reader) (int64, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
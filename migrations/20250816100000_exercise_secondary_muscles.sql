@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS exercise_secondary_muscles (
+    exercise_id INTEGER REFERENCES exercises(id) ON DELETE CASCADE,
+    muscle_group VARCHAR(50) NOT NULL,
+    PRIMARY KEY (exercise_id, muscle_group)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workouts_user_id_date ON workouts(user_id, date);
+
+INSERT INTO exercise_secondary_muscles (exercise_id, muscle_group)
+SELECT e.id, v.muscle_group
+FROM exercises e
+JOIN (VALUES
+    ('Жим лежа', 'Трицепс'),
+    ('Жим лежа', 'Плечи'),
+    ('Приседания со штангой', 'Спина'),
+    ('Становая тяга', 'Ноги'),
+    ('Подтягивания', 'Бицепс'),
+    ('Отжимания', 'Трицепс'),
+    ('Отжимания', 'Плечи'),
+    ('Жим штанги стоя', 'Трицепс'),
+    ('Тяга штанги в наклоне', 'Бицепс'),
+    ('Тяга штанги в наклоне', 'Плечи')
+) AS v(name, muscle_group) ON e.name = v.name
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_workouts_user_id_date;
+DROP TABLE IF EXISTS exercise_secondary_muscles CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE workouts
+    ADD COLUMN IF NOT EXISTS started_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS ended_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS session_rpe DECIMAL(3,1) CHECK (session_rpe BETWEEN 0 AND 10),
+    ADD CONSTRAINT workouts_ended_after_started CHECK (ended_at IS NULL OR started_at IS NULL OR ended_at >= started_at);
+
+ALTER TABLE workout_exercises
+    ADD COLUMN IF NOT EXISTS rpe DECIMAL(3,1) CHECK (rpe BETWEEN 1 AND 10),
+    ADD COLUMN IF NOT EXISTS rir INTEGER CHECK (rir BETWEEN 0 AND 10);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workout_exercises
+    DROP COLUMN IF EXISTS rir,
+    DROP COLUMN IF EXISTS rpe;
+
+ALTER TABLE workouts
+    DROP CONSTRAINT IF EXISTS workouts_ended_after_started,
+    DROP COLUMN IF EXISTS session_rpe,
+    DROP COLUMN IF EXISTS ended_at,
+    DROP COLUMN IF EXISTS started_at;
+-- +goose StatementEnd
@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN IF NOT EXISTS sex VARCHAR(10) CHECK (sex IN ('male', 'female'));
+
+CREATE TABLE IF NOT EXISTS body_weights (
+    id int generated always as identity primary key,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    weight DECIMAL(5,2) NOT NULL CHECK (weight > 0),
+    measured_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamp not null default now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_body_weights_user_id_measured_at ON body_weights(user_id, measured_at);
+
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS lift VARCHAR(20) CHECK (lift IN ('squat', 'bench', 'deadlift', 'ohp'));
+
+UPDATE exercises SET lift = 'squat' WHERE name = 'Приседания со штангой';
+UPDATE exercises SET lift = 'bench' WHERE name = 'Жим лежа';
+UPDATE exercises SET lift = 'deadlift' WHERE name = 'Становая тяга';
+UPDATE exercises SET lift = 'ohp' WHERE name = 'Жим штанги стоя';
+
+-- minimum one-rep max, as a multiple of bodyweight, required for each level
+CREATE TABLE IF NOT EXISTS strength_standards (
+    exercise_id INTEGER REFERENCES exercises(id) ON DELETE CASCADE,
+    sex VARCHAR(10) NOT NULL CHECK (sex IN ('male', 'female')),
+    level VARCHAR(20) NOT NULL,
+    rank INTEGER NOT NULL,
+    bodyweight_multiple DECIMAL(4,2) NOT NULL,
+    PRIMARY KEY (exercise_id, sex, level),
+    UNIQUE (exercise_id, sex, rank)
+);
+
+INSERT INTO strength_standards (exercise_id, sex, level, rank, bodyweight_multiple)
+SELECT e.id, v.sex, v.level, v.rank, v.multiple
+FROM exercises e
+JOIN (VALUES
+    ('squat', 'male', 'beginner', 1, 0.75), ('squat', 'male', 'novice', 2, 1.25), ('squat', 'male', 'intermediate', 3, 1.50), ('squat', 'male', 'advanced', 4, 2.25), ('squat', 'male', 'elite', 5, 2.75),
+    ('bench', 'male', 'beginner', 1, 0.50), ('bench', 'male', 'novice', 2, 0.75), ('bench', 'male', 'intermediate', 3, 1.25), ('bench', 'male', 'advanced', 4, 1.75), ('bench', 'male', 'elite', 5, 2.00),
+    ('deadlift', 'male', 'beginner', 1, 1.00), ('deadlift', 'male', 'novice', 2, 1.50), ('deadlift', 'male', 'intermediate', 3, 2.00), ('deadlift', 'male', 'advanced', 4, 2.50), ('deadlift', 'male', 'elite', 5, 3.00),
+    ('ohp', 'male', 'beginner', 1, 0.40), ('ohp', 'male', 'novice', 2, 0.55), ('ohp', 'male', 'intermediate', 3, 0.80), ('ohp', 'male', 'advanced', 4, 1.05), ('ohp', 'male', 'elite', 5, 1.35),
+    ('squat', 'female', 'beginner', 1, 0.50), ('squat', 'female', 'novice', 2, 0.75), ('squat', 'female', 'intermediate', 3, 1.25), ('squat', 'female', 'advanced', 4, 1.50), ('squat', 'female', 'elite', 5, 2.00),
+    ('bench', 'female', 'beginner', 1, 0.25), ('bench', 'female', 'novice', 2, 0.50), ('bench', 'female', 'intermediate', 3, 0.75), ('bench', 'female', 'advanced', 4, 1.00), ('bench', 'female', 'elite', 5, 1.50),
+    ('deadlift', 'female', 'beginner', 1, 0.50), ('deadlift', 'female', 'novice', 2, 1.00), ('deadlift', 'female', 'intermediate', 3, 1.25), ('deadlift', 'female', 'advanced', 4, 1.75), ('deadlift', 'female', 'elite', 5, 2.50),
+    ('ohp', 'female', 'beginner', 1, 0.20), ('ohp', 'female', 'novice', 2, 0.35), ('ohp', 'female', 'intermediate', 3, 0.50), ('ohp', 'female', 'advanced', 4, 0.75), ('ohp', 'female', 'elite', 5, 1.00)
+) AS v(lift, sex, level, rank, multiple) ON e.lift = v.lift
+ON CONFLICT DO NOTHING;
+
+-- a user's own levels for an exercise, replacing the built-in ones above
+CREATE TABLE IF NOT EXISTS user_strength_standards (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    level VARCHAR(20) NOT NULL,
+    rank INTEGER NOT NULL,
+    bodyweight_multiple DECIMAL(4,2) NOT NULL CHECK (bodyweight_multiple > 0),
+    PRIMARY KEY (user_id, exercise_id, level),
+    UNIQUE (user_id, exercise_id, rank)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS user_strength_standards;
+DROP TABLE IF EXISTS strength_standards CASCADE;
+ALTER TABLE exercises DROP COLUMN IF EXISTS lift;
+DROP INDEX IF EXISTS idx_body_weights_user_id_measured_at;
+DROP TABLE IF EXISTS body_weights CASCADE;
+ALTER TABLE users DROP COLUMN IF EXISTS sex;
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE workout_exercises
+    ADD COLUMN IF NOT EXISTS avg_heart_rate INTEGER CHECK (avg_heart_rate BETWEEN 20 AND 250),
+    ADD COLUMN IF NOT EXISTS max_heart_rate INTEGER CHECK (max_heart_rate BETWEEN 20 AND 250),
+    ADD COLUMN IF NOT EXISTS elevation_gain DECIMAL(7,1); -- в метрах
+
+CREATE TABLE IF NOT EXISTS workout_exercise_splits (
+    id int generated always as identity primary key,
+    workout_exercise_id INTEGER NOT NULL REFERENCES workout_exercises(id) ON DELETE CASCADE,
+    split_number INTEGER NOT NULL,
+    distance DECIMAL(7,3) NOT NULL DEFAULT 0, -- в км
+    duration INTEGER NOT NULL DEFAULT 0, -- в секундах
+    avg_heart_rate INTEGER CHECK (avg_heart_rate BETWEEN 20 AND 250),
+    elevation_gain DECIMAL(7,1),
+    UNIQUE (workout_exercise_id, split_number)
+);
+
+-- metabolic equivalent of task, used to estimate calories
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS met DECIMAL(4,1);
+
+UPDATE exercises SET met = v.met
+FROM (VALUES
+    ('Бег', 9.8),
+    ('Быстрая ходьба', 4.3),
+    ('Велосипед', 7.5),
+    ('Эллиптический тренажер', 5.0),
+    ('Плавание', 6.0),
+    ('Гребля', 7.0),
+    ('Степпер', 9.0),
+    ('Прыжки на скакалке', 11.8),
+    ('HIIT тренировка', 8.0),
+    ('Танцы', 5.0)
+) AS v(name, met)
+WHERE exercises.name = v.name;
+
+UPDATE exercises SET met = 5.0 WHERE type = 'strength' AND met IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE exercises DROP COLUMN IF EXISTS met;
+DROP TABLE IF EXISTS workout_exercise_splits CASCADE;
+ALTER TABLE workout_exercises
+    DROP COLUMN IF EXISTS elevation_gain,
+    DROP COLUMN IF EXISTS max_heart_rate,
+    DROP COLUMN IF EXISTS avg_heart_rate;
+-- +goose StatementEnd
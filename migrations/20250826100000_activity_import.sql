@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+-- maps names used by devices and other apps onto the exercises catalog
+CREATE TABLE IF NOT EXISTS exercise_import_mappings (
+    source VARCHAR(20) NOT NULL,
+    external_name VARCHAR(100) NOT NULL,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    PRIMARY KEY (source, external_name)
+);
+
+INSERT INTO exercise_import_mappings (source, external_name, exercise_id)
+SELECT 'sport', v.sport, e.id
+FROM exercises e
+JOIN (VALUES
+    ('running', 'Бег'),
+    ('cycling', 'Велосипед'),
+    ('walking', 'Быстрая ходьба'),
+    ('swimming', 'Плавание'),
+    ('rowing', 'Гребля')
+) AS v(sport, name) ON e.name = v.name
+ON CONFLICT DO NOTHING;
+
+-- raw uploaded files, kept so activities can be re-processed later
+CREATE TABLE IF NOT EXISTS workout_tracks (
+    id int generated always as identity primary key,
+    workout_id INTEGER NOT NULL REFERENCES workouts(id) ON DELETE CASCADE,
+    workout_exercise_id INTEGER REFERENCES workout_exercises(id) ON DELETE CASCADE,
+    format VARCHAR(10) NOT NULL,
+    raw BYTEA NOT NULL,
+    created_at timestamp not null default now()
+);
+
+CREATE TABLE IF NOT EXISTS workout_exercise_samples (
+    workout_exercise_id INTEGER NOT NULL REFERENCES workout_exercises(id) ON DELETE CASCADE,
+    recorded_at TIMESTAMP NOT NULL,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    elevation DECIMAL(7,1),
+    heart_rate INTEGER,
+    distance DECIMAL(8,3) NOT NULL DEFAULT 0 -- в км от начала
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_tracks_workout_id ON workout_tracks(workout_id);
+CREATE INDEX IF NOT EXISTS idx_workout_exercise_samples_workout_exercise_id ON workout_exercise_samples(workout_exercise_id, recorded_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_workout_exercise_samples_workout_exercise_id;
+DROP INDEX IF EXISTS idx_workout_tracks_workout_id;
+DROP TABLE IF EXISTS workout_exercise_samples CASCADE;
+DROP TABLE IF EXISTS workout_tracks CASCADE;
+DROP TABLE IF EXISTS exercise_import_mappings CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- FIT strength sets reference an exercise category and an optional subtype;
+-- mappings are keyed by "category" or "category:subtype"
+INSERT INTO exercise_import_mappings (source, external_name, exercise_id)
+SELECT 'fit_category', v.category, e.id
+FROM exercises e
+JOIN (VALUES
+    ('bench_press', 'Жим лежа'),
+    ('squat', 'Приседания со штангой'),
+    ('deadlift', 'Становая тяга'),
+    ('pull_up', 'Подтягивания'),
+    ('push_up', 'Отжимания'),
+    ('shoulder_press', 'Жим штанги стоя'),
+    ('row', 'Тяга штанги в наклоне'),
+    ('curl', 'Сгибание рук со штангой'),
+    ('triceps_extension', 'Французский жим'),
+    ('calf_raise', 'Подъемы на носки'),
+    ('plank', 'Планка'),
+    ('crunch', 'Скручивания'),
+    ('sit_up', 'Скручивания')
+) AS v(category, name) ON e.name = v.name
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM exercise_import_mappings WHERE source = 'fit_category';
+-- +goose StatementEnd
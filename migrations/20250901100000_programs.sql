@@ -0,0 +1,78 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS programs (
+    id int generated always as identity primary key,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE, -- NULL для общих программ
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    weeks INTEGER NOT NULL CHECK (weeks BETWEEN 1 AND 52),
+    created_at timestamp not null default now(),
+    updated_at timestamp
+);
+
+CREATE TABLE IF NOT EXISTS program_days (
+    id int generated always as identity primary key,
+    program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    week INTEGER NOT NULL CHECK (week >= 1),
+    day INTEGER NOT NULL CHECK (day BETWEEN 1 AND 7), -- день недели программы, считая от даты старта
+    name VARCHAR(100) NOT NULL DEFAULT '',
+    UNIQUE (program_id, week, day)
+);
+
+CREATE TABLE IF NOT EXISTS program_exercises (
+    id int generated always as identity primary key,
+    program_day_id INTEGER NOT NULL REFERENCES program_days(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id),
+    position INTEGER NOT NULL DEFAULT 0,
+    sets INTEGER NOT NULL DEFAULT 1,
+    reps INTEGER NOT NULL DEFAULT 0,
+    prescription_type VARCHAR(20) NOT NULL CHECK (prescription_type IN ('percent_tm', 'rpe', 'fixed')),
+    prescription_value DECIMAL(6,2) NOT NULL DEFAULT 0, -- % от тренировочного максимума, RPE или вес в кг
+    notes TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS program_enrollments (
+    id int generated always as identity primary key,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    program_id INTEGER NOT NULL REFERENCES programs(id) ON DELETE CASCADE,
+    start_date DATE NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at timestamp not null default now()
+);
+
+CREATE TABLE IF NOT EXISTS enrollment_training_maxes (
+    enrollment_id INTEGER NOT NULL REFERENCES program_enrollments(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id),
+    training_max DECIMAL(6,2) NOT NULL CHECK (training_max > 0),
+    PRIMARY KEY (enrollment_id, exercise_id)
+);
+
+-- completed workouts linked to the planned session they fulfil
+CREATE TABLE IF NOT EXISTS enrollment_sessions (
+    enrollment_id INTEGER NOT NULL REFERENCES program_enrollments(id) ON DELETE CASCADE,
+    planned_date DATE NOT NULL,
+    workout_id INTEGER NOT NULL REFERENCES workouts(id) ON DELETE CASCADE,
+    PRIMARY KEY (enrollment_id, planned_date),
+    UNIQUE (workout_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_programs_user_id ON programs(user_id);
+CREATE INDEX IF NOT EXISTS idx_program_days_program_id ON program_days(program_id);
+CREATE INDEX IF NOT EXISTS idx_program_exercises_program_day_id ON program_exercises(program_day_id);
+CREATE INDEX IF NOT EXISTS idx_program_enrollments_user_id ON program_enrollments(user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_program_enrollments_user_id;
+DROP INDEX IF EXISTS idx_program_exercises_program_day_id;
+DROP INDEX IF EXISTS idx_program_days_program_id;
+DROP INDEX IF EXISTS idx_programs_user_id;
+
+DROP TABLE IF EXISTS enrollment_sessions CASCADE;
+DROP TABLE IF EXISTS enrollment_training_maxes CASCADE;
+DROP TABLE IF EXISTS program_enrollments CASCADE;
+DROP TABLE IF EXISTS program_exercises CASCADE;
+DROP TABLE IF EXISTS program_days CASCADE;
+DROP TABLE IF EXISTS programs CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- последний подход "на максимум повторений" (5/3/1, GZCLP)
+ALTER TABLE program_exercises ADD COLUMN IF NOT EXISTS amrap BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE programs ADD COLUMN IF NOT EXISTS generator VARCHAR(30);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE programs DROP COLUMN IF EXISTS generator;
+ALTER TABLE program_exercises DROP COLUMN IF EXISTS amrap;
+-- +goose StatementEnd
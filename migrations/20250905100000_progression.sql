@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- шаг прибавки веса: 2.5 кг для верха тела, 5 кг для ног
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS load_increment DECIMAL(4,2) NOT NULL DEFAULT 2.5 CHECK (load_increment > 0);
+UPDATE exercises SET load_increment = 5 WHERE muscle_group IN ('Ноги', 'Голени') OR lift = 'deadlift';
+
+-- целевое число повторений, чтобы отличать выполненный план от недобора
+ALTER TABLE workout_exercises ADD COLUMN IF NOT EXISTS target_reps INTEGER CHECK (target_reps > 0);
+
+CREATE TABLE IF NOT EXISTS workout_templates (
+    id int generated always as identity primary key,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    notes TEXT NOT NULL DEFAULT '',
+    created_at timestamp not null default now()
+);
+
+CREATE TABLE IF NOT EXISTS workout_template_exercises (
+    id int generated always as identity primary key,
+    template_id INTEGER NOT NULL REFERENCES workout_templates(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id),
+    position INTEGER NOT NULL DEFAULT 0,
+    sets INTEGER NOT NULL DEFAULT 1,
+    reps INTEGER NOT NULL DEFAULT 0,
+    weight DECIMAL(5,2), -- NULL: подобрать по истории
+    notes TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_templates_user_id ON workout_templates(user_id);
+CREATE INDEX IF NOT EXISTS idx_workout_template_exercises_template_id ON workout_template_exercises(template_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_workout_template_exercises_template_id;
+DROP INDEX IF EXISTS idx_workout_templates_user_id;
+
+DROP TABLE IF EXISTS workout_template_exercises CASCADE;
+DROP TABLE IF EXISTS workout_templates CASCADE;
+
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS target_reps;
+ALTER TABLE exercises DROP COLUMN IF EXISTS load_increment;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS plate_inventories (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    unit VARCHAR(2) NOT NULL DEFAULT 'kg' CHECK (unit IN ('kg', 'lb')),
+    bar_weight DECIMAL(6,2) NOT NULL CHECK (bar_weight >= 0)
+);
+
+-- count: число блинов (не пар)
+CREATE TABLE IF NOT EXISTS plate_inventory_items (
+    user_id INTEGER NOT NULL REFERENCES plate_inventories(user_id) ON DELETE CASCADE,
+    weight DECIMAL(6,2) NOT NULL CHECK (weight > 0),
+    count INTEGER NOT NULL CHECK (count > 0),
+    PRIMARY KEY (user_id, weight)
+);
+
+-- разминочные подходы не учитываются в рекордах и объеме
+ALTER TABLE workout_exercises ADD COLUMN IF NOT EXISTS is_warmup BOOLEAN NOT NULL DEFAULT FALSE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS is_warmup;
+
+DROP TABLE IF EXISTS plate_inventory_items CASCADE;
+DROP TABLE IF EXISTS plate_inventories CASCADE;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE workout_exercises ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+-- номер группы внутри тренировки: упражнения с одним group_id выполняются по кругу
+ALTER TABLE workout_exercises ADD COLUMN IF NOT EXISTS group_id INTEGER CHECK (group_id > 0);
+
+UPDATE workout_exercises we SET position = o.rn
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY workout_id ORDER BY created_at, id) AS rn FROM workout_exercises) o
+WHERE o.id = we.id;
+
+-- тип группы; без записи определяется по числу упражнений
+CREATE TABLE IF NOT EXISTS workout_exercise_groups (
+    workout_id INTEGER NOT NULL REFERENCES workouts(id) ON DELETE CASCADE,
+    group_id INTEGER NOT NULL CHECK (group_id > 0),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('superset', 'giant_set', 'circuit')),
+    PRIMARY KEY (workout_id, group_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_exercises_workout_position ON workout_exercises(workout_id, position);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_workout_exercises_workout_position;
+DROP TABLE IF EXISTS workout_exercise_groups CASCADE;
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS group_id;
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS position;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- подходы, записанные по ходу тренировки: время выполнения и отдых перед ним
+ALTER TABLE workout_exercises ADD COLUMN IF NOT EXISTS completed_at timestamp;
+ALTER TABLE workout_exercises ADD COLUMN IF NOT EXISTS rest_seconds INTEGER CHECK (rest_seconds >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS rest_seconds;
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS completed_at;
+-- +goose StatementEnd
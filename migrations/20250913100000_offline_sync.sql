@@ -0,0 +1,115 @@
+-- +goose Up
+-- +goose StatementBegin
+-- глобальная последовательность изменений; курсор синхронизации — её значение
+CREATE SEQUENCE IF NOT EXISTS sync_seq;
+
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS uuid UUID NOT NULL DEFAULT gen_random_uuid();
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS client_updated_at timestamp NOT NULL DEFAULT now();
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS sync_seq BIGINT NOT NULL DEFAULT nextval('sync_seq');
+-- UUID создаёт клиент, поэтому он уникален только среди тренировок пользователя
+CREATE UNIQUE INDEX IF NOT EXISTS workouts_uuid_idx ON workouts(user_id, uuid);
+CREATE INDEX IF NOT EXISTS workouts_user_sync_seq_idx ON workouts(user_id, sync_seq);
+
+ALTER TABLE workout_exercises ADD COLUMN IF NOT EXISTS uuid UUID NOT NULL DEFAULT gen_random_uuid();
+ALTER TABLE workout_exercises ADD COLUMN IF NOT EXISTS client_updated_at timestamp NOT NULL DEFAULT now();
+ALTER TABLE workout_exercises ADD COLUMN IF NOT EXISTS sync_seq BIGINT NOT NULL DEFAULT nextval('sync_seq');
+-- подходы своей таблицы пользователя не имеют, поэтому UUID уникален в пределах
+-- тренировки; уникальность среди подходов пользователя проверяет синхронизация
+CREATE UNIQUE INDEX IF NOT EXISTS workout_exercises_uuid_idx ON workout_exercises(workout_id, uuid);
+CREATE INDEX IF NOT EXISTS workout_exercises_sync_seq_idx ON workout_exercises(sync_seq);
+
+-- удалённые записи, чтобы клиенты узнали об удалении
+CREATE TABLE IF NOT EXISTS sync_tombstones (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    entity VARCHAR(20) NOT NULL CHECK (entity IN ('workout', 'workout_exercise')),
+    uuid UUID NOT NULL,
+    deleted_at timestamp NOT NULL DEFAULT now(),
+    sync_seq BIGINT NOT NULL DEFAULT nextval('sync_seq'),
+    PRIMARY KEY (user_id, entity, uuid)
+);
+CREATE INDEX IF NOT EXISTS sync_tombstones_user_sync_seq_idx ON sync_tombstones(user_id, sync_seq);
+
+-- Каждое изменение получает новый номер. Блокировка по пользователю держится
+-- до конца транзакции, поэтому номера изменений одного пользователя
+-- становятся видимыми по порядку и курсор ничего не пропускает.
+-- Если клиент не передал своё время изменения, берётся серверное.
+CREATE OR REPLACE FUNCTION workouts_sync_change() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(NEW.user_id);
+    NEW.sync_seq := nextval('sync_seq');
+    IF TG_OP = 'UPDATE' AND NEW.client_updated_at IS NOT DISTINCT FROM OLD.client_updated_at THEN
+        NEW.client_updated_at := now();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION workout_exercises_sync_change() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(w.user_id) FROM workouts w WHERE w.id = NEW.workout_id;
+    NEW.sync_seq := nextval('sync_seq');
+    IF TG_OP = 'UPDATE' AND NEW.client_updated_at IS NOT DISTINCT FROM OLD.client_updated_at THEN
+        NEW.client_updated_at := now();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- при удалении тренировки её упражнения удаляются каскадом, когда строки
+-- тренировки уже нет, поэтому их отметки ставятся заранее
+CREATE OR REPLACE FUNCTION workouts_sync_delete() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(OLD.user_id);
+    INSERT INTO sync_tombstones (user_id, entity, uuid)
+    SELECT OLD.user_id, 'workout_exercise', we.uuid FROM workout_exercises we WHERE we.workout_id = OLD.id
+    ON CONFLICT DO NOTHING;
+    INSERT INTO sync_tombstones (user_id, entity, uuid)
+    VALUES (OLD.user_id, 'workout', OLD.uuid)
+    ON CONFLICT DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION workout_exercises_sync_delete() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_advisory_xact_lock(w.user_id) FROM workouts w WHERE w.id = OLD.workout_id;
+    INSERT INTO sync_tombstones (user_id, entity, uuid)
+    SELECT w.user_id, 'workout_exercise', OLD.uuid FROM workouts w WHERE w.id = OLD.workout_id
+    ON CONFLICT DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER workouts_sync_change BEFORE INSERT OR UPDATE ON workouts
+    FOR EACH ROW EXECUTE FUNCTION workouts_sync_change();
+CREATE TRIGGER workout_exercises_sync_change BEFORE INSERT OR UPDATE ON workout_exercises
+    FOR EACH ROW EXECUTE FUNCTION workout_exercises_sync_change();
+CREATE TRIGGER workouts_sync_delete BEFORE DELETE ON workouts
+    FOR EACH ROW EXECUTE FUNCTION workouts_sync_delete();
+CREATE TRIGGER workout_exercises_sync_delete BEFORE DELETE ON workout_exercises
+    FOR EACH ROW EXECUTE FUNCTION workout_exercises_sync_delete();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS workout_exercises_sync_delete ON workout_exercises;
+DROP TRIGGER IF EXISTS workouts_sync_delete ON workouts;
+DROP TRIGGER IF EXISTS workout_exercises_sync_change ON workout_exercises;
+DROP TRIGGER IF EXISTS workouts_sync_change ON workouts;
+DROP FUNCTION IF EXISTS workout_exercises_sync_delete();
+DROP FUNCTION IF EXISTS workouts_sync_delete();
+DROP FUNCTION IF EXISTS workout_exercises_sync_change();
+DROP FUNCTION IF EXISTS workouts_sync_change();
+DROP TABLE IF EXISTS sync_tombstones;
+DROP INDEX IF EXISTS workout_exercises_sync_seq_idx;
+DROP INDEX IF EXISTS workout_exercises_uuid_idx;
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS sync_seq;
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS client_updated_at;
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS uuid;
+DROP INDEX IF EXISTS workouts_user_sync_seq_idx;
+DROP INDEX IF EXISTS workouts_uuid_idx;
+ALTER TABLE workouts DROP COLUMN IF EXISTS sync_seq;
+ALTER TABLE workouts DROP COLUMN IF EXISTS client_updated_at;
+ALTER TABLE workouts DROP COLUMN IF EXISTS uuid;
+DROP SEQUENCE IF EXISTS sync_seq;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- постраничный вывод тренировок по (date, id) от новых к старым
+CREATE INDEX IF NOT EXISTS idx_workouts_user_date_id ON workouts(user_id, date DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_exercises_name_id ON exercises(name, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_exercises_name_id;
+DROP INDEX IF EXISTS idx_workouts_user_date_id;
+-- +goose StatementEnd
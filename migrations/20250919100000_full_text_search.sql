@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- полнотекстовый поиск: каталог на русском, поэтому каждый текст индексируется
+-- в русской и английской конфигурациях; вес A у названий, B у заметок, C у описаний
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(name, '')) || to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(notes, '')) || to_tsvector('english', coalesce(notes, '')), 'B')
+) STORED;
+
+ALTER TABLE workout_exercises ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(notes, '')) || to_tsvector('english', coalesce(notes, '')), 'B')
+) STORED;
+
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce(name, '')) || to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce(description, '')) || to_tsvector('english', coalesce(description, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_workouts_search ON workouts USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_workout_exercises_search ON workout_exercises USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_exercises_search ON exercises USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_exercises_search;
+DROP INDEX IF EXISTS idx_workout_exercises_search;
+DROP INDEX IF EXISTS idx_workouts_search;
+ALTER TABLE exercises DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE workouts DROP COLUMN IF EXISTS search_vector;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- пользовательские метки тренировок («разгрузка», «поездка», ...);
+-- имя уникально у пользователя без учёта регистра
+CREATE TABLE IF NOT EXISTS tags (
+    id int generated always as identity primary key,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7),
+    created_at timestamp not null default now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_name ON tags(user_id, lower(name));
+
+CREATE TABLE IF NOT EXISTS workout_tags (
+    workout_id INTEGER NOT NULL REFERENCES workouts(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (workout_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_tags_tag_id ON workout_tags(tag_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS workout_tags;
+DROP TABLE IF EXISTS tags;
+-- +goose StatementEnd
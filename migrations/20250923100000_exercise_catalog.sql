@@ -0,0 +1,193 @@
+-- +goose Up
+-- +goose StatementBegin
+-- справочники оборудования и мышц; exercises.muscle_group остаётся крупной
+-- группой для аналитики, а muscles.muscle_group связывает мышцу с ней
+CREATE TABLE IF NOT EXISTS equipment (
+    id int generated always as identity primary key,
+    code VARCHAR(30) NOT NULL UNIQUE,
+    name VARCHAR(50) NOT NULL
+);
+
+INSERT INTO equipment (code, name) VALUES
+    ('barbell', 'Штанга'),
+    ('dumbbell', 'Гантели'),
+    ('machine', 'Тренажёр'),
+    ('bodyweight', 'Собственный вес'),
+    ('cable', 'Блок')
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS muscles (
+    id int generated always as identity primary key,
+    code VARCHAR(30) NOT NULL UNIQUE,
+    name VARCHAR(50) NOT NULL,
+    muscle_group VARCHAR(50) NOT NULL
+);
+
+INSERT INTO muscles (code, name, muscle_group) VALUES
+    ('chest', 'Грудные', 'Грудь'),
+    ('front_delts', 'Передние дельты', 'Плечи'),
+    ('side_delts', 'Средние дельты', 'Плечи'),
+    ('rear_delts', 'Задние дельты', 'Плечи'),
+    ('triceps', 'Трицепс', 'Трицепс'),
+    ('biceps', 'Бицепс', 'Бицепс'),
+    ('lats', 'Широчайшие', 'Спина'),
+    ('upper_back', 'Трапеции и ромбовидные', 'Спина'),
+    ('lower_back', 'Разгибатели спины', 'Спина'),
+    ('quads', 'Квадрицепсы', 'Ноги'),
+    ('hamstrings', 'Бицепс бедра', 'Ноги'),
+    ('glutes', 'Ягодичные', 'Ноги'),
+    ('calves', 'Икроножные', 'Голени'),
+    ('abs', 'Пресс', 'Пресс')
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS exercise_muscles (
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    muscle_id INTEGER NOT NULL REFERENCES muscles(id),
+    is_primary BOOLEAN NOT NULL,
+    PRIMARY KEY (exercise_id, muscle_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_muscles_muscle_id ON exercise_muscles(muscle_id);
+
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS equipment_id INTEGER REFERENCES equipment(id);
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS movement_pattern VARCHAR(30) CHECK (movement_pattern IN (
+    'squat', 'hinge', 'lunge', 'horizontal_push', 'vertical_push', 'horizontal_pull', 'vertical_pull',
+    'elbow_flexion', 'elbow_extension', 'plantar_flexion', 'core', 'carry', 'locomotion'
+));
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS mechanics VARCHAR(10) CHECK (mechanics IN ('compound', 'isolation'));
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS unilateral BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE exercises e
+SET equipment_id = eq.id, movement_pattern = v.pattern, mechanics = v.mechanics
+FROM (VALUES
+    ('Жим лежа', 'barbell', 'horizontal_push', 'compound'),
+    ('Приседания со штангой', 'barbell', 'squat', 'compound'),
+    ('Становая тяга', 'barbell', 'hinge', 'compound'),
+    ('Подтягивания', 'bodyweight', 'vertical_pull', 'compound'),
+    ('Отжимания', 'bodyweight', 'horizontal_push', 'compound'),
+    ('Жим штанги стоя', 'barbell', 'vertical_push', 'compound'),
+    ('Тяга штанги в наклоне', 'barbell', 'horizontal_pull', 'compound'),
+    ('Сгибание рук со штангой', 'barbell', 'elbow_flexion', 'isolation'),
+    ('Французский жим', 'barbell', 'elbow_extension', 'isolation'),
+    ('Подъемы на носки', 'bodyweight', 'plantar_flexion', 'isolation'),
+    ('Планка', 'bodyweight', 'core', NULL),
+    ('Скручивания', 'bodyweight', 'core', 'isolation'),
+    ('Бег', 'bodyweight', 'locomotion', NULL),
+    ('Быстрая ходьба', 'bodyweight', 'locomotion', NULL),
+    ('Велосипед', 'machine', 'locomotion', NULL),
+    ('Эллиптический тренажер', 'machine', 'locomotion', NULL),
+    ('Плавание', 'bodyweight', 'locomotion', NULL),
+    ('Гребля', 'machine', 'locomotion', NULL),
+    ('Степпер', 'machine', 'locomotion', NULL),
+    ('Прыжки на скакалке', 'bodyweight', 'locomotion', NULL),
+    ('HIIT тренировка', 'bodyweight', NULL, NULL),
+    ('Танцы', 'bodyweight', 'locomotion', NULL)
+) AS v(name, equipment, pattern, mechanics)
+JOIN equipment eq ON eq.code = v.equipment
+WHERE e.name = v.name;
+
+-- мышцы встроенных упражнений с разделением групп на отдельные мышцы;
+-- вторичная мышца чужой группы остаётся, только если группа записана в
+-- exercise_secondary_muscles
+INSERT INTO exercise_muscles (exercise_id, muscle_id, is_primary)
+SELECT e.id, m.id, v.is_primary
+FROM (VALUES
+    ('Жим лежа', 'chest', TRUE),
+    ('Жим лежа', 'triceps', FALSE),
+    ('Жим лежа', 'front_delts', FALSE),
+    ('Приседания со штангой', 'quads', TRUE),
+    ('Приседания со штангой', 'glutes', TRUE),
+    ('Приседания со штангой', 'hamstrings', FALSE),
+    ('Приседания со штангой', 'lower_back', FALSE),
+    ('Становая тяга', 'lower_back', TRUE),
+    ('Становая тяга', 'glutes', FALSE),
+    ('Становая тяга', 'hamstrings', FALSE),
+    ('Становая тяга', 'upper_back', FALSE),
+    ('Подтягивания', 'lats', TRUE),
+    ('Подтягивания', 'biceps', FALSE),
+    ('Подтягивания', 'upper_back', FALSE),
+    ('Отжимания', 'chest', TRUE),
+    ('Отжимания', 'triceps', FALSE),
+    ('Отжимания', 'front_delts', FALSE),
+    ('Жим штанги стоя', 'front_delts', TRUE),
+    ('Жим штанги стоя', 'triceps', FALSE),
+    ('Жим штанги стоя', 'side_delts', FALSE),
+    ('Тяга штанги в наклоне', 'lats', TRUE),
+    ('Тяга штанги в наклоне', 'upper_back', TRUE),
+    ('Тяга штанги в наклоне', 'biceps', FALSE),
+    ('Тяга штанги в наклоне', 'rear_delts', FALSE),
+    ('Сгибание рук со штангой', 'biceps', TRUE),
+    ('Французский жим', 'triceps', TRUE),
+    ('Подъемы на носки', 'calves', TRUE),
+    ('Планка', 'abs', TRUE),
+    ('Скручивания', 'abs', TRUE)
+) AS v(name, muscle, is_primary)
+JOIN exercises e ON e.name = v.name
+JOIN muscles m ON m.code = v.muscle
+WHERE v.is_primary
+    OR m.muscle_group IS NOT DISTINCT FROM e.muscle_group
+    OR EXISTS (
+        SELECT 1 FROM exercise_secondary_muscles sm
+        WHERE sm.exercise_id = e.id AND sm.muscle_group = m.muscle_group
+    )
+ON CONFLICT DO NOTHING;
+
+-- вторичные группы из exercise_secondary_muscles, которые не покрыты
+-- мышцами выше, переезжают всеми мышцами группы
+INSERT INTO exercise_muscles (exercise_id, muscle_id, is_primary)
+SELECT sm.exercise_id, m.id, FALSE
+FROM exercise_secondary_muscles sm
+JOIN muscles m ON m.muscle_group = sm.muscle_group
+WHERE NOT EXISTS (
+    SELECT 1 FROM exercise_muscles em
+    JOIN muscles mm ON mm.id = em.muscle_id
+    WHERE em.exercise_id = sm.exercise_id AND mm.muscle_group = sm.muscle_group
+)
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS exercise_secondary_muscles;
+
+-- администраторы ведут общий каталог упражнений: загружают медиа
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS exercise_media (
+    id int generated always as identity primary key,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('image', 'video')),
+    storage_key VARCHAR(255) NOT NULL UNIQUE,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    uploaded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp not null default now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_media_exercise_id ON exercise_media(exercise_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS exercise_media;
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
+
+CREATE TABLE IF NOT EXISTS exercise_secondary_muscles (
+    exercise_id INTEGER REFERENCES exercises(id) ON DELETE CASCADE,
+    muscle_group VARCHAR(50) NOT NULL,
+    PRIMARY KEY (exercise_id, muscle_group)
+);
+
+INSERT INTO exercise_secondary_muscles (exercise_id, muscle_group)
+SELECT DISTINCT em.exercise_id, m.muscle_group
+FROM exercise_muscles em
+JOIN muscles m ON m.id = em.muscle_id
+JOIN exercises e ON e.id = em.exercise_id
+WHERE NOT em.is_primary AND m.muscle_group IS DISTINCT FROM e.muscle_group
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE exercises DROP COLUMN IF EXISTS unilateral;
+ALTER TABLE exercises DROP COLUMN IF EXISTS mechanics;
+ALTER TABLE exercises DROP COLUMN IF EXISTS movement_pattern;
+ALTER TABLE exercises DROP COLUMN IF EXISTS equipment_id;
+DROP TABLE IF EXISTS exercise_muscles;
+DROP TABLE IF EXISTS muscles;
+DROP TABLE IF EXISTS equipment;
+-- +goose StatementEnd
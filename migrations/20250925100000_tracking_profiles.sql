@@ -0,0 +1,83 @@
+-- +goose Up
+-- +goose StatementBegin
+-- профиль учёта задаёт, какими показателями записывается подход упражнения:
+-- вес и повторы, только повторы, только время, вес и время, дистанция и время
+-- или повторы с помощью (вес хранится отрицательным, это разгрузка);
+-- при дистанции и времени дистанцию можно не указывать, если записано время:
+-- велотренажёр и беговая дорожка без дисплея дистанции
+ALTER TABLE exercises DROP CONSTRAINT IF EXISTS exercises_type_check;
+ALTER TABLE exercises ADD CONSTRAINT exercises_type_check CHECK (type IN ('strength', 'cardio', 'flexibility'));
+
+ALTER TABLE exercises ADD COLUMN IF NOT EXISTS tracking_profile VARCHAR(20) NOT NULL DEFAULT 'weight_reps' CHECK (tracking_profile IN (
+    'weight_reps', 'reps', 'time', 'weight_time', 'distance_time', 'assisted'
+));
+
+UPDATE exercises SET tracking_profile = v.profile
+FROM (VALUES
+    ('Подтягивания', 'reps'),
+    ('Отжимания', 'reps'),
+    ('Скручивания', 'reps'),
+    ('Планка', 'time'),
+    ('Бег', 'distance_time'),
+    ('Быстрая ходьба', 'distance_time'),
+    ('Велосипед', 'distance_time'),
+    ('Плавание', 'distance_time'),
+    ('Гребля', 'distance_time'),
+    ('Эллиптический тренажер', 'time'),
+    ('Степпер', 'time'),
+    ('Прыжки на скакалке', 'time'),
+    ('HIIT тренировка', 'time'),
+    ('Танцы', 'time')
+) AS v(name, profile)
+WHERE exercises.name = v.name;
+
+INSERT INTO exercises (name, type, muscle_group, description, met, tracking_profile, equipment_id, movement_pattern, mechanics)
+SELECT v.name, v.type, v.muscle_group, v.description, v.met, v.profile, eq.id, v.pattern, v.mechanics
+FROM (VALUES
+    ('Подтягивания в гравитроне', 'strength', 'Спина', 'Подтягивания с противовесом для тех, кто пока не подтягивается сам', 5.0, 'assisted', 'machine', 'vertical_pull', 'compound'),
+    ('Фермерская прогулка', 'strength', 'Спина', 'Ходьба с тяжёлыми гантелями в руках для хвата, трапеций и кора', 6.0, 'weight_time', 'dumbbell', 'carry', 'compound'),
+    ('Растяжка задней поверхности бедра', 'flexibility', 'Мобильность', 'Статическая растяжка бицепса бедра', 2.3, 'time', 'bodyweight', NULL, NULL),
+    ('Мобилизация плечевого сустава', 'flexibility', 'Мобильность', 'Упражнения на подвижность плеч перед жимами', 2.3, 'time', 'bodyweight', NULL, NULL)
+) AS v(name, type, muscle_group, description, met, profile, equipment, pattern, mechanics)
+JOIN equipment eq ON eq.code = v.equipment
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO exercise_muscles (exercise_id, muscle_id, is_primary)
+SELECT e.id, m.id, v.is_primary
+FROM (VALUES
+    ('Подтягивания в гравитроне', 'lats', TRUE),
+    ('Подтягивания в гравитроне', 'biceps', FALSE),
+    ('Подтягивания в гравитроне', 'upper_back', FALSE),
+    ('Фермерская прогулка', 'upper_back', TRUE),
+    ('Фермерская прогулка', 'abs', FALSE),
+    ('Фермерская прогулка', 'glutes', FALSE),
+    ('Растяжка задней поверхности бедра', 'hamstrings', TRUE),
+    ('Мобилизация плечевого сустава', 'front_delts', TRUE),
+    ('Мобилизация плечевого сустава', 'rear_delts', TRUE)
+) AS v(name, muscle, is_primary)
+JOIN exercises e ON e.name = v.name
+JOIN muscles m ON m.code = v.muscle
+ON CONFLICT DO NOTHING;
+
+-- рекорд хранит показатели, по которым его сравнивают для профиля
+ALTER TABLE personal_records ADD COLUMN IF NOT EXISTS duration INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE personal_records ADD COLUMN IF NOT EXISTS distance DECIMAL(6,2) NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE personal_records DROP COLUMN IF EXISTS distance;
+ALTER TABLE personal_records DROP COLUMN IF EXISTS duration;
+
+DELETE FROM exercises WHERE name IN (
+    'Подтягивания в гравитроне',
+    'Фермерская прогулка',
+    'Растяжка задней поверхности бедра',
+    'Мобилизация плечевого сустава'
+);
+
+ALTER TABLE exercises DROP COLUMN IF EXISTS tracking_profile;
+
+ALTER TABLE exercises DROP CONSTRAINT IF EXISTS exercises_type_check;
+ALTER TABLE exercises ADD CONSTRAINT exercises_type_check CHECK (type IN ('strength', 'cardio'));
+-- +goose StatementEnd
@@ -0,0 +1,65 @@
+-- +goose Up
+-- +goose StatementBegin
+-- переводы названий и описаний упражнений; exercises.name остаётся
+-- каноническим русским названием и используется, когда перевода нет
+CREATE TABLE IF NOT EXISTS exercise_translations (
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL CHECK (locale IN ('en', 'ru')),
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', coalesce(name, '')) || to_tsvector('english', coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(description, '')) || to_tsvector('english', coalesce(description, '')), 'C')
+    ) STORED,
+    PRIMARY KEY (exercise_id, locale)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exercise_translations_search ON exercise_translations USING GIN (search_vector);
+
+INSERT INTO exercise_translations (exercise_id, locale, name, description)
+SELECT id, 'ru', name, description FROM exercises
+ON CONFLICT DO NOTHING;
+
+INSERT INTO exercise_translations (exercise_id, locale, name, description)
+SELECT e.id, 'en', v.en_name, v.en_description
+FROM (VALUES
+    ('Жим лежа', 'Bench Press', 'Compound lift for the chest, front delts and triceps'),
+    ('Приседания со штангой', 'Barbell Back Squat', 'Compound lift for the quads, glutes and hamstrings'),
+    ('Становая тяга', 'Deadlift', 'Compound lift for the back and legs that strengthens the whole body'),
+    ('Подтягивания', 'Pull-up', 'Bodyweight pull for the lats and biceps'),
+    ('Отжимания', 'Push-up', 'Bodyweight push for the chest, triceps and delts'),
+    ('Жим штанги стоя', 'Overhead Press', 'Standing press for the delts and the core stabilizers'),
+    ('Тяга штанги в наклоне', 'Barbell Row', 'Bent-over row for the lats and rear delts'),
+    ('Сгибание рук со штангой', 'Barbell Curl', 'Isolation exercise for the biceps'),
+    ('Французский жим', 'Skull Crusher', 'Isolation exercise for the triceps'),
+    ('Подъемы на носки', 'Calf Raise', 'Exercise for the calves'),
+    ('Планка', 'Plank', 'Isometric hold that strengthens the core'),
+    ('Скручивания', 'Crunch', 'Exercise for the rectus abdominis'),
+    ('Бег', 'Running', 'Cardio for endurance and burning calories'),
+    ('Быстрая ходьба', 'Brisk Walking', 'Low-intensity cardio suitable for beginners'),
+    ('Велосипед', 'Cycling', 'Cardio on a bike or a stationary bike'),
+    ('Эллиптический тренажер', 'Elliptical Trainer', 'Cardio on an elliptical machine'),
+    ('Плавание', 'Swimming', 'Full-body cardio that works every muscle group'),
+    ('Гребля', 'Rowing', 'Cardio on a rowing machine'),
+    ('Степпер', 'Stair Stepper', 'Cardio that simulates climbing stairs'),
+    ('Прыжки на скакалке', 'Jump Rope', 'High-intensity cardio that builds coordination'),
+    ('HIIT тренировка', 'HIIT Workout', 'High-intensity interval training'),
+    ('Танцы', 'Dancing', 'Cardio in the form of dance'),
+    ('Подтягивания в гравитроне', 'Assisted Pull-up', 'Pull-ups with a counterweight for those who cannot do them unassisted yet'),
+    ('Фермерская прогулка', 'Farmer''s Walk', 'Walking with heavy dumbbells for grip, traps and core'),
+    ('Растяжка задней поверхности бедра', 'Hamstring Stretch', 'Static stretch for the hamstrings'),
+    ('Мобилизация плечевого сустава', 'Shoulder Mobility Drill', 'Shoulder mobility work before pressing')
+) AS v(name, en_name, en_description)
+JOIN exercises e ON e.name = v.name
+ON CONFLICT DO NOTHING;
+
+-- язык, выбранный в профиле, важнее заголовка Accept-Language
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(10) CHECK (locale IN ('en', 'ru'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
+DROP INDEX IF EXISTS idx_exercise_translations_search;
+DROP TABLE IF EXISTS exercise_translations;
+-- +goose StatementEnd
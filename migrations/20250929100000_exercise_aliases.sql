@@ -0,0 +1,98 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- альтернативные названия упражнений: сокращения, жаргон, варианты написания;
+-- normalized хранит название в нижнем регистре, с «е» вместо «ё» и без
+-- знаков препинания, так же как его нормализует сервис; встроенные названия
+-- (created_by IS NULL) видны всем, добавленные пользователем — только ему
+CREATE TABLE IF NOT EXISTS exercise_aliases (
+    id int generated always as identity primary key,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id) ON DELETE CASCADE,
+    alias VARCHAR(100) NOT NULL,
+    normalized VARCHAR(100) NOT NULL,
+    created_by INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp not null default now(),
+    search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', alias) || to_tsvector('english', alias), 'A')
+    ) STORED
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_exercise_aliases_unique ON exercise_aliases(exercise_id, normalized, coalesce(created_by, 0));
+
+CREATE INDEX IF NOT EXISTS idx_exercise_aliases_normalized ON exercise_aliases USING GIN (normalized gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_exercise_aliases_search ON exercise_aliases USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_exercise_translations_name_trgm ON exercise_translations USING GIN (lower(name) gin_trgm_ops);
+
+INSERT INTO exercise_aliases (exercise_id, alias, normalized)
+SELECT e.id, v.alias, replace(v.alias, 'ё', 'е')
+FROM (VALUES
+    ('Жим лежа', 'bench'),
+    ('Жим лежа', 'bp'),
+    ('Жим лежа', 'жим лёжа'),
+    ('Жим лежа', 'жим штанги лежа'),
+    ('Приседания со штангой', 'squat'),
+    ('Приседания со штангой', 'back squat'),
+    ('Приседания со штангой', 'присед'),
+    ('Приседания со штангой', 'приседания'),
+    ('Становая тяга', 'dl'),
+    ('Становая тяга', 'conventional deadlift'),
+    ('Становая тяга', 'становая'),
+    ('Подтягивания', 'pullup'),
+    ('Подтягивания', 'подтягивание'),
+    ('Отжимания', 'pushup'),
+    ('Отжимания', 'press up'),
+    ('Отжимания', 'отжимание'),
+    ('Жим штанги стоя', 'ohp'),
+    ('Жим штанги стоя', 'military press'),
+    ('Жим штанги стоя', 'shoulder press'),
+    ('Жим штанги стоя', 'армейский жим'),
+    ('Тяга штанги в наклоне', 'bent over row'),
+    ('Тяга штанги в наклоне', 'тяга в наклоне'),
+    ('Сгибание рук со штангой', 'biceps curl'),
+    ('Сгибание рук со штангой', 'подъем штанги на бицепс'),
+    ('Французский жим', 'french press'),
+    ('Французский жим', 'lying triceps extension'),
+    ('Подъемы на носки', 'calf raises'),
+    ('Подъемы на носки', 'подъем на носки'),
+    ('Скручивания', 'crunches'),
+    ('Скручивания', 'sit up'),
+    ('Бег', 'run'),
+    ('Бег', 'jogging'),
+    ('Бег', 'пробежка'),
+    ('Быстрая ходьба', 'walking'),
+    ('Быстрая ходьба', 'ходьба'),
+    ('Велосипед', 'bike'),
+    ('Велосипед', 'велотренажер'),
+    ('Эллиптический тренажер', 'elliptical'),
+    ('Эллиптический тренажер', 'эллипс'),
+    ('Плавание', 'swim'),
+    ('Плавание', 'бассейн'),
+    ('Гребля', 'rowing machine'),
+    ('Гребля', 'гребной тренажер'),
+    ('Степпер', 'stairmaster'),
+    ('Прыжки на скакалке', 'skipping'),
+    ('Прыжки на скакалке', 'скакалка'),
+    ('HIIT тренировка', 'hiit'),
+    ('HIIT тренировка', 'интервальная тренировка'),
+    ('Танцы', 'dance'),
+    ('Подтягивания в гравитроне', 'gravitron'),
+    ('Подтягивания в гравитроне', 'гравитрон'),
+    ('Фермерская прогулка', 'farmers walk'),
+    ('Фермерская прогулка', 'farmers carry'),
+    ('Растяжка задней поверхности бедра', 'растяжка бицепса бедра'),
+    ('Мобилизация плечевого сустава', 'shoulder mobility'),
+    ('Мобилизация плечевого сустава', 'мобилизация плеч')
+) AS v(name, alias)
+JOIN exercises e ON e.name = v.name
+ON CONFLICT DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_exercise_translations_name_trgm;
+DROP INDEX IF EXISTS idx_exercise_aliases_search;
+DROP INDEX IF EXISTS idx_exercise_aliases_normalized;
+DROP INDEX IF EXISTS idx_exercise_aliases_unique;
+DROP TABLE IF EXISTS exercise_aliases;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- оборудование, доступное пользователю; без записей считается, что доступно любое
+CREATE TABLE IF NOT EXISTS user_equipment (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    equipment_id INTEGER NOT NULL REFERENCES equipment(id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, equipment_id)
+);
+
+-- замены упражнений в запланированных сессиях записи на программу; сама
+-- программа может быть общей, поэтому замена хранится отдельно, а подходы,
+-- повторения и нагрузка берутся из исходного упражнения программы
+CREATE TABLE IF NOT EXISTS enrollment_substitutions (
+    enrollment_id INTEGER NOT NULL REFERENCES program_enrollments(id) ON DELETE CASCADE,
+    program_exercise_id INTEGER NOT NULL REFERENCES program_exercises(id) ON DELETE CASCADE,
+    exercise_id INTEGER NOT NULL REFERENCES exercises(id),
+    PRIMARY KEY (enrollment_id, program_exercise_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS enrollment_substitutions;
+DROP TABLE IF EXISTS user_equipment;
+-- +goose StatementEnd
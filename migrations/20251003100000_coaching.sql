@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+-- связи тренера со спортсменом; тренер приглашает спортсмена по адресу почты,
+-- связь действует после того, как спортсмен примет приглашение. До этого
+-- athlete_id не заполнен, чтобы тренер не узнал, зарегистрирован ли адрес.
+-- Уровни доступа к тренировкам спортсмена вложены: view - просмотр истории,
+-- comment - ещё и комментарии, assign - ещё и назначение тренировок и программ
+CREATE TABLE IF NOT EXISTS coach_athletes (
+    id int generated always as identity primary key,
+    coach_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    athlete_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    permission TEXT NOT NULL CHECK (permission IN ('view', 'comment', 'assign')),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'active')),
+    created_at timestamp not null default now(),
+    accepted_at timestamp,
+    UNIQUE (coach_id, athlete_id),
+    UNIQUE (coach_id, email),
+    CHECK (coach_id <> athlete_id),
+    CHECK ((status = 'active') = (athlete_id IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_coach_athletes_athlete ON coach_athletes(athlete_id);
+CREATE INDEX IF NOT EXISTS idx_coach_athletes_email ON coach_athletes(email) WHERE status = 'pending';
+
+-- тренер, назначивший тренировку; он может дополнять её, пока спортсмен
+-- её не начал
+ALTER TABLE workouts ADD COLUMN IF NOT EXISTS assigned_by INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+-- подходы, запланированные тренером; они не считаются выполненными, пока
+-- спортсмен не запишет их в тренировке
+ALTER TABLE workout_exercises ADD COLUMN IF NOT EXISTS is_planned BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS workout_comments (
+    id int generated always as identity primary key,
+    workout_id INTEGER NOT NULL REFERENCES workouts(id) ON DELETE CASCADE,
+    author_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at timestamp not null default now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_workout_comments_workout ON workout_comments(workout_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS workout_comments;
+ALTER TABLE workout_exercises DROP COLUMN IF EXISTS is_planned;
+ALTER TABLE workouts DROP COLUMN IF EXISTS assigned_by;
+DROP TABLE IF EXISTS coach_athletes;
+-- +goose StatementEnd
@@ -2,8 +2,13 @@
 let authToken = localStorage.getItem('auth_token');
 let currentUser = JSON.parse(localStorage.getItem('current_user') || '{}');
 let currentWorkoutId = null;
+let currentWorkoutUuid = null;
 let exercises = [];
 
+// Офлайн-очередь: изменения, сделанные без сети, отправляются в /api/sync
+let syncQueue = JSON.parse(localStorage.getItem('sync_queue') || '[]');
+let syncCursor = localStorage.getItem('sync_cursor') || '';
+
 // API базовый URL
 const API_BASE = '/api';
 
@@ -29,18 +34,30 @@ function formatDate(dateString) {
     });
 }
 
+// С idempotencyKey запрос при обрыве связи повторяется один раз:
+// сервер вернёт сохранённый ответ, если первый запрос до него дошёл
 async function apiRequest(endpoint, options = {}) {
     const url = `${API_BASE}${endpoint}`;
+    const { idempotencyKey, ...fetchOptions } = options;
     const config = {
         headers: {
             'Content-Type': 'application/json',
-            ...(authToken && { 'Authorization': `Bearer ${authToken}` })
+            ...(authToken && { 'Authorization': `Bearer ${authToken}` }),
+            ...(idempotencyKey && { 'Idempotency-Key': idempotencyKey })
         },
-        ...options
+        ...fetchOptions
     };
 
     try {
-        const response = await fetch(url, config);
+        let response;
+        try {
+            response = await fetch(url, config);
+        } catch (error) {
+            if (!idempotencyKey || !isNetworkError(error)) {
+                throw error;
+            }
+            response = await fetch(url, config);
+        }
         const data = await response.json();
 
         if (!response.ok) {
@@ -54,6 +71,68 @@ async function apiRequest(endpoint, options = {}) {
     }
 }
 
+// Синхронизация
+function newUUID() {
+    if (crypto.randomUUID) {
+        return crypto.randomUUID();
+    }
+    return ([1e7] + -1e3 + -4e3 + -8e3 + -1e11).replace(/[018]/g, c =>
+        (c ^ crypto.getRandomValues(new Uint8Array(1))[0] & 15 >> c / 4).toString(16));
+}
+
+// fetch бросает TypeError, когда сервер недоступен
+function isNetworkError(error) {
+    return error instanceof TypeError;
+}
+
+function saveSyncQueue() {
+    localStorage.setItem('sync_queue', JSON.stringify(syncQueue));
+}
+
+function queueChange(entity, uuid, data) {
+    const change = {
+        entity,
+        op: 'upsert',
+        uuid,
+        client_updated_at: new Date().toISOString()
+    };
+    change[entity === 'workout' ? 'workout' : 'entry'] = data;
+    syncQueue.push(change);
+    saveSyncQueue();
+}
+
+async function flushSyncQueue() {
+    if (!authToken || !navigator.onLine) {
+        return;
+    }
+
+    const changes = syncQueue.slice();
+    try {
+        const result = await apiRequest('/sync', {
+            method: 'POST',
+            body: JSON.stringify({ cursor: syncCursor, changes })
+        });
+
+        syncQueue = syncQueue.slice(changes.length);
+        saveSyncQueue();
+        syncCursor = result.cursor;
+        localStorage.setItem('sync_cursor', syncCursor);
+
+        const rejected = result.results.filter(r => r.status === 'rejected');
+        if (rejected.length > 0) {
+            showAlert(`Не удалось синхронизировать изменений: ${rejected.length}`, 'error');
+        }
+        if (changes.length > 0) {
+            showAlert('Офлайн-изменения синхронизированы');
+            loadWorkouts();
+        }
+    } catch (error) {
+        console.error('Sync Error:', error);
+    }
+}
+
+window.addEventListener('online', flushSyncQueue);
+
 // Аутентификация
 function initAuth() {
     if (authToken && currentUser.id) {
@@ -131,8 +210,12 @@ function toggleAuthMode() {
 document.getElementById('logout-btn').addEventListener('click', () => {
     localStorage.removeItem('auth_token');
     localStorage.removeItem('current_user');
+    localStorage.removeItem('sync_queue');
+    localStorage.removeItem('sync_cursor');
     authToken = null;
     currentUser = {};
+    syncQueue = [];
+    syncCursor = '';
     showAuth();
 });
 
@@ -161,14 +244,15 @@ document.querySelectorAll('.tab').forEach(tab => {
 
 // Загрузка данных
 async function loadData() {
+    await flushSyncQueue();
     await loadWorkouts();
     await loadExercises();
 }
 
 async function loadWorkouts() {
     try {
-        const workouts = await apiRequest('/workouts');
-        displayWorkouts(workouts);
+        const page = await apiRequest('/workouts');
+        displayWorkouts(page.items);
     } catch (error) {
         document.getElementById('workouts-list').innerHTML = `<p>Ошибка загрузки: ${error.message}</p>`;
     }
@@ -176,6 +260,10 @@ async function loadWorkouts() {
 
 function displayWorkouts(workouts) {
     const container = document.getElementById('workouts-list');
+    const pending = syncQueue
+        .filter(c => c.entity === 'workout')
+        .map(c => ({ ...c.workout, pending: true }));
+    workouts = pending.concat(workouts || []);
 
     if (workouts.length === 0) {
         container.innerHTML = '<p>У вас пока нет тренировок. Создайте первую!</p>';
@@ -188,9 +276,12 @@ function displayWorkouts(workouts) {
                         <h4>${workout.name}</h4>
                         <p>${formatDate(workout.date)}</p>
                         ${workout.notes ? `<p><em>${workout.notes}</em></p>` : ''}
+                        ${(workout.tags || []).map(tag => `<span class="tag">${tag.name}</span>`).join(' ')}
                     </div>
                     <div>
-                        <button class="btn btn-success btn-small" onclick="viewWorkout(${workout.id})">Подробнее</button>
+                        ${workout.pending ?
+        '<span>Ожидает синхронизации</span>' :
+        `<button class="btn btn-success btn-small" onclick="viewWorkout(${workout.id})">Подробнее</button>`}
                     </div>
                 </div>
             `).join('');
@@ -199,8 +290,17 @@ function displayWorkouts(workouts) {
 async function loadExercises() {
     try {
         const type = document.getElementById('exercise-type-filter')?.value || '';
-        const url = type ? `/exercises?type=${type}` : '/exercises';
-        exercises = await apiRequest(url);
+        // каталог нужен целиком для выпадающих списков, поэтому идём по всем страницам
+        exercises = [];
+        let cursor = '';
+        do {
+            const params = new URLSearchParams({ limit: '200' });
+            if (type) params.set('type', type);
+            if (cursor) params.set('cursor', cursor);
+            const page = await apiRequest(`/exercises?${params}`);
+            exercises = exercises.concat(page.items);
+            cursor = page.next_cursor;
+        } while (cursor);
         displayExercises(exercises);
         updateExerciseSelects();
     } catch (error) {
@@ -208,6 +308,12 @@ async function loadExercises() {
     }
 }
 
+const exerciseTypeNames = {
+    strength: 'Силовое',
+    cardio: 'Кардио',
+    flexibility: 'Мобильность'
+};
+
 function displayExercises(exercisesList) {
     const container = document.getElementById('exercises-list');
 
@@ -233,7 +339,7 @@ function displayExercises(exercisesList) {
                                     <h5>${exercise.name}</h5>
                                     <p style="margin: 5px 0; color: #666;">${exercise.description}</p>
                                     <span style="background: ${exercise.type === 'strength' ? '#28a745' : '#17a2b8'}; color: white; padding: 3px 8px; border-radius: 12px; font-size: 12px;">
-                                        ${exercise.type === 'strength' ? 'Силовое' : 'Кардио'}
+                                        ${exerciseTypeNames[exercise.type] || exercise.type}
                                     </span>
                                 </div>
                             </div>
@@ -251,7 +357,7 @@ function updateExerciseSelects() {
         if (select) {
             const currentValue = select.value;
             select.innerHTML = '<option value="">Выберите упражнение</option>' +
-                exercises.map(ex => `<option value="${ex.id}" data-type="${ex.type}">${ex.name}</option>`).join('');
+                exercises.map(ex => `<option value="${ex.id}" data-type="${ex.type}" data-profile="${ex.tracking_profile || 'weight_reps'}">${ex.name}</option>`).join('');
             select.value = currentValue;
         }
     });
@@ -282,7 +388,8 @@ document.getElementById('workout-form').addEventListener('submit', async (e) =>
     try {
         await apiRequest('/workouts', {
             method: 'POST',
-            body: JSON.stringify(workoutData)
+            body: JSON.stringify(workoutData),
+            idempotencyKey: newUUID()
         });
 
         showAlert('Тренировка создана успешно!');
@@ -290,7 +397,16 @@ document.getElementById('workout-form').addEventListener('submit', async (e) =>
         document.getElementById('workout-form').reset();
         loadWorkouts();
     } catch (error) {
-        showAlert(error.message, 'error');
+        if (!isNetworkError(error)) {
+            showAlert(error.message, 'error');
+            return;
+        }
+
+        queueChange('workout', newUUID(), workoutData);
+        showAlert('Нет сети: тренировка сохранится при подключении');
+        document.getElementById('workout-modal').classList.remove('show');
+        document.getElementById('workout-form').reset();
+        displayWorkouts([]);
     }
 });
 
@@ -300,6 +416,7 @@ async function viewWorkout(workoutId) {
 
     try {
         const data = await apiRequest(`/workouts/${workoutId}`);
+        currentWorkoutUuid = data.workout.uuid;
         displayWorkoutDetails(data.workout, data.exercises);
     } catch (error) {
         showAlert(error.message, 'error');
@@ -331,14 +448,7 @@ function displayWorkoutDetails(workout, workoutExercises) {
                                 <div class="exercise-item">
                                     <h5>${we.exercise.name}</h5>
                                     <div class="exercise-stats">
-                                        ${we.exercise.type === 'strength' ? `
-                                            <div class="stat-item">Подходы: ${we.sets}</div>
-                                            <div class="stat-item">Повторения: ${we.reps}</div>
-                                            <div class="stat-item">Вес: ${we.weight} кг</div>
-                                        ` : `
-                                            <div class="stat-item">Время: ${Math.floor(we.duration / 60)} мин</div>
-                                            ${we.distance > 0 ? `<div class="stat-item">Расстояние: ${we.distance} км</div>` : ''}
-                                        `}
+                                        ${formatEntryMetrics(we)}
                                     </div>
                                 </div>
                             `).join('')
@@ -358,47 +468,89 @@ document.getElementById('close-exercise-modal').addEventListener('click', () =>
     document.getElementById('exercise-modal').classList.remove('show');
 });
 
-// Переключение полей в зависимости от типа упражнения
-document.getElementById('exercise-select').addEventListener('change', (e) => {
-    const selectedOption = e.target.options[e.target.selectedIndex];
-    const exerciseType = selectedOption.dataset.type;
+// Показатели, которыми записывается подход, по профилю учёта упражнения
+const trackedMetrics = {
+    weight_reps: ['reps', 'weight'],
+    reps: ['reps'],
+    time: ['duration'],
+    weight_time: ['weight', 'duration'],
+    distance_time: ['distance', 'duration'],
+    assisted: ['reps', 'weight']
+};
+
+function selectedProfile() {
+    const select = document.getElementById('exercise-select');
+    const option = select.options[select.selectedIndex];
+    return (option && option.dataset.profile) || 'weight_reps';
+}
 
-    if (exerciseType === 'cardio') {
-        document.getElementById('strength-fields').classList.add('hidden');
-        document.getElementById('cardio-fields').classList.remove('hidden');
-    } else {
-        document.getElementById('strength-fields').classList.remove('hidden');
-        document.getElementById('cardio-fields').classList.add('hidden');
-    }
+// Переключение полей в зависимости от профиля учёта упражнения
+document.getElementById('exercise-select').addEventListener('change', () => {
+    const profile = selectedProfile();
+    const metrics = trackedMetrics[profile];
+
+    ['reps', 'weight', 'duration', 'distance'].forEach(id => {
+        document.getElementById(id).closest('.form-group').classList.toggle('hidden', !metrics.includes(id));
+    });
+    document.getElementById('strength-fields').classList.remove('hidden');
+    document.getElementById('cardio-fields').classList.remove('hidden');
+    document.querySelector('label[for="weight"]').textContent = profile === 'assisted' ? 'Помощь (кг):' : 'Вес (кг):';
 });
 
+function formatEntryMetrics(we) {
+    const profile = we.exercise.tracking_profile || 'weight_reps';
+    const items = [`Подходы: ${we.sets}`];
+    if (we.reps > 0) items.push(`Повторения: ${we.reps}`);
+    if (profile === 'assisted') {
+        items.push(`Помощь: ${Math.abs(we.weight || 0)} кг`);
+    } else if (we.weight > 0) {
+        items.push(`Вес: ${we.weight} кг`);
+    }
+    if (we.duration > 0 && trackedMetrics[profile].includes('duration')) items.push(`Время: ${formatSeconds(we.duration)}`);
+    if (we.distance > 0) items.push(`Расстояние: ${we.distance} км`);
+    return items.map(item => `<div class="stat-item">${item}</div>`).join('');
+}
+
+function formatSeconds(seconds) {
+    const m = Math.floor(seconds / 60);
+    const s = seconds % 60;
+    return s > 0 ? `${m} мин ${s} с` : `${m} мин`;
+}
+
 document.getElementById('exercise-form').addEventListener('submit', async (e) => {
     e.preventDefault();
 
-    const selectedOption = document.getElementById('exercise-select').options[document.getElementById('exercise-select').selectedIndex];
-    const exerciseType = selectedOption.dataset.type;
+    const profile = selectedProfile();
+    const metrics = trackedMetrics[profile];
 
     const exerciseData = {
         exercise_id: parseInt(document.getElementById('exercise-select').value),
-        sets: parseInt(document.getElementById('sets').value)
+        sets: parseInt(document.getElementById('sets').value),
+        reps: 0,
+        weight: 0,
+        duration: 0,
+        distance: 0
     };
 
-    if (exerciseType === 'strength') {
+    if (metrics.includes('reps')) {
         exerciseData.reps = parseInt(document.getElementById('reps').value);
-        exerciseData.weight = parseFloat(document.getElementById('weight').value);
-        exerciseData.duration = 0;
-        exerciseData.distance = 0;
-    } else {
-        exerciseData.reps = 0;
-        exerciseData.weight = 0;
-        exerciseData.duration = parseInt(document.getElementById('duration').value) * 60; // в секундах
+    }
+    if (metrics.includes('weight')) {
+        const weight = parseFloat(document.getElementById('weight').value) || 0;
+        exerciseData.weight = profile === 'assisted' ? -weight : weight; // помощь хранится отрицательным весом
+    }
+    if (metrics.includes('duration')) {
+        exerciseData.duration = Math.round(parseFloat(document.getElementById('duration').value) * 60); // в секундах
+    }
+    if (metrics.includes('distance')) {
         exerciseData.distance = parseFloat(document.getElementById('distance').value);
     }
 
     try {
         await apiRequest(`/workouts/${currentWorkoutId}/exercises`, {
             method: 'POST',
-            body: JSON.stringify(exerciseData)
+            body: JSON.stringify(exerciseData),
+            idempotencyKey: newUUID()
         });
 
         showAlert('Упражнение добавлено!');
@@ -406,15 +558,23 @@ document.getElementById('exercise-form').addEventListener('submit', async (e) =>
         document.getElementById('exercise-form').reset();
         viewWorkout(currentWorkoutId); // Обновить детали тренировки
     } catch (error) {
-        showAlert(error.message, 'error');
+        if (!isNetworkError(error) || !currentWorkoutUuid) {
+            showAlert(error.message, 'error');
+            return;
+        }
+
+        queueChange('workout_exercise', newUUID(), { ...exerciseData, workout_uuid: currentWorkoutUuid });
+        showAlert('Нет сети: упражнение сохранится при подключении');
+        document.getElementById('exercise-modal').classList.remove('show');
+        document.getElementById('exercise-form').reset();
     }
 });
 
 // Личные рекорды
 async function loadPersonalRecords() {
     try {
-        const records = await apiRequest('/records');
-        displayPersonalRecords(records);
+        const data = await apiRequest('/records');
+        displayPersonalRecords(data.items);
     } catch (error) {
         document.getElementById('records-list').innerHTML = `<p>Ошибка загрузки: ${error.message}</p>`;
     }
@@ -434,16 +594,34 @@ function displayPersonalRecords(records) {
                         <div class="record-card">
                             <h4>${record.exercise.name}</h4>
                             <div style="font-size: 2em; margin: 10px 0;">
-                                ${record.weight}кг × ${record.reps}
+                                ${formatRecord(record)}
                             </div>
                             <p>Установлен: ${formatDate(record.date)}</p>
-                            <small>Расчетный 1RM: ${(record.weight * (1 + record.reps / 30)).toFixed(1)}кг</small>
+                            ${record.strength ? `<small>Расчетный 1RM: ${record.strength.estimated_1rm.toFixed(1)}кг</small>` : ''}
+                            ${record.strength && record.strength.level ? `<p>Уровень: ${record.strength.level}</p>` : ''}
                         </div>
                     `).join('')}
                 </div>
             `;
 }
 
+function formatRecord(record) {
+    switch (record.exercise.tracking_profile) {
+        case 'reps':
+            return `${record.reps} повт.`;
+        case 'time':
+            return formatSeconds(record.duration);
+        case 'weight_time':
+            return `${record.weight}кг × ${formatSeconds(record.duration)}`;
+        case 'distance_time':
+            return `${record.distance} км${record.duration > 0 ? ` за ${formatSeconds(record.duration)}` : ''}`;
+        case 'assisted':
+            return `${record.reps} повт. с помощью ${Math.abs(record.weight)}кг`;
+        default:
+            return `${record.weight}кг × ${record.reps}`;
+    }
+}
+
 // Прогресс
 async function loadProgressExercises() {
     updateExerciseSelects();